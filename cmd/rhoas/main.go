@@ -2,12 +2,17 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 
+	"github.com/redhat-developer/app-services-cli/pkg/cmdutil"
+	"github.com/redhat-developer/app-services-cli/pkg/common/clierr"
 	"github.com/redhat-developer/app-services-cli/pkg/doc"
+	"github.com/redhat-developer/app-services-cli/pkg/dump"
 	"github.com/redhat-developer/app-services-cli/pkg/localize"
 	"github.com/redhat-developer/app-services-cli/pkg/localize/goi18n"
+	"github.com/redhat-developer/app-services-cli/pkg/plugin"
 
 	"github.com/redhat-developer/app-services-cli/internal/build"
 
@@ -51,7 +56,12 @@ func main() {
 		os.Exit(0)
 	}
 
-	err = rootCmd.Execute()
+	executedCmd, err := rootCmd.ExecuteC()
+
+	// the flags have been parsed, so the logger now uses the logging options
+	if cmdLogger, loggerErr := cmdFactory.Logger(); loggerErr == nil {
+		logger = cmdLogger
+	}
 	if err == nil {
 		if debug.Enabled() {
 			build.CheckForUpdate(context.Background(), logger, localizer)
@@ -59,11 +69,37 @@ func main() {
 		return
 	}
 
-	if err != nil {
-		logger.Error(wrapErrorf(err, localizer))
-		build.CheckForUpdate(context.Background(), logger, localizer)
-		os.Exit(1)
+	// the plugin has reported its own error
+	var pluginErr *plugin.ExitError
+	if errors.As(err, &pluginErr) {
+		os.Exit(pluginErr.Code)
+	}
+
+	envelope := clierr.Describe(err)
+	if errorFormat(executedCmd) == clierr.JSONFormat {
+		if jsonErr := clierr.WriteJSON(cmdFactory.IOStreams.ErrOut, envelope); jsonErr != nil {
+			logger.Error(wrapErrorf(err, localizer))
+		}
+		os.Exit(envelope.ExitCode)
+	}
+
+	logger.Error(wrapErrorf(err, localizer))
+	build.CheckForUpdate(context.Background(), logger, localizer)
+	os.Exit(envelope.ExitCode)
+}
+
+// errorFormat returns the format of the error of the command,
+// which is JSON when the command prints its output as JSON or when set through the environment
+func errorFormat(cmd *cobra.Command) string {
+	if format := os.Getenv(clierr.FormatEnvName); format != "" {
+		return format
 	}
+	if cmd != nil {
+		if output := cmd.Flags().Lookup("output"); output != nil && output.Value.String() == dump.JSONFormat {
+			return clierr.JSONFormat
+		}
+	}
+	return ""
 }
 
 /**
@@ -77,6 +113,13 @@ func generateDocumentation(rootCommand *cobra.Command) {
 
 	rootCommand.DisableAutoGenTag = true
 
+	// the plugins found on the PATH of the machine are not documented
+	for _, cmd := range rootCommand.Commands() {
+		if cmdutil.IsPluginCommand(cmd) {
+			rootCommand.RemoveCommand(cmd)
+		}
+	}
+
 	linkHandler := func(s string) string { return s }
 
 	err := doc.GenAsciidocTreeCustom(rootCommand, "./docs/commands", filePrepender, linkHandler)
@@ -112,11 +155,11 @@ func initConfig(f *factory.Factory) error {
 		return err
 	}
 
-	cfgFile = &config.Config{}
-	if err := f.Config.Save(cfgFile); err != nil {
-		return err
-	}
-	return nil
+	// the file may have been created by another process in the meantime,
+	// in which case Update keeps its content
+	return f.Config.Update(func(*config.Config) error {
+		return nil
+	})
 }
 
 func wrapErrorf(err error, localizer localize.Localizer) error {
@@ -29,6 +29,9 @@ var _ IConfig = &IConfigMock{}
 //             SaveFunc: func(config *Config) error {
 // 	               panic("mock out the Save method")
 //             },
+//             UpdateFunc: func(fn func(config *Config) error) error {
+// 	               panic("mock out the Update method")
+//             },
 //         }
 //
 //         // use mockedIConfig in code that requires IConfig
@@ -48,6 +51,9 @@ type IConfigMock struct {
 	// SaveFunc mocks the Save method.
 	SaveFunc func(config *Config) error
 
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(fn func(config *Config) error) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// Load holds details about calls to the Load method.
@@ -61,11 +67,17 @@ type IConfigMock struct {
 			// Config is the config argument value.
 			Config *Config
 		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Fn is the fn argument value.
+			Fn func(config *Config) error
+		}
 	}
 	lockLoad     sync.RWMutex
 	lockLocation sync.RWMutex
 	lockRemove   sync.RWMutex
 	lockSave     sync.RWMutex
+	lockUpdate   sync.RWMutex
 }
 
 // Load calls LoadFunc.
@@ -167,3 +179,34 @@ func (mock *IConfigMock) SaveCalls() []struct {
 	mock.lockSave.RUnlock()
 	return calls
 }
+
+// Update calls UpdateFunc.
+func (mock *IConfigMock) Update(fn func(config *Config) error) error {
+	if mock.UpdateFunc == nil {
+		panic("IConfigMock.UpdateFunc: method is nil but IConfig.Update was just called")
+	}
+	callInfo := struct {
+		Fn func(config *Config) error
+	}{
+		Fn: fn,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	return mock.UpdateFunc(fn)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//     len(mockedIConfig.UpdateCalls())
+func (mock *IConfigMock) UpdateCalls() []struct {
+	Fn func(config *Config) error
+} {
+	var calls []struct {
+		Fn func(config *Config) error
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
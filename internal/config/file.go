@@ -15,8 +15,17 @@ func NewFile() IConfig {
 	return cfg
 }
 
+// NewFileAt creates a config type for the config file at the given path,
+// such as the config file of another environment
+func NewFileAt(path string) IConfig {
+	return &File{Path: path}
+}
+
 // File is a type which describes a config file
-type File struct{}
+type File struct {
+	// Path is the path of the config file. When empty, the default location is used
+	Path string
+}
 
 const errorFormat = "%v: %w"
 
@@ -50,30 +59,49 @@ func (c *File) Load() (*Config, error) {
 }
 
 // Save saves the given configuration to the configuration file.
+// The file is replaced atomically, so it is never left partially written.
 func (c *File) Save(cfg *Config) error {
 	file, err := c.Location()
 	if err != nil {
 		return err
 	}
-	data, err := json.MarshalIndent(cfg, "", "  ")
+	unlock, err := lock(file)
 	if err != nil {
-		return fmt.Errorf("%v: %w", "unable to marshal config", err)
+		return err
 	}
-	rhoasCfgDir, err := DefaultDir()
+	defer unlock()
+
+	return write(file, cfg)
+}
+
+// Update loads the configuration, applies fn to it and saves the result.
+// The configuration file is locked while it is updated, so concurrent updates are applied
+// one after the other instead of overwriting each other.
+// If the configuration file doesn't exist, fn is given an empty configuration.
+// Nothing is saved when fn returns an error.
+func (c *File) Update(fn func(cfg *Config) error) error {
+	file, err := c.Location()
 	if err != nil {
 		return err
 	}
-	if _, err = os.Stat(rhoasCfgDir); os.IsNotExist(err) {
-		err = os.Mkdir(rhoasCfgDir, 0o700)
-		if err != nil {
-			return err
-		}
-	}
-	err = ioutil.WriteFile(file, data, 0o600)
+	unlock, err := lock(file)
 	if err != nil {
-		return fmt.Errorf(errorFormat, "unable to save config", err)
+		return err
 	}
-	return nil
+	defer unlock()
+
+	cfg, err := c.Load()
+	if os.IsNotExist(err) {
+		cfg = &Config{}
+	} else if err != nil {
+		return err
+	}
+
+	if err = fn(cfg); err != nil {
+		return err
+	}
+
+	return write(file, cfg)
 }
 
 // Remove removes the configuration file.
@@ -95,6 +123,9 @@ func (c *File) Remove() error {
 
 // Location gets the path to the config file
 func (c *File) Location() (path string, err error) {
+	if c.Path != "" {
+		return c.Path, nil
+	}
 	if rhoasConfig := os.Getenv(EnvName); rhoasConfig != "" {
 		path = rhoasConfig
 	} else {
@@ -116,6 +147,84 @@ func HasCustomLocation() bool {
 	return rhoasConfig != ""
 }
 
+// lock takes an exclusive lock on the lock file of the config file,
+// blocking until it is released by other processes.
+// The returned function releases the lock
+func lock(file string) (unlock func(), err error) {
+	if err = createDir(file); err != nil {
+		return nil, err
+	}
+	// #nosec G304
+	lockFileHandle, err := os.OpenFile(file+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf(errorFormat, "unable to open config lock file", err)
+	}
+	if err = lockFile(lockFileHandle); err != nil {
+		lockFileHandle.Close()
+		return nil, fmt.Errorf(errorFormat, "unable to lock config file", err)
+	}
+
+	return func() {
+		_ = unlockFile(lockFileHandle)
+		lockFileHandle.Close()
+	}, nil
+}
+
+// write writes the configuration to a temporary file which then replaces the config file
+func write(file string, cfg *Config) (err error) {
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf(errorFormat, "unable to marshal config", err)
+	}
+	if err = createDir(file); err != nil {
+		return err
+	}
+
+	tmpFile, err := ioutil.TempFile(filepath.Dir(file), filepath.Base(file)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf(errorFormat, "unable to save config", err)
+	}
+	defer func() {
+		// the temporary file is removed when it could not replace the config file
+		if err != nil {
+			tmpFile.Close()
+			os.Remove(tmpFile.Name())
+		}
+	}()
+
+	if err = tmpFile.Chmod(0o600); err != nil {
+		return fmt.Errorf(errorFormat, "unable to save config", err)
+	}
+	if _, err = tmpFile.Write(data); err != nil {
+		return fmt.Errorf(errorFormat, "unable to save config", err)
+	}
+	if err = tmpFile.Sync(); err != nil {
+		return fmt.Errorf(errorFormat, "unable to save config", err)
+	}
+	if err = tmpFile.Close(); err != nil {
+		return fmt.Errorf(errorFormat, "unable to save config", err)
+	}
+	if err = os.Rename(tmpFile.Name(), file); err != nil {
+		return fmt.Errorf(errorFormat, "unable to save config", err)
+	}
+	return nil
+}
+
+// createDir creates the default config directory and the parent directory of the config file
+func createDir(file string) error {
+	rhoasCfgDir, err := DefaultDir()
+	if err != nil {
+		return err
+	}
+	if _, err = os.Stat(rhoasCfgDir); os.IsNotExist(err) {
+		err = os.Mkdir(rhoasCfgDir, 0o700)
+		if err != nil {
+			return err
+		}
+	}
+	return os.MkdirAll(filepath.Dir(file), 0o700)
+}
+
 // DefaultDir returns the default parent directory of the config file
 func DefaultDir() (string, error) {
 	userCfgDir, err := os.UserConfigDir()
@@ -0,0 +1,201 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// newTestFile returns a config file which is stored in a temporary directory
+func newTestFile(t *testing.T) (*File, string) {
+	dir, err := ioutil.TempDir("", "rhoas-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+
+	location := filepath.Join(dir, "rhoas", "config.json")
+	for name, value := range map[string]string{EnvName: location, "XDG_CONFIG_HOME": dir} {
+		previous, ok := os.LookupEnv(name)
+		os.Setenv(name, value)
+		name := name
+		t.Cleanup(func() {
+			if ok {
+				os.Setenv(name, previous)
+			} else {
+				os.Unsetenv(name)
+			}
+		})
+	}
+
+	return &File{}, location
+}
+
+func TestFile_UpdateCreatesConfig(t *testing.T) {
+	file, location := newTestFile(t)
+
+	err := file.Update(func(cfg *Config) error {
+		cfg.APIUrl = "https://api.openshift.com"
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	cfg, err := file.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.APIUrl != "https://api.openshift.com" {
+		t.Errorf("APIUrl = %v, want https://api.openshift.com", cfg.APIUrl)
+	}
+
+	info, err := os.Stat(location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("file mode = %v, want %v", info.Mode().Perm(), os.FileMode(0o600))
+	}
+}
+
+func TestNewFileAt(t *testing.T) {
+	file, _ := newTestFile(t)
+	other := filepath.Join(t.TempDir(), "other.json")
+
+	if err := NewFileAt(other).Save(&Config{APIUrl: "https://api.stage.openshift.com"}); err != nil {
+		t.Fatal(err)
+	}
+
+	location, err := NewFileAt(other).Location()
+	if err != nil || location != other {
+		t.Errorf("Location() = %v, %v, want %v", location, err, other)
+	}
+	cfg, err := NewFileAt(other).Load()
+	if err != nil || cfg.APIUrl != "https://api.stage.openshift.com" {
+		t.Errorf("Load() = %v, %v", cfg, err)
+	}
+	// the config file of the current environment is left alone
+	if _, err := file.Load(); !os.IsNotExist(err) {
+		t.Errorf("expected the default config file not to exist, got %v", err)
+	}
+}
+
+func TestFile_UpdateErrorDoesNotSave(t *testing.T) {
+	file, _ := newTestFile(t)
+
+	if err := file.Save(&Config{APIUrl: "https://api.openshift.com"}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	updateErr := errors.New("invalid")
+	err := file.Update(func(cfg *Config) error {
+		cfg.APIUrl = "https://api.stage.openshift.com"
+		return updateErr
+	})
+	if !errors.Is(err, updateErr) {
+		t.Errorf("Update() error = %v, want %v", err, updateErr)
+	}
+
+	cfg, err := file.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.APIUrl != "https://api.openshift.com" {
+		t.Errorf("APIUrl = %v, want the value before the update", cfg.APIUrl)
+	}
+}
+
+func TestFile_ConcurrentUpdates(t *testing.T) {
+	file, location := newTestFile(t)
+
+	const updates = 50
+
+	var wg sync.WaitGroup
+	errs := make(chan error, updates)
+	for i := 0; i < updates; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- file.Update(func(cfg *Config) error {
+				cfg.Scopes = append(cfg.Scopes, fmt.Sprintf("scope-%v", i))
+				return nil
+			})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+	}
+
+	cfg, err := file.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	// every update is applied on top of the previous one, so none of them is lost
+	if len(cfg.Scopes) != updates {
+		t.Errorf("got %v scopes, want %v", len(cfg.Scopes), updates)
+	}
+
+	// no temporary file is left behind
+	entries, err := ioutil.ReadDir(filepath.Dir(location))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if entry.Name() != "config.json" && entry.Name() != "config.json.lock" {
+			t.Errorf("unexpected file %v", entry.Name())
+		}
+	}
+}
+
+func TestFile_LoadDuringSave(t *testing.T) {
+	file, _ := newTestFile(t)
+
+	if err := file.Save(&Config{}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	done := make(chan struct{})
+	saveErr := make(chan error, 1)
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			scopes := make([]string, i)
+			for j := range scopes {
+				scopes[j] = fmt.Sprintf("scope-%v", j)
+			}
+			if err := file.Save(&Config{Scopes: scopes}); err != nil {
+				saveErr <- err
+				return
+			}
+		}
+	}()
+
+	// the file is replaced atomically, so readers never see a partially written file
+	for {
+		select {
+		case <-done:
+			select {
+			case err := <-saveErr:
+				t.Fatalf("Save() error = %v", err)
+			default:
+			}
+			return
+		default:
+		}
+		if _, err := file.Load(); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+	}
+}
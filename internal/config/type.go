@@ -6,24 +6,31 @@ package config
 type IConfig interface {
 	Load() (*Config, error)
 	Save(config *Config) error
+	Update(fn func(config *Config) error) error
 	Remove() error
 	Location() (string, error)
 }
 
 // Config is a type which describes the properties which can be in the config
 type Config struct {
-	AccessToken       string           `json:"access_token,omitempty" doc:"Bearer access token."`
-	RefreshToken      string           `json:"refresh_token,omitempty" doc:"Offline or refresh token."`
-	MasAuthURL        string           `json:"mas_auth_url,omitempty"`
-	MasAccessToken    string           `json:"mas_access_token,omitempty"`
-	MasRefreshToken   string           `json:"mas_refresh_token,omitempty"`
-	Services          ServiceConfigMap `json:"services,omitempty"`
-	APIUrl            string           `json:"api_url,omitempty" doc:"URL of the API gateway. The value can be the complete URL or an alias. The valid aliases are 'production', 'staging' and 'integration'."`
-	AuthURL           string           `json:"auth_url,omitempty" doc:"URL of the authentication server"`
-	ClientID          string           `json:"client_id,omitempty" doc:"OpenID client identifier."`
-	Insecure          bool             `json:"insecure,omitempty" doc:"Enables insecure communication with the server. This disables verification of TLS certificates and host names."`
-	Scopes            []string         `json:"scopes,omitempty" doc:"OpenID scope. If this option is used it will replace completely the default scopes. Can be repeated multiple times to specify multiple scopes."`
-	DevPreviewEnabled bool             `json:"dev_preview_enabled,omitempty" doc:"Enables Developer preview commands"`
+	AccessToken       string              `json:"access_token,omitempty" doc:"Bearer access token."`
+	RefreshToken      string              `json:"refresh_token,omitempty" doc:"Offline or refresh token."`
+	MasAuthURL        string              `json:"mas_auth_url,omitempty"`
+	MasAccessToken    string              `json:"mas_access_token,omitempty"`
+	MasRefreshToken   string              `json:"mas_refresh_token,omitempty"`
+	Services          ServiceConfigMap    `json:"services,omitempty"`
+	APIUrl            string              `json:"api_url,omitempty" doc:"URL of the API gateway. The value can be the complete URL or an alias. The valid aliases are 'production', 'staging' and 'integration'."`
+	AuthURL           string              `json:"auth_url,omitempty" doc:"URL of the authentication server"`
+	ClientID          string              `json:"client_id,omitempty" doc:"OpenID client identifier."`
+	Insecure          bool                `json:"insecure,omitempty" doc:"Enables insecure communication with the server. This disables verification of TLS certificates and host names."`
+	CAFile            string              `json:"ca_file,omitempty" doc:"Path to a PEM encoded bundle of certificate authorities which are trusted in addition to the system ones."`
+	CertFile          string              `json:"cert_file,omitempty" doc:"Path to a PEM encoded client certificate used for mutual TLS."`
+	KeyFile           string              `json:"key_file,omitempty" doc:"Path to the PEM encoded private key of the client certificate."`
+	Scopes            []string            `json:"scopes,omitempty" doc:"OpenID scope. If this option is used it will replace completely the default scopes. Can be repeated multiple times to specify multiple scopes."`
+	MaxRetries        *int                `json:"max_retries,omitempty" doc:"Number of times API requests which failed because of a transient error are retried. Set it to 0 to disable retries."`
+	DevPreviewEnabled bool                `json:"dev_preview_enabled,omitempty" doc:"Enables Developer preview commands"`
+	Profile           string              `json:"profile,omitempty" doc:"Active profile. When set to 'local', commands use the local development environment."`
+	LocalProfile      *LocalProfileConfig `json:"local_profile,omitempty" doc:"Local development environment created by 'rhoas dev init'"`
 }
 
 // ServiceConfigMap is a map of configs for the application services
@@ -42,6 +49,59 @@ type ServiceRegistryConfig struct {
 	Name       string `json:"name"`
 }
 
+// LocalProfile is the name of the profile which uses the local development environment
+const LocalProfile = "local"
+
+// LocalInstanceID is the ID of the Kafka and Service Registry instances of the local profile
+const LocalInstanceID = "local"
+
+// LocalProfileConfig describes the local development environment
+type LocalProfileConfig struct {
+	BootstrapServerHost string `json:"bootstrap_server_host"`
+	AdminAPIURL         string `json:"admin_api_url"`
+	RegistryURL         string `json:"registry_url,omitempty"`
+	ComposeFile         string `json:"compose_file,omitempty"`
+	// CloudServices are the services which were used before the local profile was activated
+	CloudServices *ServiceConfigMap `json:"cloud_services,omitempty"`
+}
+
+// IsLocalProfile returns true when the local development environment is used
+func (c *Config) IsLocalProfile() bool {
+	return c.Profile == LocalProfile && c.LocalProfile != nil
+}
+
+// UseLocalProfile activates the local profile and selects its instances.
+// The services selected beforehand are kept, so that they are restored by UseCloudProfile
+func (c *Config) UseLocalProfile(local *LocalProfileConfig) {
+	if c.IsLocalProfile() {
+		local.CloudServices = c.LocalProfile.CloudServices
+	} else {
+		services := c.Services
+		local.CloudServices = &services
+	}
+
+	c.Profile = LocalProfile
+	c.LocalProfile = local
+	c.Services = ServiceConfigMap{
+		Kafka:           &KafkaConfig{ClusterID: LocalInstanceID},
+		ServiceRegistry: &ServiceRegistryConfig{InstanceID: LocalInstanceID, Name: LocalInstanceID},
+	}
+}
+
+// UseCloudProfile deactivates the local profile and restores the services selected before it was activated
+func (c *Config) UseCloudProfile() {
+	if !c.IsLocalProfile() {
+		return
+	}
+
+	c.Services = ServiceConfigMap{}
+	if c.LocalProfile.CloudServices != nil {
+		c.Services = *c.LocalProfile.CloudServices
+		c.LocalProfile.CloudServices = nil
+	}
+	c.Profile = ""
+}
+
 func (c *Config) HasKafka() bool {
 	return c.Services.Kafka != nil &&
 		c.Services.Kafka.ClusterID != ""
@@ -0,0 +1,36 @@
+package config
+
+import "testing"
+
+func TestLocalProfile(t *testing.T) {
+	cfg := &Config{
+		Services: ServiceConfigMap{
+			Kafka: &KafkaConfig{ClusterID: "cloud-kafka"},
+		},
+	}
+
+	cfg.UseLocalProfile(&LocalProfileConfig{BootstrapServerHost: "localhost:9092"})
+	if !cfg.IsLocalProfile() {
+		t.Fatal("expected the local profile to be active")
+	}
+	if cfg.Services.Kafka.ClusterID != LocalInstanceID {
+		t.Errorf("Kafka = %v, want %v", cfg.Services.Kafka.ClusterID, LocalInstanceID)
+	}
+
+	// activating the local profile again keeps the cloud services
+	cfg.UseLocalProfile(&LocalProfileConfig{BootstrapServerHost: "localhost:19092"})
+	if cfg.LocalProfile.BootstrapServerHost != "localhost:19092" {
+		t.Errorf("BootstrapServerHost = %v, want localhost:19092", cfg.LocalProfile.BootstrapServerHost)
+	}
+
+	cfg.UseCloudProfile()
+	if cfg.IsLocalProfile() {
+		t.Fatal("expected the local profile to be inactive")
+	}
+	if !cfg.HasKafka() || cfg.Services.Kafka.ClusterID != "cloud-kafka" {
+		t.Errorf("Kafka = %v, want cloud-kafka", cfg.Services.Kafka)
+	}
+	if cfg.HasServiceRegistry() {
+		t.Errorf("expected no Service Registry to be restored, got %v", cfg.Services.ServiceRegistry)
+	}
+}
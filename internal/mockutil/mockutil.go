@@ -23,6 +23,12 @@ func NewConfigMock(cfg *config.Config) config.IConfig {
 			cfg = c
 			return nil
 		},
+		UpdateFunc: func(fn func(c *config.Config) error) error {
+			if cfg == nil {
+				cfg = &config.Config{}
+			}
+			return fn(cfg)
+		},
 		RemoveFunc: func() error {
 			cfg = nil
 			return nil
@@ -50,17 +56,13 @@ func NewConnectionMock(conn *connection.KeycloakConnection, apiClient *kafkamgmt
 				return errors.New("")
 			}
 
-			cfg, err := conn.Config.Load()
-			if err != nil {
-				return err
-			}
-
-			cfg.AccessToken = ""
-			cfg.RefreshToken = ""
-			cfg.MasAccessToken = ""
-			cfg.MasRefreshToken = ""
-
-			return conn.Config.Save(cfg)
+			return conn.Config.Update(func(cfg *config.Config) error {
+				cfg.AccessToken = ""
+				cfg.RefreshToken = ""
+				cfg.MasAccessToken = ""
+				cfg.MasRefreshToken = ""
+				return nil
+			})
 		},
 		APIFunc: func() *api.API {
 			a := &api.API{
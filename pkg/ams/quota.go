@@ -0,0 +1,148 @@
+package ams
+
+import (
+	"context"
+	"errors"
+
+	"github.com/redhat-developer/app-services-cli/pkg/api/ams/amsclient"
+	"github.com/redhat-developer/app-services-cli/pkg/connection"
+	"github.com/redhat-developer/app-services-cli/pkg/localize"
+)
+
+// Products of the quota of the services
+const (
+	ProductKafkaStandard   = "RHOSAK"
+	ProductKafkaEvaluation = "RHOSAKTrial"
+	ProductServiceRegistry = "RHOSR"
+)
+
+// Services the quota applies to
+const (
+	ServiceKafka           = "kafka"
+	ServiceServiceRegistry = "service-registry"
+)
+
+// Types of instances
+const (
+	InstanceTypeStandard   = "standard"
+	InstanceTypeEvaluation = "evaluation"
+)
+
+// Quota is the quota of the organization for a type of instances of a service
+type Quota struct {
+	Service      string `json:"service" yaml:"service"`
+	InstanceType string `json:"instance_type" yaml:"instance_type"`
+	QuotaID      string `json:"quota_id" yaml:"quota_id"`
+	Allowed      int    `json:"allowed" yaml:"allowed"`
+	Consumed     int    `json:"consumed" yaml:"consumed"`
+}
+
+// Remaining returns the number of instances which can still be created with the quota
+func (q *Quota) Remaining() int {
+	if q.Consumed >= q.Allowed {
+		return 0
+	}
+	return q.Allowed - q.Consumed
+}
+
+// GetOrganizationID returns the ID of the organization of the current account
+func GetOrganizationID(conn connection.Connection) (string, error) {
+	account, _, err := conn.API().AccountMgmt().
+		ApiAccountsMgmtV1CurrentAccountGet(context.Background()).
+		Execute()
+	if err != nil {
+		return "", err
+	}
+
+	if account.Organization != nil && account.Organization.GetId() != "" {
+		return account.Organization.GetId(), nil
+	}
+	if account.GetOrganizationId() != "" {
+		return account.GetOrganizationId(), nil
+	}
+	return "", errors.New("the current account has no organization")
+}
+
+// GetQuotas returns the quota of the organization of the current account for the application services
+func GetQuotas(conn connection.Connection) ([]Quota, error) {
+	orgID, err := GetOrganizationID(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	quotaCosts, _, err := conn.API().AccountMgmt().
+		ApiAccountsMgmtV1OrganizationsOrgIdQuotaCostGet(context.Background(), orgID).
+		FetchRelatedResources(true).
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	return mapQuotaCosts(quotaCosts.GetItems()), nil
+}
+
+// mapQuotaCosts returns the quota of the application services among the quota costs,
+// which are identified by the product of their related resources
+func mapQuotaCosts(quotaCosts []amsclient.QuotaCost) []Quota {
+	quotas := []Quota{}
+	for _, quotaCost := range quotaCosts {
+		for _, resource := range quotaCost.GetRelatedResources() {
+			q := Quota{
+				QuotaID:  quotaCost.GetQuotaId(),
+				Allowed:  int(quotaCost.GetAllowed()),
+				Consumed: int(quotaCost.GetConsumed()),
+			}
+			switch resource.GetProduct() {
+			case ProductKafkaStandard:
+				q.Service, q.InstanceType = ServiceKafka, InstanceTypeStandard
+			case ProductKafkaEvaluation:
+				q.Service, q.InstanceType = ServiceKafka, InstanceTypeEvaluation
+			case ProductServiceRegistry:
+				q.Service, q.InstanceType = ServiceServiceRegistry, InstanceTypeStandard
+			default:
+				continue
+			}
+			quotas = append(quotas, q)
+			break
+		}
+	}
+	return quotas
+}
+
+// FindQuota returns the quota for a type of instances of a service, or nil if the organization has none
+func FindQuota(quotas []Quota, service string, instanceType string) *Quota {
+	for i := range quotas {
+		if quotas[i].Service == service && quotas[i].InstanceType == instanceType {
+			return &quotas[i]
+		}
+	}
+	return nil
+}
+
+// KafkaInstanceType returns the type of the Kafka instance which can be provisioned with the quota:
+// a standard instance when the standard quota has not all been consumed, and an evaluation instance otherwise
+func KafkaInstanceType(quotas []Quota) string {
+	if q := FindQuota(quotas, ServiceKafka, InstanceTypeStandard); q != nil && q.Remaining() > 0 {
+		return InstanceTypeStandard
+	}
+	return InstanceTypeEvaluation
+}
+
+// DescribeKafkaInstanceType explains which type of Kafka instance can be provisioned with the quota
+func DescribeKafkaInstanceType(localizer localize.Localizer, quotas []Quota) string {
+	q := FindQuota(quotas, ServiceKafka, InstanceTypeStandard)
+	switch {
+	case q == nil || q.Allowed == 0:
+		return localizer.MustLocalize("quota.log.info.kafkaEvaluation")
+	case q.Remaining() == 0:
+		return localizer.MustLocalize("quota.log.info.kafkaStandardConsumed",
+			localize.NewEntry("Consumed", q.Consumed),
+			localize.NewEntry("Allowed", q.Allowed),
+		)
+	default:
+		return localizer.MustLocalize("quota.log.info.kafkaStandard",
+			localize.NewEntry("Remaining", q.Remaining()),
+			localize.NewEntry("Allowed", q.Allowed),
+		)
+	}
+}
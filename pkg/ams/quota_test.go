@@ -0,0 +1,75 @@
+package ams
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/redhat-developer/app-services-cli/pkg/api/ams/amsclient"
+)
+
+func newQuotaCost(quotaID string, allowed int32, consumed int32, products ...string) amsclient.QuotaCost {
+	resources := []amsclient.RelatedResource{}
+	for _, product := range products {
+		resources = append(resources, amsclient.RelatedResource{Product: product})
+	}
+	return amsclient.QuotaCost{
+		QuotaId:          quotaID,
+		Allowed:          allowed,
+		Consumed:         consumed,
+		RelatedResources: &resources,
+	}
+}
+
+func TestMapQuotaCosts(t *testing.T) {
+	got := mapQuotaCosts([]amsclient.QuotaCost{
+		newQuotaCost("cluster|rhinfra|rhosak|marketplace", 5, 2, "RHOSAK"),
+		newQuotaCost("cluster|rhinfra|rhosaktrial|marketplace", 1, 0, "RHOSAKTrial"),
+		newQuotaCost("cluster|rhinfra|rhosr|marketplace", 3, 3, "RHOSR"),
+		newQuotaCost("cluster|byoc|osd", 10, 1, "OSD"),
+		newQuotaCost("cluster|rhinfra|none", 10, 1),
+	})
+	want := []Quota{
+		{Service: ServiceKafka, InstanceType: InstanceTypeStandard, QuotaID: "cluster|rhinfra|rhosak|marketplace", Allowed: 5, Consumed: 2},
+		{Service: ServiceKafka, InstanceType: InstanceTypeEvaluation, QuotaID: "cluster|rhinfra|rhosaktrial|marketplace", Allowed: 1, Consumed: 0},
+		{Service: ServiceServiceRegistry, InstanceType: InstanceTypeStandard, QuotaID: "cluster|rhinfra|rhosr|marketplace", Allowed: 3, Consumed: 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mapQuotaCosts() = %+v, want %+v", got, want)
+	}
+}
+
+func TestKafkaInstanceType(t *testing.T) {
+	tests := []struct {
+		name   string
+		quotas []Quota
+		want   string
+	}{
+		{
+			name:   "no quota",
+			quotas: []Quota{},
+			want:   InstanceTypeEvaluation,
+		},
+		{
+			name:   "standard quota remaining",
+			quotas: []Quota{{Service: ServiceKafka, InstanceType: InstanceTypeStandard, Allowed: 2, Consumed: 1}},
+			want:   InstanceTypeStandard,
+		},
+		{
+			name:   "standard quota consumed",
+			quotas: []Quota{{Service: ServiceKafka, InstanceType: InstanceTypeStandard, Allowed: 2, Consumed: 2}},
+			want:   InstanceTypeEvaluation,
+		},
+		{
+			name:   "only Service Registry quota",
+			quotas: []Quota{{Service: ServiceServiceRegistry, InstanceType: InstanceTypeStandard, Allowed: 2}},
+			want:   InstanceTypeEvaluation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KafkaInstanceType(tt.quotas); got != tt.want {
+				t.Errorf("KafkaInstanceType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+)
+
+// the CLI calls the admin API of Kafka instances on localhost over HTTP under this path
+const adminPath = "/data/kafka"
+
+// default configuration of the topics, which is overridden by the configuration set when creating or updating them
+var defaultTopicConfig = map[string]string{
+	"cleanup.policy":  "delete",
+	"retention.bytes": "-1",
+	"retention.ms":    "604800000",
+}
+
+// adminServer is the admin API of a Kafka instance
+type adminServer struct {
+	// host is the address of the admin API, in the host:port format
+	host string
+
+	server *httptest.Server
+
+	mu             sync.Mutex
+	topics         map[string]*topic
+	consumerGroups map[string]kafkainstanceclient.ConsumerGroup
+}
+
+type topic struct {
+	partitions int32
+	config     map[string]string
+}
+
+func newAdminServer() (*adminServer, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	a := &adminServer{
+		host:           fmt.Sprintf("localhost:%v", listener.Addr().(*net.TCPAddr).Port),
+		topics:         map[string]*topic{},
+		consumerGroups: map[string]kafkainstanceclient.ConsumerGroup{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(adminPath+"/topics", a.handleTopics)
+	mux.HandleFunc(adminPath+"/topics/", a.handleTopics)
+	mux.HandleFunc(adminPath+"/consumer-groups", a.handleConsumerGroups)
+	mux.HandleFunc(adminPath+"/consumer-groups/", a.handleConsumerGroups)
+
+	a.server = &httptest.Server{
+		Listener: listener,
+		Config: &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+				writeAdminError(w, http.StatusUnauthorized, "unauthorized")
+				return
+			}
+			mux.ServeHTTP(w, r)
+		})},
+	}
+	a.server.Start()
+
+	return a, nil
+}
+
+// Close stops the admin API
+func (a *adminServer) Close() {
+	a.server.Close()
+}
+
+// AddConsumerGroup adds a consumer group to a Kafka instance, as consumer groups are created by
+// Kafka clients instead of the API. It returns false if there is no Kafka instance with this ID
+func (s *Server) AddConsumerGroup(kafkaID string, group kafkainstanceclient.ConsumerGroup) bool {
+	s.mu.Lock()
+	k := s.findKafka(kafkaID)
+	s.mu.Unlock()
+	if k == nil {
+		return false
+	}
+
+	k.admin.mu.Lock()
+	defer k.admin.mu.Unlock()
+	k.admin.consumerGroups[group.GroupId] = group
+	return true
+}
+
+type adminError struct {
+	Code         int    `json:"code"`
+	ErrorMessage string `json:"error_message"`
+}
+
+// writeAdminError writes an error in the format of the admin API
+func writeAdminError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, adminError{Code: status, ErrorMessage: message})
+}
+
+func (a *adminServer) handleTopics(w http.ResponseWriter, r *http.Request) {
+	parts := pathParts(r, adminPath+"/topics")
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		a.listTopics(w, r)
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		a.createTopic(w, r)
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		a.getTopic(w, parts[0])
+	case len(parts) == 1 && r.Method == http.MethodPatch:
+		a.updateTopic(w, r, parts[0])
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		if _, ok := a.topics[parts[0]]; !ok {
+			writeAdminError(w, http.StatusNotFound, "topic "+parts[0]+" does not exist")
+			return
+		}
+		delete(a.topics, parts[0])
+		w.WriteHeader(http.StatusOK)
+	default:
+		writeAdminError(w, http.StatusNotImplemented, "not implemented by the fake control plane")
+	}
+}
+
+func (a *adminServer) listTopics(w http.ResponseWriter, r *http.Request) {
+	filter := r.URL.Query().Get("filter")
+
+	names := []string{}
+	for name := range a.topics {
+		if strings.Contains(name, filter) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	pageNumber, start, end := page(r, len(names))
+	items := []kafkainstanceclient.Topic{}
+	for _, name := range names[start:end] {
+		items = append(items, a.topics[name].toTopic(name))
+	}
+
+	writeJSON(w, http.StatusOK, kafkainstanceclient.TopicsList{
+		Page:  kafkainstanceclient.PtrInt32(int32(pageNumber)),
+		Size:  kafkainstanceclient.PtrInt32(int32(len(items))),
+		Total: kafkainstanceclient.PtrInt32(int32(len(names))),
+		Items: &items,
+	})
+}
+
+func (a *adminServer) createTopic(w http.ResponseWriter, r *http.Request) {
+	var input kafkainstanceclient.NewTopicInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		writeAdminError(w, http.StatusBadRequest, "unable to read request body: "+err.Error())
+		return
+	}
+	if input.Name == "" {
+		writeAdminError(w, http.StatusBadRequest, "topic name is required")
+		return
+	}
+	if _, ok := a.topics[input.Name]; ok {
+		writeAdminError(w, http.StatusConflict, "topic "+input.Name+" already exists")
+		return
+	}
+
+	t := &topic{partitions: input.Settings.NumPartitions, config: map[string]string{}}
+	if t.partitions < 1 {
+		t.partitions = 1
+	}
+	for key, value := range defaultTopicConfig {
+		t.config[key] = value
+	}
+	t.setConfig(input.Settings.Config)
+	a.topics[input.Name] = t
+
+	writeJSON(w, http.StatusCreated, t.toTopic(input.Name))
+}
+
+func (a *adminServer) getTopic(w http.ResponseWriter, name string) {
+	t, ok := a.topics[name]
+	if !ok {
+		writeAdminError(w, http.StatusNotFound, "topic "+name+" does not exist")
+		return
+	}
+	writeJSON(w, http.StatusOK, t.toTopic(name))
+}
+
+func (a *adminServer) updateTopic(w http.ResponseWriter, r *http.Request, name string) {
+	t, ok := a.topics[name]
+	if !ok {
+		writeAdminError(w, http.StatusNotFound, "topic "+name+" does not exist")
+		return
+	}
+
+	var input kafkainstanceclient.UpdateTopicInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		writeAdminError(w, http.StatusBadRequest, "unable to read request body: "+err.Error())
+		return
+	}
+	if input.NumPartitions != nil {
+		if *input.NumPartitions < t.partitions {
+			writeAdminError(w, http.StatusBadRequest, "the number of partitions can only be increased")
+			return
+		}
+		t.partitions = *input.NumPartitions
+	}
+	t.setConfig(input.Config)
+
+	writeJSON(w, http.StatusOK, t.toTopic(name))
+}
+
+func (t *topic) setConfig(entries *[]kafkainstanceclient.ConfigEntry) {
+	if entries == nil {
+		return
+	}
+	for _, entry := range *entries {
+		t.config[entry.GetKey()] = entry.GetValue()
+	}
+}
+
+func (t *topic) toTopic(name string) kafkainstanceclient.Topic {
+	partitions := make([]kafkainstanceclient.Partition, t.partitions)
+	for i := range partitions {
+		partitions[i] = kafkainstanceclient.Partition{Id: int32(i)}
+	}
+
+	keys := make([]string, 0, len(t.config))
+	for key := range t.config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	config := make([]kafkainstanceclient.ConfigEntry, len(keys))
+	for i, key := range keys {
+		config[i] = kafkainstanceclient.ConfigEntry{
+			Key:   kafkainstanceclient.PtrString(key),
+			Value: kafkainstanceclient.PtrString(t.config[key]),
+		}
+	}
+
+	return kafkainstanceclient.Topic{
+		Name:       kafkainstanceclient.PtrString(name),
+		Partitions: &partitions,
+		Config:     &config,
+	}
+}
+
+func (a *adminServer) handleConsumerGroups(w http.ResponseWriter, r *http.Request) {
+	parts := pathParts(r, adminPath+"/consumer-groups")
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		a.listConsumerGroups(w, r)
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		group, ok := a.consumerGroups[parts[0]]
+		if !ok {
+			writeAdminError(w, http.StatusNotFound, "consumer group "+parts[0]+" does not exist")
+			return
+		}
+		writeJSON(w, http.StatusOK, group)
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		if _, ok := a.consumerGroups[parts[0]]; !ok {
+			writeAdminError(w, http.StatusNotFound, "consumer group "+parts[0]+" does not exist")
+			return
+		}
+		delete(a.consumerGroups, parts[0])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeAdminError(w, http.StatusNotImplemented, "not implemented by the fake control plane")
+	}
+}
+
+func (a *adminServer) listConsumerGroups(w http.ResponseWriter, r *http.Request) {
+	groupIDFilter := r.URL.Query().Get("group-id-filter")
+	topicFilter := r.URL.Query().Get("topic")
+
+	ids := []string{}
+	for id, group := range a.consumerGroups {
+		if !strings.Contains(id, groupIDFilter) {
+			continue
+		}
+		if topicFilter != "" && !consumesTopic(group, topicFilter) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	pageNumber, start, end := page(r, len(ids))
+	items := []kafkainstanceclient.ConsumerGroup{}
+	for _, id := range ids[start:end] {
+		items = append(items, a.consumerGroups[id])
+	}
+
+	writeJSON(w, http.StatusOK, kafkainstanceclient.ConsumerGroupList{
+		Items: &items,
+		Page:  kafkainstanceclient.PtrInt32(int32(pageNumber)),
+		Size:  kafkainstanceclient.PtrFloat32(float32(len(items))),
+		Total: kafkainstanceclient.PtrFloat32(float32(len(ids))),
+	})
+}
+
+func consumesTopic(group kafkainstanceclient.ConsumerGroup, topic string) bool {
+	for _, consumer := range group.Consumers {
+		if consumer.Topic == topic {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package fake
+
+import (
+	"net/http"
+
+	"github.com/redhat-developer/app-services-cli/pkg/api/ams/amsclient"
+)
+
+// termsURL is the page the user is sent to when they must accept terms and conditions
+const termsURL = "https://www.redhat.com/wapps/tnc/ackrequired"
+
+func (s *Server) handleTermsReview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusNotImplemented, amsErrCodePrefix, errCodeNotImplemented, "not implemented by the fake control plane")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := amsclient.TermsReviewResponse{
+		AccountId:      AccountID,
+		OrganizationId: OrgID,
+		TermsAvailable: s.termsRequired,
+		TermsRequired:  s.termsRequired,
+	}
+	if s.termsRequired {
+		res.RedirectUrl = amsclient.PtrString(termsURL)
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (s *Server) handleCurrentAccount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusNotImplemented, amsErrCodePrefix, errCodeNotImplemented, "not implemented by the fake control plane")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, amsclient.Account{
+		Id:       amsclient.PtrString(AccountID),
+		Kind:     amsclient.PtrString("Account"),
+		Username: Username,
+		Organization: &amsclient.Organization{
+			Id:   amsclient.PtrString(OrgID),
+			Kind: amsclient.PtrString("Organization"),
+		},
+	})
+}
+
+// handleOrganizations serves the quota cost of the organization, under /api/accounts_mgmt/v1/organizations/{orgId}/quota_cost
+func (s *Server) handleOrganizations(w http.ResponseWriter, r *http.Request) {
+	parts := pathParts(r, "/api/accounts_mgmt/v1/organizations")
+	if len(parts) != 2 || parts[1] != "quota_cost" || r.Method != http.MethodGet {
+		writeError(w, http.StatusNotImplemented, amsErrCodePrefix, errCodeNotImplemented, "not implemented by the fake control plane")
+		return
+	}
+	if parts[0] != OrgID {
+		writeError(w, http.StatusNotFound, amsErrCodePrefix, errCodeNotFound, "organization with id='"+parts[0]+"' not found")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := []amsclient.QuotaCost{}
+	if s.kafkaQuota > 0 {
+		items = append(items, newQuotaCost("cluster|rhinfra|rhosak|marketplace", "RHOSAK", "rhosak", s.kafkaQuota, len(s.kafkas)))
+	}
+	if s.registryQuota > 0 {
+		items = append(items, newQuotaCost("cluster|rhinfra|rhosr|marketplace", "RHOSR", "rhosr", s.registryQuota, len(s.registries)))
+	}
+
+	writeJSON(w, http.StatusOK, amsclient.QuotaCostList{
+		Kind:  "QuotaCostList",
+		Page:  1,
+		Size:  int32(len(items)),
+		Total: int32(len(items)),
+		Items: items,
+	})
+}
+
+func newQuotaCost(quotaID string, product string, resourceName string, allowed int, consumed int) amsclient.QuotaCost {
+	return amsclient.QuotaCost{
+		Kind:           amsclient.PtrString("QuotaCost"),
+		QuotaId:        quotaID,
+		OrganizationId: amsclient.PtrString(OrgID),
+		Allowed:        int32(allowed),
+		Consumed:       int32(consumed),
+		RelatedResources: &[]amsclient.RelatedResource{
+			{
+				BillingModel:  "marketplace",
+				CloudProvider: "any",
+				Cost:          1,
+				Product:       product,
+				ResourceName:  amsclient.PtrString(resourceName),
+				ResourceType:  "cluster",
+			},
+		},
+	}
+}
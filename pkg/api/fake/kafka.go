@@ -0,0 +1,308 @@
+package fake
+
+import (
+	"net/http"
+	"time"
+
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+)
+
+const (
+	kafkasPath         = "/api/kafkas_mgmt/v1/kafkas"
+	cloudProvidersPath = "/api/kafkas_mgmt/v1/cloud_providers"
+
+	kafkaVersion = "2.8.0"
+)
+
+// kafkaInstance is a Kafka instance along with its admin API
+type kafkaInstance struct {
+	kafkamgmtclient.KafkaRequest
+	admin *adminServer
+}
+
+var cloudProviders = []kafkamgmtclient.CloudProvider{
+	{
+		Kind:        kafkamgmtclient.PtrString("CloudProvider"),
+		Id:          kafkamgmtclient.PtrString("aws"),
+		Name:        kafkamgmtclient.PtrString("aws"),
+		DisplayName: kafkamgmtclient.PtrString("Amazon Web Services"),
+		Enabled:     true,
+	},
+	{
+		Kind:        kafkamgmtclient.PtrString("CloudProvider"),
+		Id:          kafkamgmtclient.PtrString("gcp"),
+		Name:        kafkamgmtclient.PtrString("gcp"),
+		DisplayName: kafkamgmtclient.PtrString("Google Cloud Platform"),
+		Enabled:     false,
+	},
+}
+
+// cloudRegion is a region of a cloud provider, including the instance types it supports,
+// which the API client does not have a field for
+type cloudRegion struct {
+	Kind                   string   `json:"kind"`
+	ID                     string   `json:"id"`
+	DisplayName            string   `json:"display_name"`
+	Enabled                bool     `json:"enabled"`
+	SupportedInstanceTypes []string `json:"supported_instance_types"`
+}
+
+type cloudRegionList struct {
+	Kind  string        `json:"kind"`
+	Page  int32         `json:"page"`
+	Size  int32         `json:"size"`
+	Total int32         `json:"total"`
+	Items []cloudRegion `json:"items"`
+}
+
+var cloudRegions = map[string][]cloudRegion{
+	"aws": {
+		{
+			Kind:                   "CloudRegion",
+			ID:                     "us-east-1",
+			DisplayName:            "US East, N. Virginia",
+			Enabled:                true,
+			SupportedInstanceTypes: []string{"standard", "eval"},
+		},
+		{
+			Kind:                   "CloudRegion",
+			ID:                     "eu-west-1",
+			DisplayName:            "EU, Ireland",
+			Enabled:                true,
+			SupportedInstanceTypes: []string{"eval"},
+		},
+		{
+			Kind:                   "CloudRegion",
+			ID:                     "ap-south-1",
+			DisplayName:            "Asia Pacific, Mumbai",
+			Enabled:                false,
+			SupportedInstanceTypes: []string{},
+		},
+	},
+	"gcp": {
+		{
+			Kind:                   "CloudRegion",
+			ID:                     "us-central1",
+			DisplayName:            "US Central, Iowa",
+			Enabled:                false,
+			SupportedInstanceTypes: []string{},
+		},
+	},
+}
+
+func (s *Server) handleKafkas(w http.ResponseWriter, r *http.Request) {
+	parts := pathParts(r, kafkasPath)
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		s.listKafkas(w, r)
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		s.createKafka(w, r)
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		s.getKafka(w, parts[0])
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		s.deleteKafka(w, parts[0])
+	case len(parts) == 3 && parts[1] == "metrics" && r.Method == http.MethodGet:
+		s.handleMetrics(w, r, parts[0], parts[2])
+	default:
+		writeError(w, http.StatusNotImplemented, kafkasErrCodePrefix, errCodeNotImplemented, "not implemented by the fake control plane")
+	}
+}
+
+func (s *Server) listKafkas(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := []kafkamgmtclient.KafkaRequest{}
+	for _, k := range s.kafkas {
+		matched, err := matchSearch(r.URL.Query().Get("search"), map[string]string{
+			"name":           k.GetName(),
+			"owner":          k.GetOwner(),
+			"cloud_provider": k.GetCloudProvider(),
+			"region":         k.GetRegion(),
+			"status":         k.GetStatus(),
+		})
+		if err != nil {
+			writeError(w, http.StatusBadRequest, kafkasErrCodePrefix, errCodeFailedToParseSearch, err.Error())
+			return
+		}
+		if matched {
+			items = append(items, k.KafkaRequest)
+		}
+	}
+
+	pageNumber, start, end := page(r, len(items))
+	writeJSON(w, http.StatusOK, kafkamgmtclient.KafkaRequestList{
+		Kind:  "KafkaRequestList",
+		Page:  int32(pageNumber),
+		Size:  int32(end - start),
+		Total: int32(len(items)),
+		Items: items[start:end],
+	})
+}
+
+func (s *Server) createKafka(w http.ResponseWriter, r *http.Request) {
+	var payload kafkamgmtclient.KafkaRequestPayload
+	if !readJSON(w, r, kafkasErrCodePrefix, &payload) {
+		return
+	}
+	if payload.Name == "" {
+		writeError(w, http.StatusBadRequest, kafkasErrCodePrefix, errCodeValidation, "name is required")
+		return
+	}
+	if payload.CloudProvider == nil {
+		payload.CloudProvider = kafkamgmtclient.PtrString("aws")
+	}
+	if payload.Region == nil {
+		payload.Region = kafkamgmtclient.PtrString("us-east-1")
+	}
+	if payload.MultiAz == nil {
+		payload.MultiAz = kafkamgmtclient.PtrBool(true)
+	}
+	if !isEnabledRegion(payload.GetCloudProvider(), "") {
+		writeError(w, http.StatusBadRequest, kafkasErrCodePrefix, errCodeProviderNotSupported, "provider "+payload.GetCloudProvider()+" is not supported")
+		return
+	}
+	if !isEnabledRegion(payload.GetCloudProvider(), payload.GetRegion()) {
+		writeError(w, http.StatusBadRequest, kafkasErrCodePrefix, errCodeRegionNotSupported, "region "+payload.GetRegion()+" is not supported")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, k := range s.kafkas {
+		if k.GetName() == payload.Name {
+			writeError(w, http.StatusConflict, kafkasErrCodePrefix, errCodeDuplicateName, "Kafka cluster name is already used")
+			return
+		}
+	}
+
+	admin, err := newAdminServer()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, kafkasErrCodePrefix, errCodeGeneral, err.Error())
+		return
+	}
+
+	id := newID()
+	now := time.Now()
+	k := &kafkaInstance{
+		KafkaRequest: kafkamgmtclient.KafkaRequest{
+			Id:                  kafkamgmtclient.PtrString(id),
+			Kind:                kafkamgmtclient.PtrString("Kafka"),
+			Href:                kafkamgmtclient.PtrString(kafkasPath + "/" + id),
+			Status:              kafkamgmtclient.PtrString("ready"),
+			CloudProvider:       payload.CloudProvider,
+			MultiAz:             payload.MultiAz,
+			Region:              payload.Region,
+			Owner:               kafkamgmtclient.PtrString(Username),
+			Name:                kafkamgmtclient.PtrString(payload.Name),
+			BootstrapServerHost: kafkamgmtclient.PtrString(admin.host),
+			CreatedAt:           kafkamgmtclient.PtrTime(now),
+			UpdatedAt:           kafkamgmtclient.PtrTime(now),
+			Version:             kafkamgmtclient.PtrString(kafkaVersion),
+		},
+		admin: admin,
+	}
+	s.kafkas = append(s.kafkas, k)
+
+	// the instance is ready straight away, but it is accepted first as in the real service
+	res := k.KafkaRequest
+	res.Status = kafkamgmtclient.PtrString("accepted")
+	writeJSON(w, http.StatusAccepted, res)
+}
+
+func (s *Server) getKafka(w http.ResponseWriter, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := s.findKafka(id)
+	if k == nil {
+		writeError(w, http.StatusNotFound, kafkasErrCodePrefix, errCodeNotFound, "KafkaResource with id='"+id+"' not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, k.KafkaRequest)
+}
+
+func (s *Server) deleteKafka(w http.ResponseWriter, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := s.findKafka(id)
+	if k == nil {
+		writeError(w, http.StatusNotFound, kafkasErrCodePrefix, errCodeNotFound, "KafkaResource with id='"+id+"' not found")
+		return
+	}
+
+	k.admin.Close()
+	for i := range s.kafkas {
+		if s.kafkas[i] == k {
+			s.kafkas = append(s.kafkas[:i], s.kafkas[i+1:]...)
+			break
+		}
+	}
+
+	res := k.KafkaRequest
+	res.Status = kafkamgmtclient.PtrString("deprovision")
+	writeJSON(w, http.StatusAccepted, res)
+}
+
+func (s *Server) findKafka(id string) *kafkaInstance {
+	for _, k := range s.kafkas {
+		if k.GetId() == id {
+			return k
+		}
+	}
+	return nil
+}
+
+func (s *Server) handleCloudProviders(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusNotImplemented, kafkasErrCodePrefix, errCodeNotImplemented, "not implemented by the fake control plane")
+		return
+	}
+
+	parts := pathParts(r, cloudProvidersPath)
+	switch {
+	case len(parts) == 0:
+		writeJSON(w, http.StatusOK, kafkamgmtclient.CloudProviderList{
+			Kind:  "CloudProviderList",
+			Page:  1,
+			Size:  int32(len(cloudProviders)),
+			Total: int32(len(cloudProviders)),
+			Items: cloudProviders,
+		})
+	case len(parts) == 2 && parts[1] == "regions":
+		regions, ok := cloudRegions[parts[0]]
+		if !ok {
+			writeError(w, http.StatusNotFound, kafkasErrCodePrefix, errCodeNotFound, "cloud provider "+parts[0]+" not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, cloudRegionList{
+			Kind:  "CloudRegionList",
+			Page:  1,
+			Size:  int32(len(regions)),
+			Total: int32(len(regions)),
+			Items: regions,
+		})
+	default:
+		writeError(w, http.StatusNotImplemented, kafkasErrCodePrefix, errCodeNotImplemented, "not implemented by the fake control plane")
+	}
+}
+
+// isEnabledRegion returns true if the cloud provider is enabled, and the region too when it is not empty
+func isEnabledRegion(provider string, region string) bool {
+	for _, p := range cloudProviders {
+		if p.GetId() != provider || !p.GetEnabled() {
+			continue
+		}
+		if region == "" {
+			return true
+		}
+		for _, r := range cloudRegions[provider] {
+			if r.ID == region {
+				return r.Enabled
+			}
+		}
+	}
+	return false
+}
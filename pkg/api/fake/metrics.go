@@ -0,0 +1,182 @@
+package fake
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+)
+
+// brokers are the brokers which report the metrics of the Kafka instances
+var brokers = []string{"0", "1"}
+
+// Values of the metrics reported by each broker, as the fake Kafka instances have no traffic
+const (
+	BrokerBytesIn       = 1024 * 1024
+	BrokerBytesOut      = 512 * 1024
+	BrokerStorageUsed   = 100 * 1024 * 1024
+	BrokerStorageLimit  = 1024 * 1024 * 1024
+	BrokerTopicMessages = 10
+	InstanceConnections = 4
+)
+
+const (
+	metricsNameLabel   = "__name__"
+	metricsTopicLabel  = "topic"
+	metricsBrokerLabel = "broker"
+
+	// defaults of the range queries, in minutes and in seconds
+	metricsDefaultRange = 5
+	metricsDefaultStep  = 30
+
+	// the timestamps of the metrics are in milliseconds
+	metricsTimestampUnit = int64(time.Millisecond)
+)
+
+// metricSample is a value of a metric with its labels
+type metricSample struct {
+	labels map[string]string
+	value  float64
+}
+
+// handleMetrics answers the instant and range queries of the metrics of a Kafka instance
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request, id string, query string) {
+	s.mu.Lock()
+	k := s.findKafka(id)
+	s.mu.Unlock()
+	if k == nil {
+		writeError(w, http.StatusNotFound, kafkasErrCodePrefix, errCodeNotFound, "KafkaResource with id='"+id+"' not found")
+		return
+	}
+
+	samples := k.metrics(filters(r))
+	now := time.Now()
+
+	switch query {
+	case "query":
+		items := make([]kafkamgmtclient.InstantQuery, len(samples))
+		for i, sample := range samples {
+			labels := sample.labels
+			items[i] = kafkamgmtclient.InstantQuery{
+				Metric:    &labels,
+				Timestamp: kafkamgmtclient.PtrInt64(now.UnixNano() / metricsTimestampUnit),
+				Value:     sample.value,
+			}
+		}
+		writeJSON(w, http.StatusOK, kafkamgmtclient.MetricsInstantQueryList{
+			Kind:  kafkamgmtclient.PtrString("MetricsInstantQueryList"),
+			Id:    kafkamgmtclient.PtrString(id),
+			Items: &items,
+		})
+	case "query_range":
+		duration, ok := intParam(w, r, "duration", metricsDefaultRange)
+		if !ok {
+			return
+		}
+		interval, ok := intParam(w, r, "interval", metricsDefaultStep)
+		if !ok {
+			return
+		}
+
+		// the values are reported at every interval until now, aligned on the interval
+		step := time.Duration(interval) * time.Second
+		end := now.Truncate(step)
+		timestamps := []int64{}
+		for t := end.Add(-time.Duration(duration) * time.Minute); !t.After(end); t = t.Add(step) {
+			timestamps = append(timestamps, t.UnixNano()/metricsTimestampUnit)
+		}
+
+		items := make([]kafkamgmtclient.RangeQuery, len(samples))
+		for i, sample := range samples {
+			labels := sample.labels
+			values := make([]kafkamgmtclient.Values, len(timestamps))
+			for j, t := range timestamps {
+				values[j] = kafkamgmtclient.Values{Timestamp: kafkamgmtclient.PtrInt64(t), Value: sample.value}
+			}
+			items[i] = kafkamgmtclient.RangeQuery{Metric: &labels, Values: &values}
+		}
+		writeJSON(w, http.StatusOK, kafkamgmtclient.MetricsRangeQueryList{
+			Kind:  kafkamgmtclient.PtrString("MetricsRangeQueryList"),
+			Id:    kafkamgmtclient.PtrString(id),
+			Items: &items,
+		})
+	default:
+		writeError(w, http.StatusNotImplemented, kafkasErrCodePrefix, errCodeNotImplemented, "not implemented by the fake control plane")
+	}
+}
+
+// metrics returns the metrics reported by the brokers of the Kafka instance, restricted to the names in filters if any
+func (k *kafkaInstance) metrics(filters map[string]bool) []metricSample {
+	k.admin.mu.Lock()
+	topics := make([]string, 0, len(k.admin.topics))
+	partitions := 0
+	for name, t := range k.admin.topics {
+		topics = append(topics, name)
+		partitions += int(t.partitions)
+	}
+	k.admin.mu.Unlock()
+	sort.Strings(topics)
+
+	samples := []metricSample{}
+	add := func(name string, value float64, labels map[string]string) {
+		if len(filters) > 0 && !filters[name] {
+			return
+		}
+		metric := map[string]string{metricsNameLabel: name}
+		for k, v := range labels {
+			metric[k] = v
+		}
+		samples = append(samples, metricSample{labels: metric, value: value})
+	}
+
+	for _, broker := range brokers {
+		brokerLabels := map[string]string{metricsBrokerLabel: broker}
+		add("kafka_server_brokertopicmetrics_bytes_in_total", BrokerBytesIn, brokerLabels)
+		add("kafka_server_brokertopicmetrics_bytes_out_total", BrokerBytesOut, brokerLabels)
+		add("kafka_broker_quota_totalstorageusedbytes", BrokerStorageUsed, brokerLabels)
+		add("kafka_broker_quota_softlimitbytes", BrokerStorageLimit, brokerLabels)
+		// the brokers also report the messages of every topic, without the topic label
+		add("kafka_server_brokertopicmetrics_messages_in_total", float64(BrokerTopicMessages*len(topics)), brokerLabels)
+		for _, topic := range topics {
+			add("kafka_server_brokertopicmetrics_messages_in_total", BrokerTopicMessages, map[string]string{
+				metricsBrokerLabel: broker,
+				metricsTopicLabel:  topic,
+			})
+		}
+	}
+	// only the controller reports the partitions
+	add("kafka_controller_kafkacontroller_global_partition_count", float64(partitions), map[string]string{metricsBrokerLabel: brokers[0]})
+	add("kafka_namespace:kafka_server_socket_server_metrics_connection_count:sum", InstanceConnections, nil)
+
+	return samples
+}
+
+// filters returns the names of the metrics requested through the filters query parameter
+func filters(r *http.Request) map[string]bool {
+	names := map[string]bool{}
+	for _, v := range r.URL.Query()["filters"] {
+		for _, name := range strings.Split(v, ",") {
+			if name != "" {
+				names[name] = true
+			}
+		}
+	}
+	return names
+}
+
+// intParam returns the value of an integer query parameter, writing an error response when it is invalid
+func intParam(w http.ResponseWriter, r *http.Request, name string, defaultValue int) (int, bool) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return defaultValue, true
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		writeError(w, http.StatusBadRequest, kafkasErrCodePrefix, errCodeValidation, "invalid "+name+" "+v)
+		return 0, false
+	}
+	return n, true
+}
@@ -0,0 +1,113 @@
+package fake
+
+import (
+	"net/http"
+	"time"
+
+	registrymgmtclient "github.com/redhat-developer/app-services-sdk-go/registrymgmt/apiv1/client"
+)
+
+const registriesPath = "/api/serviceregistry_mgmt/v1/registries"
+
+func (s *Server) handleRegistries(w http.ResponseWriter, r *http.Request) {
+	parts := pathParts(r, registriesPath)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		s.listRegistries(w, r)
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		s.createRegistry(w, r)
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		if registry := s.findRegistry(w, parts[0]); registry != nil {
+			writeJSON(w, http.StatusOK, registry)
+		}
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		if registry := s.findRegistry(w, parts[0]); registry != nil {
+			for i := range s.registries {
+				if s.registries[i] == registry {
+					s.registries = append(s.registries[:i], s.registries[i+1:]...)
+					break
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	default:
+		writeError(w, http.StatusNotImplemented, registryErrCodePrefix, errCodeNotImplemented, "not implemented by the fake control plane")
+	}
+}
+
+func (s *Server) listRegistries(w http.ResponseWriter, r *http.Request) {
+	items := []registrymgmtclient.RegistryRest{}
+	for _, registry := range s.registries {
+		matched, err := matchSearch(r.URL.Query().Get("search"), map[string]string{
+			"name":   registry.GetName(),
+			"owner":  registry.GetOwner(),
+			"status": string(registry.GetStatus()),
+		})
+		if err != nil {
+			writeError(w, http.StatusBadRequest, registryErrCodePrefix, errCodeFailedToParseSearch, err.Error())
+			return
+		}
+		if matched {
+			items = append(items, *registry)
+		}
+	}
+
+	pageNumber, start, end := page(r, len(items))
+	writeJSON(w, http.StatusOK, registrymgmtclient.RegistryListRest{
+		Kind:  "RegistryList",
+		Page:  int32(pageNumber),
+		Size:  int32(end - start),
+		Total: int32(len(items)),
+		Items: items[start:end],
+	})
+}
+
+func (s *Server) createRegistry(w http.ResponseWriter, r *http.Request) {
+	var req registrymgmtclient.RegistryCreateRest
+	if !readJSON(w, r, registryErrCodePrefix, &req) {
+		return
+	}
+	if req.GetName() == "" {
+		writeError(w, http.StatusBadRequest, registryErrCodePrefix, errCodeValidation, "name is required")
+		return
+	}
+	for _, registry := range s.registries {
+		if registry.GetName() == req.GetName() {
+			writeError(w, http.StatusConflict, registryErrCodePrefix, errCodeConflict, "a registry with this name already exists")
+			return
+		}
+	}
+
+	id := newID()
+	now := time.Now()
+	registry := &registrymgmtclient.RegistryRest{
+		Id:          id,
+		Kind:        registrymgmtclient.PtrString("Registry"),
+		Href:        registrymgmtclient.PtrString(registriesPath + "/" + id),
+		Status:      registrymgmtclient.READY,
+		RegistryUrl: registrymgmtclient.PtrString(s.URL + "/t/" + id),
+		Name:        req.Name,
+		Owner:       registrymgmtclient.PtrString(Username),
+		Description: req.Description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	s.registries = append(s.registries, registry)
+
+	writeJSON(w, http.StatusOK, registry)
+}
+
+// findRegistry returns the registry with the ID, writing an error response when there is none
+func (s *Server) findRegistry(w http.ResponseWriter, id string) *registrymgmtclient.RegistryRest {
+	for _, registry := range s.registries {
+		if registry.Id == id {
+			return registry
+		}
+	}
+	writeError(w, http.StatusNotFound, registryErrCodePrefix, errCodeNotFound, "registry with id='"+id+"' not found")
+	return nil
+}
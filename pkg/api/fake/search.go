@@ -0,0 +1,155 @@
+package fake
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// matchSearch returns true if the fields match the search query of a management API,
+// such as "name = my-kafka" or "name like 'my-%' or owner like %user%".
+// The "=", "<>" and "like" operators are supported, and clauses can be combined with "and" and "or".
+// Values can be quoted with single quotes, in which quotes and backslashes are escaped with a backslash
+func matchSearch(query string, fields map[string]string) (bool, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return false, err
+	}
+	if len(tokens) == 0 {
+		return true, nil
+	}
+
+	// "and" takes precedence over "or", so the clauses are evaluated as a disjunction of conjunctions
+	matched, conjunction := false, true
+	for i := 0; ; i += 4 {
+		if len(tokens) < i+3 {
+			return false, errors.New("incomplete search clause")
+		}
+		ok, err := matchClause(tokens[i], tokens[i+1], tokens[i+2], fields)
+		if err != nil {
+			return false, err
+		}
+		conjunction = conjunction && ok
+
+		if len(tokens) == i+3 {
+			return matched || conjunction, nil
+		}
+		switch keyword := tokens[i+3]; {
+		case !keyword.quoted && strings.EqualFold(keyword.text, "and"):
+		case !keyword.quoted && strings.EqualFold(keyword.text, "or"):
+			matched = matched || conjunction
+			conjunction = true
+		default:
+			return false, fmt.Errorf("unexpected %q in search query", keyword.text)
+		}
+	}
+}
+
+type token struct {
+	text   string
+	quoted bool
+}
+
+// tokenize splits the query into words, operators and quoted values
+func tokenize(query string) ([]token, error) {
+	tokens := []token{}
+	for i := 0; i < len(query); {
+		switch c := query[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '\'':
+			var value strings.Builder
+			i++
+			for ; i < len(query) && query[i] != '\''; i++ {
+				if query[i] == '\\' && i+1 < len(query) {
+					i++
+				}
+				value.WriteByte(query[i])
+			}
+			if i == len(query) {
+				return nil, errors.New("unterminated quoted value in search query")
+			}
+			i++
+			tokens = append(tokens, token{text: value.String(), quoted: true})
+		case c == '=':
+			tokens = append(tokens, token{text: "="})
+			i++
+		case strings.HasPrefix(query[i:], "<>"):
+			tokens = append(tokens, token{text: "<>"})
+			i += 2
+		default:
+			start := i
+			for i < len(query) && !strings.ContainsRune(" \t'=<", rune(query[i])) {
+				i++
+			}
+			tokens = append(tokens, token{text: query[start:i]})
+		}
+	}
+	return tokens, nil
+}
+
+func matchClause(field token, operator token, value token, fields map[string]string) (bool, error) {
+	actual, ok := fields[strings.ToLower(field.text)]
+	if field.quoted || !ok {
+		return false, fmt.Errorf("unsupported search field %q", field.text)
+	}
+
+	switch strings.ToLower(operator.text) {
+	case "=":
+		return actual == value.text, nil
+	case "<>":
+		return actual != value.text, nil
+	case "like":
+		return likePattern(value.text).MatchString(actual), nil
+	default:
+		return false, fmt.Errorf("unsupported search operator %q", operator.text)
+	}
+}
+
+// likePattern converts a pattern of the "like" operator into a regular expression,
+// where "%" matches any characters and "\" escapes the next character
+func likePattern(pattern string) *regexp.Regexp {
+	var expr strings.Builder
+	expr.WriteString("(?i)^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		case c == '%':
+			expr.WriteString(".*")
+		case c == '_':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.MustCompile(expr.String())
+}
+
+// page returns the bounds of the requested page of a list of the given length.
+// Pages start at 1 and contain 100 items by default
+func page(r *http.Request, length int) (pageNumber int, start int, end int) {
+	pageNumber, _ = strconv.Atoi(r.URL.Query().Get("page"))
+	if pageNumber < 1 {
+		pageNumber = 1
+	}
+	size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+	if size < 1 {
+		size = 100
+	}
+
+	start = (pageNumber - 1) * size
+	if start > length {
+		start = length
+	}
+	end = start + size
+	if end > length {
+		end = length
+	}
+	return pageNumber, start, end
+}
@@ -0,0 +1,76 @@
+package fake
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMatchSearch(t *testing.T) {
+	fields := map[string]string{
+		"name":   "my-kafka",
+		"owner":  "fake-user",
+		"status": "ready",
+	}
+
+	tests := []struct {
+		query   string
+		want    bool
+		wantErr bool
+	}{
+		{query: "", want: true},
+		{query: "name = my-kafka", want: true},
+		{query: "name = 'my-kafka'", want: true},
+		{query: "name = other", want: false},
+		{query: "name <> other", want: true},
+		{query: "name like my-%", want: true},
+		{query: "name LIKE %KAFKA", want: true},
+		{query: "name like other-%", want: false},
+		{query: "name = my-kafka and status = ready", want: true},
+		{query: "name = my-kafka and status = failed", want: false},
+		{query: "name = other or owner like %user", want: true},
+		{query: `name = 'my-kafka' and owner like 'fake-%'`, want: true},
+		{query: `name = 'my-kafka or owner = fake-user'`, want: false},
+		{query: `name = 'it\'s'`, want: false},
+		{query: "name like my_kafka", want: true},
+		{query: `name like 'my\\_kafka'`, want: false},
+		{query: `name like 'my\\-kafka'`, want: true},
+		{query: "region = us-east-1", wantErr: true},
+		{query: "name", wantErr: true},
+		{query: "name = 'my-kafka", wantErr: true},
+		{query: "name = my-kafka owner = fake-user", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := matchSearch(tt.query, fields)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("matchSearch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("matchSearch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPage(t *testing.T) {
+	tests := []struct {
+		query          string
+		wantPageNumber int
+		wantStart      int
+		wantEnd        int
+	}{
+		{query: "", wantPageNumber: 1, wantStart: 0, wantEnd: 5},
+		{query: "size=2", wantPageNumber: 1, wantStart: 0, wantEnd: 2},
+		{query: "page=3&size=2", wantPageNumber: 3, wantStart: 4, wantEnd: 5},
+		{query: "page=4&size=2", wantPageNumber: 4, wantStart: 5, wantEnd: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/?"+tt.query, nil)
+			pageNumber, start, end := page(r, 5)
+			if pageNumber != tt.wantPageNumber || start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("page() = %v, %v, %v, want %v, %v, %v", pageNumber, start, end, tt.wantPageNumber, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
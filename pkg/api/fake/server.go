@@ -0,0 +1,215 @@
+// Package fake implements an in-memory fake of the control plane used by the CLI,
+// so that commands and scripts can be tested without a live service.
+//
+// The fake serves the Kafka management, service account, Service Registry management and AMS APIs,
+// and the token endpoints of the SSO servers, over HTTP, so that the generated API clients talk to it
+// in the same way as they talk to the real services.
+// Every Kafka instance has its own Kafka instance admin API, which is served on a separate port
+// of localhost. This address is also used as the bootstrap server host of the instance.
+package fake
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/redhat-developer/app-services-cli/internal/build"
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	registrymgmtclient "github.com/redhat-developer/app-services-sdk-go/registrymgmt/apiv1/client"
+)
+
+// Server is a running fake control plane
+type Server struct {
+	// URL is the base URL of the fake, which is used as the API gateway and the base of the SSO URLs
+	URL string
+
+	server *httptest.Server
+
+	mu              sync.Mutex
+	termsRequired   bool
+	kafkaQuota      int
+	registryQuota   int
+	kafkas          []*kafkaInstance
+	serviceAccounts []*kafkamgmtclient.ServiceAccount
+	registries      []*registrymgmtclient.RegistryRest
+}
+
+// NewServer starts a fake on a random port of the loopback interface.
+// It panics if the fake cannot be started, as it is meant to be used in tests
+func NewServer() *Server {
+	s, err := Listen("127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Listen starts a fake listening on the given address
+func Listen(addr string) (*Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth/realms/", s.handleSSO)
+	mux.Handle("/api/kafkas_mgmt/v1/kafkas", s.authenticated(s.handleKafkas))
+	mux.Handle("/api/kafkas_mgmt/v1/kafkas/", s.authenticated(s.handleKafkas))
+	mux.Handle("/api/kafkas_mgmt/v1/cloud_providers", s.authenticated(s.handleCloudProviders))
+	mux.Handle("/api/kafkas_mgmt/v1/cloud_providers/", s.authenticated(s.handleCloudProviders))
+	mux.Handle("/api/kafkas_mgmt/v1/service_accounts", s.authenticated(s.handleServiceAccounts))
+	mux.Handle("/api/kafkas_mgmt/v1/service_accounts/", s.authenticated(s.handleServiceAccounts))
+	mux.Handle("/api/serviceregistry_mgmt/v1/registries", s.authenticated(s.handleRegistries))
+	mux.Handle("/api/serviceregistry_mgmt/v1/registries/", s.authenticated(s.handleRegistries))
+	mux.Handle("/api/authorizations/v1/self_terms_review", s.authenticated(s.handleTermsReview))
+	mux.Handle("/api/accounts_mgmt/v1/current_account", s.authenticated(s.handleCurrentAccount))
+	mux.Handle("/api/accounts_mgmt/v1/organizations/", s.authenticated(s.handleOrganizations))
+
+	s.server = &httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: mux},
+	}
+	s.server.Start()
+	s.URL = s.server.URL
+
+	return s, nil
+}
+
+// Close stops the fake and the admin APIs of its Kafka instances
+func (s *Server) Close() {
+	s.mu.Lock()
+	for _, k := range s.kafkas {
+		k.admin.Close()
+	}
+	s.kafkas = nil
+	s.mu.Unlock()
+
+	s.server.Close()
+}
+
+// AuthURL returns the URL of the SSO realm of the fake
+func (s *Server) AuthURL() string {
+	return s.URL + "/auth/realms/" + SSORealm
+}
+
+// MASAuthURL returns the URL of the MAS-SSO realm of the fake
+func (s *Server) MASAuthURL() string {
+	return s.URL + "/auth/realms/" + MASSSORealm
+}
+
+// Login sets the URLs of the fake in the configuration, along with SSO and MAS-SSO tokens issued by it,
+// so that the CLI uses the fake as if the user had logged in to it
+func (s *Server) Login(cfg *config.Config) {
+	cfg.UseCloudProfile()
+	cfg.APIUrl = s.URL
+	cfg.AuthURL = s.AuthURL()
+	cfg.MasAuthURL = s.MASAuthURL()
+	cfg.Insecure = false
+	cfg.ClientID = build.DefaultClientID
+	cfg.AccessToken = s.newToken(SSORealm, accessTokenType)
+	cfg.RefreshToken = s.newToken(SSORealm, refreshTokenType)
+	cfg.MasAccessToken = s.newToken(MASSSORealm, accessTokenType)
+	cfg.MasRefreshToken = s.newToken(MASSSORealm, refreshTokenType)
+}
+
+// SetTermsRequired sets whether the user must accept terms and conditions before creating Kafka instances
+func (s *Server) SetTermsRequired(required bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.termsRequired = required
+}
+
+// SetQuota sets the number of standard Kafka and Service Registry instances the organization is allowed to create.
+// By default, the organization has no quota
+func (s *Server) SetQuota(kafkas int, registries int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.kafkaQuota = kafkas
+	s.registryQuota = registries
+}
+
+// authenticated rejects the requests which have no bearer token
+func (s *Server) authenticated(handler http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			writeError(w, http.StatusUnauthorized, kafkasErrCodePrefix, errCodeUnauthenticated, "account authentication could not be verified")
+			return
+		}
+		handler(w, r)
+	})
+}
+
+// Error codes of the management APIs
+const (
+	kafkasErrCodePrefix   = "KAFKAS-MGMT"
+	registryErrCodePrefix = "SRS-MGMT"
+	amsErrCodePrefix      = "ACCT-MGMT"
+
+	errCodeConflict             = "6"
+	errCodeNotFound             = "7"
+	errCodeValidation           = "8"
+	errCodeGeneral              = "9"
+	errCodeNotImplemented       = "10"
+	errCodeUnauthenticated      = "15"
+	errCodeMalformedRequest     = "17"
+	errCodeFailedToParseSearch  = "23"
+	errCodeProviderNotSupported = "30"
+	errCodeRegionNotSupported   = "31"
+	errCodeDuplicateName        = "36"
+)
+
+type apiError struct {
+	ID     string `json:"id"`
+	Kind   string `json:"kind"`
+	Code   string `json:"code"`
+	Reason string `json:"reason"`
+}
+
+// writeError writes an error in the format of the management APIs
+func writeError(w http.ResponseWriter, status int, prefix string, code string, reason string) {
+	writeJSON(w, status, apiError{
+		ID:     code,
+		Kind:   "Error",
+		Code:   prefix + "-" + code,
+		Reason: reason,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// readJSON decodes the body of the request into v, writing an error response when it is invalid
+func readJSON(w http.ResponseWriter, r *http.Request, prefix string, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, prefix, errCodeMalformedRequest, "unable to read request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// pathParts returns the parts of the path which follow the prefix
+func pathParts(r *http.Request, prefix string) []string {
+	rest := strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	if rest == "" {
+		return nil
+	}
+	return strings.Split(rest, "/")
+}
+
+// newID returns a random ID in the format used by the management APIs
+func newID() string {
+	b := make([]byte, 10)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
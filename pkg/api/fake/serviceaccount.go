@@ -0,0 +1,102 @@
+package fake
+
+import (
+	"net/http"
+	"time"
+
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+)
+
+const serviceAccountsPath = "/api/kafkas_mgmt/v1/service_accounts"
+
+func (s *Server) handleServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	parts := pathParts(r, serviceAccountsPath)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		items := []kafkamgmtclient.ServiceAccountListItem{}
+		for _, sa := range s.serviceAccounts {
+			items = append(items, kafkamgmtclient.ServiceAccountListItem{
+				Id:          sa.Id,
+				Kind:        sa.Kind,
+				Href:        sa.Href,
+				ClientId:    sa.ClientId,
+				Name:        sa.Name,
+				Owner:       sa.Owner,
+				CreatedAt:   sa.CreatedAt,
+				Description: sa.Description,
+			})
+		}
+		writeJSON(w, http.StatusOK, kafkamgmtclient.ServiceAccountList{
+			Kind:  "ServiceAccountList",
+			Items: items,
+		})
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		s.createServiceAccount(w, r)
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		if sa := s.findServiceAccount(w, parts[0]); sa != nil {
+			// the secret is only returned when it is created
+			res := *sa
+			res.ClientSecret = nil
+			writeJSON(w, http.StatusOK, res)
+		}
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		if sa := s.findServiceAccount(w, parts[0]); sa != nil {
+			for i := range s.serviceAccounts {
+				if s.serviceAccounts[i] == sa {
+					s.serviceAccounts = append(s.serviceAccounts[:i], s.serviceAccounts[i+1:]...)
+					break
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	case len(parts) == 2 && parts[1] == "reset_credentials" && r.Method == http.MethodPost:
+		if sa := s.findServiceAccount(w, parts[0]); sa != nil {
+			sa.ClientSecret = kafkamgmtclient.PtrString(newID())
+			writeJSON(w, http.StatusOK, sa)
+		}
+	default:
+		writeError(w, http.StatusNotImplemented, kafkasErrCodePrefix, errCodeNotImplemented, "not implemented by the fake control plane")
+	}
+}
+
+func (s *Server) createServiceAccount(w http.ResponseWriter, r *http.Request) {
+	var req kafkamgmtclient.ServiceAccountRequest
+	if !readJSON(w, r, kafkasErrCodePrefix, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, kafkasErrCodePrefix, errCodeValidation, "name is required")
+		return
+	}
+
+	id := newID()
+	sa := &kafkamgmtclient.ServiceAccount{
+		Id:           kafkamgmtclient.PtrString(id),
+		Kind:         kafkamgmtclient.PtrString("ServiceAccount"),
+		Href:         kafkamgmtclient.PtrString(serviceAccountsPath + "/" + id),
+		Name:         kafkamgmtclient.PtrString(req.Name),
+		Description:  req.Description,
+		ClientId:     kafkamgmtclient.PtrString("srvc-acct-" + id),
+		ClientSecret: kafkamgmtclient.PtrString(newID()),
+		Owner:        kafkamgmtclient.PtrString(Username),
+		CreatedAt:    kafkamgmtclient.PtrTime(time.Now()),
+	}
+	s.serviceAccounts = append(s.serviceAccounts, sa)
+
+	writeJSON(w, http.StatusAccepted, sa)
+}
+
+// findServiceAccount returns the service account with the ID, writing an error response when there is none
+func (s *Server) findServiceAccount(w http.ResponseWriter, id string) *kafkamgmtclient.ServiceAccount {
+	for _, sa := range s.serviceAccounts {
+		if sa.GetId() == id {
+			return sa
+		}
+	}
+	writeError(w, http.StatusNotFound, kafkasErrCodePrefix, errCodeNotFound, "service account with id='"+id+"' not found")
+	return nil
+}
@@ -0,0 +1,144 @@
+package fake
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// Realms of the fake SSO servers
+const (
+	SSORealm    = "redhat-external"
+	MASSSORealm = "rhoas"
+)
+
+// Details of the user the fake issues tokens for
+const (
+	Username  = "fake-user"
+	OrgID     = "11111111"
+	AccountID = "22222222"
+)
+
+// Types of the tokens, set in the "typ" claim
+const (
+	accessTokenType  = "Bearer"
+	refreshTokenType = "Refresh"
+	offlineTokenType = "Offline"
+)
+
+const (
+	accessTokenLifespan  = 15 * time.Minute
+	refreshTokenLifespan = 10 * time.Hour
+)
+
+// the tokens are signed, but the CLI does not verify them
+var signingKey = []byte("fake")
+
+// OfflineToken returns an offline token of the SSO realm, which can be used to log in
+// with "rhoas login --token"
+func (s *Server) OfflineToken() string {
+	return s.newToken(SSORealm, offlineTokenType)
+}
+
+// newToken issues a token of the realm
+func (s *Server) newToken(realm string, tokenType string) string {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":                s.URL + "/auth/realms/" + realm,
+		"typ":                tokenType,
+		"iat":                now.Unix(),
+		"preferred_username": Username,
+		"org_id":             OrgID,
+		"account_id":         AccountID,
+		"scope":              "openid",
+	}
+	switch tokenType {
+	case accessTokenType:
+		claims["exp"] = now.Add(accessTokenLifespan).Unix()
+	case refreshTokenType:
+		claims["exp"] = now.Add(refreshTokenLifespan).Unix()
+	}
+
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(signingKey)
+	return token
+}
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	ExpiresIn        int    `json:"expires_in"`
+	RefreshToken     string `json:"refresh_token,omitempty"`
+	RefreshExpiresIn int    `json:"refresh_expires_in"`
+	TokenType        string `json:"token_type"`
+	Scope            string `json:"scope"`
+}
+
+type oauthError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// handleSSO serves the token and logout endpoints of the realms, under
+// /auth/realms/{realm}/protocol/openid-connect/
+func (s *Server) handleSSO(w http.ResponseWriter, r *http.Request) {
+	parts := pathParts(r, "/auth/realms")
+	if len(parts) != 4 || (parts[0] != SSORealm && parts[0] != MASSSORealm) || parts[1] != "protocol" || parts[2] != "openid-connect" {
+		http.NotFound(w, r)
+		return
+	}
+	realm := parts[0]
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	switch parts[3] {
+	case "token":
+		s.handleToken(w, r, realm)
+	case "logout":
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request, realm string) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, oauthError{Error: "invalid_request", ErrorDescription: err.Error()})
+		return
+	}
+
+	res := tokenResponse{
+		AccessToken:      s.newToken(realm, accessTokenType),
+		ExpiresIn:        int(accessTokenLifespan.Seconds()),
+		RefreshExpiresIn: int(refreshTokenLifespan.Seconds()),
+		TokenType:        "Bearer",
+		Scope:            "openid",
+	}
+
+	switch grantType := r.PostForm.Get("grant_type"); grantType {
+	case "refresh_token":
+		refreshToken := r.PostForm.Get("refresh_token")
+		token, _, err := new(jwt.Parser).ParseUnverified(refreshToken, jwt.MapClaims{})
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, oauthError{Error: "invalid_grant", ErrorDescription: "Invalid refresh token"})
+			return
+		}
+		// offline tokens stay valid, they are returned as is
+		if claims, ok := token.Claims.(jwt.MapClaims); ok && claims["typ"] == offlineTokenType {
+			res.RefreshToken = refreshToken
+			res.RefreshExpiresIn = 0
+		} else {
+			res.RefreshToken = s.newToken(realm, refreshTokenType)
+		}
+	case "client_credentials":
+		// service accounts do not get a refresh token
+		res.RefreshExpiresIn = 0
+	default:
+		writeJSON(w, http.StatusBadRequest, oauthError{Error: "unsupported_grant_type", ErrorDescription: "Unsupported grant_type " + grantType})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
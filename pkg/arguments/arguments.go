@@ -3,6 +3,9 @@ package arguments
 
 import (
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/debug"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/logflags"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/loghttp"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/retry"
 	"github.com/spf13/pflag"
 )
 
@@ -10,3 +13,18 @@ import (
 func AddDebugFlag(fs *pflag.FlagSet) {
 	debug.AddFlag(fs)
 }
+
+// AddRetryFlag adds the '--max-retries' flag to the given set of command line flags
+func AddRetryFlag(fs *pflag.FlagSet, description string) {
+	retry.AddFlag(fs, description)
+}
+
+// AddLogHTTPFlag adds the '--log-http' flag to the given set of command line flags
+func AddLogHTTPFlag(fs *pflag.FlagSet, description string) {
+	loghttp.AddFlag(fs, description)
+}
+
+// AddLogFlags adds the '--log-format' and '--log-file' flags to the given set of command line flags
+func AddLogFlags(fs *pflag.FlagSet, formatDescription string, fileDescription string) {
+	logflags.AddFlags(fs, formatDescription, fileDescription)
+}
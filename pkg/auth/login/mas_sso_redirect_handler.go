@@ -96,16 +96,13 @@ func (h *masRedirectPageHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 	w.WriteHeader(http.StatusOK)
 	fmt.Fprint(w, redirectPage)
 
-	cfg, err := h.Config.Load()
-	if err != nil {
-		logger.Error(err)
-		os.Exit(1)
-	}
 	// save the received tokens to the user's config
-	cfg.MasAccessToken = oauth2Token.AccessToken
-	cfg.MasRefreshToken = oauth2Token.RefreshToken
-
-	if err = h.Config.Save(cfg); err != nil {
+	err = h.Config.Update(func(cfg *config.Config) error {
+		cfg.MasAccessToken = oauth2Token.AccessToken
+		cfg.MasRefreshToken = oauth2Token.RefreshToken
+		return nil
+	})
+	if err != nil {
 		logger.Error(err)
 		os.Exit(1)
 	}
@@ -79,12 +79,6 @@ func (h *redirectPageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	cfg, err := h.Config.Load()
-	if err != nil {
-		h.Logger.Error(err)
-		os.Exit(1)
-	}
-
 	username, ok := token.GetUsername(oauth2Token.AccessToken)
 	if !ok {
 		username = "unknown"
@@ -105,10 +99,12 @@ func (h *redirectPageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	fmt.Fprint(w, redirectPage)
 
 	// save the received tokens to the user's config
-	cfg.AccessToken = oauth2Token.AccessToken
-	cfg.RefreshToken = oauth2Token.RefreshToken
-
-	if err = h.Config.Save(cfg); err != nil {
+	err = h.Config.Update(func(cfg *config.Config) error {
+		cfg.AccessToken = oauth2Token.AccessToken
+		cfg.RefreshToken = oauth2Token.RefreshToken
+		return nil
+	})
+	if err != nil {
 		h.Logger.Error(err)
 		os.Exit(1)
 	}
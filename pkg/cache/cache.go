@@ -0,0 +1,203 @@
+// Package cache stores API responses on disk, next to the config file, so that shell completion
+// and read-only commands do not need to call the API every time they run.
+// The cache is best-effort: entries which cannot be read are treated as missing.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/redhat-developer/app-services-cli/internal/config"
+)
+
+// Namespaces group the entries which are invalidated together
+const (
+	KafkasNamespace         = "kafkas"
+	CloudProvidersNamespace = "cloud-providers"
+	CloudRegionsNamespace   = "cloud-regions"
+)
+
+// Time to live of the cached entries
+const (
+	KafkasTTL         = time.Minute
+	TopicsTTL         = time.Minute
+	ConsumerGroupsTTL = time.Minute
+	CloudProvidersTTL = time.Hour
+	CloudRegionsTTL   = time.Hour
+)
+
+// TopicsNamespace returns the namespace of the topics of a Kafka instance
+func TopicsNamespace(instanceID string) string {
+	return "topics-" + instanceID
+}
+
+// ConsumerGroupsNamespace returns the namespace of the consumer groups of a Kafka instance
+func ConsumerGroupsNamespace(instanceID string) string {
+	return "consumer-groups-" + instanceID
+}
+
+// Key returns the key of an entry. The API URL is part of every key,
+// so that the responses of different environments are not mixed up
+func Key(apiURL string, parts ...string) string {
+	return strings.Join(append([]string{apiURL}, parts...), "/")
+}
+
+// Cache is a directory of cached API responses
+type Cache struct {
+	// Dir is the directory the entries are stored in
+	Dir string
+
+	now func() time.Time
+}
+
+type entry struct {
+	ExpiresAt time.Time       `json:"expires_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// New creates the cache which belongs to the given config file.
+// The entries are stored in a directory named after the config file, such as "config-cache"
+// for "config.json", so that every config file has its own cache.
+func New(cfg config.IConfig) (*Cache, error) {
+	location, err := cfg.Location()
+	if err != nil {
+		return nil, err
+	}
+	return &Cache{Dir: strings.TrimSuffix(location, filepath.Ext(location)) + "-cache"}, nil
+}
+
+// Invalidate removes the namespaces from the cache of the given config file.
+// It is called by the commands which change the resources the namespaces contain.
+func Invalidate(cfg config.IConfig, namespaces ...string) error {
+	c, err := New(cfg)
+	if err != nil {
+		return err
+	}
+	return c.Invalidate(namespaces...)
+}
+
+// Clear removes every entry from the cache of the given config file.
+// It is called when logging in and out, as the entries belong to the current user.
+func Clear(cfg config.IConfig) error {
+	c, err := New(cfg)
+	if err != nil {
+		return err
+	}
+	return c.Clear()
+}
+
+// Get reads the entry stored for key into v.
+// It returns false when there is no entry, or when it has expired.
+func (c *Cache) Get(namespace string, key string, v interface{}) bool {
+	// #nosec G304
+	data, err := ioutil.ReadFile(c.path(namespace, key))
+	if err != nil {
+		return false
+	}
+	var e entry
+	if err = json.Unmarshal(data, &e); err != nil {
+		return false
+	}
+	if !c.timeNow().Before(e.ExpiresAt) {
+		return false
+	}
+	return json.Unmarshal(e.Data, v) == nil
+}
+
+// Set stores v for key, until the time to live has passed
+func (c *Cache) Set(namespace string, key string, v interface{}, ttl time.Duration) (err error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	data, err = json.Marshal(entry{
+		ExpiresAt: c.timeNow().Add(ttl),
+		Data:      data,
+	})
+	if err != nil {
+		return err
+	}
+
+	file := c.path(namespace, key)
+	if err = os.MkdirAll(filepath.Dir(file), 0o700); err != nil {
+		return fmt.Errorf("unable to create cache directory: %w", err)
+	}
+
+	// the entry is written to a temporary file first, so concurrent reads never see a partial entry
+	tmpFile, err := ioutil.TempFile(filepath.Dir(file), filepath.Base(file)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("unable to write cache entry: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tmpFile.Close()
+			os.Remove(tmpFile.Name())
+		}
+	}()
+	if _, err = tmpFile.Write(data); err != nil {
+		return fmt.Errorf("unable to write cache entry: %w", err)
+	}
+	if err = tmpFile.Close(); err != nil {
+		return fmt.Errorf("unable to write cache entry: %w", err)
+	}
+	if err = os.Rename(tmpFile.Name(), file); err != nil {
+		return fmt.Errorf("unable to write cache entry: %w", err)
+	}
+	return nil
+}
+
+// Fetch reads the entry stored for key into v.
+// When there is no fresh entry, fetch is called to fill v instead and the result is stored for key.
+func (c *Cache) Fetch(namespace string, key string, ttl time.Duration, v interface{}, fetch func() error) error {
+	if c.Get(namespace, key, v) {
+		return nil
+	}
+	if err := fetch(); err != nil {
+		return err
+	}
+	_ = c.Set(namespace, key, v, ttl)
+	return nil
+}
+
+// Invalidate removes every entry of the namespaces
+func (c *Cache) Invalidate(namespaces ...string) error {
+	for _, namespace := range namespaces {
+		if err := os.RemoveAll(c.namespaceDir(namespace)); err != nil {
+			return fmt.Errorf("unable to invalidate cache: %w", err)
+		}
+	}
+	return nil
+}
+
+// Clear removes every entry
+func (c *Cache) Clear() error {
+	if err := os.RemoveAll(c.Dir); err != nil {
+		return fmt.Errorf("unable to clear cache: %w", err)
+	}
+	return nil
+}
+
+func (c *Cache) namespaceDir(namespace string) string {
+	return filepath.Join(c.Dir, url.PathEscape(namespace))
+}
+
+// path returns the file of an entry, the key is hashed as it can contain any character
+func (c *Cache) path(namespace string, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.namespaceDir(namespace), hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *Cache) timeNow() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/redhat-developer/app-services-cli/internal/config"
+)
+
+func newTestCache(t *testing.T) (*Cache, *time.Time) {
+	dir, err := ioutil.TempDir("", "rhoas-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	now := time.Unix(1600000000, 0)
+	c := &Cache{
+		Dir: filepath.Join(dir, "config-cache"),
+		now: func() time.Time { return now },
+	}
+	return c, &now
+}
+
+func TestNew(t *testing.T) {
+	cfg := &config.IConfigMock{
+		LocationFunc: func() (string, error) {
+			return filepath.Join("home", ".config", "rhoas", "config.json"), nil
+		},
+	}
+	c, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join("home", ".config", "rhoas", "config-cache"); c.Dir != want {
+		t.Errorf("Dir = %v, want %v", c.Dir, want)
+	}
+}
+
+func TestCache_GetSet(t *testing.T) {
+	c, now := newTestCache(t)
+	key := Key("https://api.openshift.com", "names")
+
+	var names []string
+	if c.Get(KafkasNamespace, key, &names) {
+		t.Fatal("Get() found an entry in an empty cache")
+	}
+
+	if err := c.Set(KafkasNamespace, key, []string{"kafka-1", "kafka-2"}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if !c.Get(KafkasNamespace, key, &names) || !reflect.DeepEqual(names, []string{"kafka-1", "kafka-2"}) {
+		t.Errorf("Get() = %v, want the stored names", names)
+	}
+
+	// the entries of other APIs are separate
+	if c.Get(KafkasNamespace, Key("https://api.stage.openshift.com", "names"), &names) {
+		t.Error("Get() found an entry of another API")
+	}
+
+	*now = now.Add(time.Minute)
+	if c.Get(KafkasNamespace, key, &names) {
+		t.Error("Get() found an expired entry")
+	}
+}
+
+func TestCache_Fetch(t *testing.T) {
+	c, _ := newTestCache(t)
+
+	calls := 0
+	fetch := func(names *[]string) func() error {
+		return func() error {
+			calls++
+			*names = []string{"my-topic"}
+			return nil
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		var names []string
+		if err := c.Fetch(TopicsNamespace("1"), "names", time.Minute, &names, fetch(&names)); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(names, []string{"my-topic"}) {
+			t.Errorf("Fetch() = %v, want [my-topic]", names)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("fetch was called %v times, want 1", calls)
+	}
+
+	// errors are returned and not cached
+	wantErr := errors.New("offline")
+	var names []string
+	err := c.Fetch(TopicsNamespace("2"), "names", time.Minute, &names, func() error { return wantErr })
+	if err != wantErr {
+		t.Errorf("Fetch() error = %v, want %v", err, wantErr)
+	}
+	if c.Get(TopicsNamespace("2"), "names", &names) {
+		t.Error("a failed fetch was cached")
+	}
+}
+
+func TestCache_Invalidate(t *testing.T) {
+	c, _ := newTestCache(t)
+
+	for _, namespace := range []string{KafkasNamespace, TopicsNamespace("1"), CloudProvidersNamespace} {
+		if err := c.Set(namespace, "key", "value", time.Hour); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := c.Invalidate(KafkasNamespace, TopicsNamespace("1")); err != nil {
+		t.Fatal(err)
+	}
+
+	var value string
+	if c.Get(KafkasNamespace, "key", &value) || c.Get(TopicsNamespace("1"), "key", &value) {
+		t.Error("Get() found an invalidated entry")
+	}
+	if !c.Get(CloudProvidersNamespace, "key", &value) {
+		t.Error("Invalidate() removed the entries of another namespace")
+	}
+
+	if err := c.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(c.Dir); !os.IsNotExist(err) {
+		t.Errorf("Clear() did not remove the cache directory: %v", err)
+	}
+}
+
+func TestCache_CorruptEntry(t *testing.T) {
+	c, _ := newTestCache(t)
+
+	if err := c.Set(KafkasNamespace, "key", "value", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(c.path(KafkasNamespace, "key"), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var value string
+	if c.Get(KafkasNamespace, "key", &value) {
+		t.Error("Get() found a corrupt entry")
+	}
+}
@@ -0,0 +1,316 @@
+// clientconfig package renders the configuration used by applications
+// to connect to Kafka and Service Registry instances
+package clientconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/redhat-developer/app-services-cli/internal/build"
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/serviceaccount/credentials"
+)
+
+// Configuration types
+const (
+	QuarkusType    = "quarkus"
+	SpringType     = "spring"
+	LibrdkafkaType = "librdkafka"
+	EnvType        = "env"
+	JSONType       = "json"
+	ConfigMapType  = "configmap"
+)
+
+// SASL mechanisms
+const (
+	OAuthBearerMechanism = "oauthbearer"
+	PlainMechanism       = "plain"
+)
+
+var (
+	// Types are the supported configuration types
+	Types = []string{QuarkusType, SpringType, LibrdkafkaType, EnvType, JSONType, ConfigMapType}
+	// SASLMechanisms are the supported SASL mechanisms
+	SASLMechanisms = []string{OAuthBearerMechanism, PlainMechanism}
+)
+
+// Placeholders used when no credentials are provided,
+// they reference the variables of the env file created by "rhoas service-account create"
+const (
+	clientIDPlaceholder     = "${CLIENT_ID}"
+	clientSecretPlaceholder = "${CLIENT_SECRET}"
+)
+
+// TokenEndpointPath is the path of the token endpoint relative to the identity provider realm URL
+const TokenEndpointPath = "/protocol/openid-connect/token"
+
+// Configuration contains the values which are rendered into the client configuration
+type Configuration struct {
+	// BootstrapServerHost is the bootstrap server host of the Kafka instance
+	BootstrapServerHost string
+	// RegistryURL is the URL of the Service Registry instance, if any
+	RegistryURL string
+	// Credentials are the service account credentials,
+	// placeholders referencing the CLIENT_ID and CLIENT_SECRET environment variables are rendered when nil
+	Credentials *credentials.Credentials
+	// TokenURL is the OAuth token endpoint which issues tokens for the service account
+	TokenURL string
+	// SASLMechanism is either OAuthBearerMechanism or PlainMechanism
+	SASLMechanism string
+	// Name is the name of the Kubernetes resources rendered by the configmap type
+	Name string
+	// Local disables TLS and authentication, as used by the local development environment
+	Local bool
+}
+
+// templateData is the view of a Configuration used by the templates
+type templateData struct {
+	*Configuration
+	ClientID     string
+	ClientSecret string
+	OAuthBearer  bool
+}
+
+// jsonConfiguration is the document written by the json type
+type jsonConfiguration struct {
+	BootstrapServerHost string `json:"bootstrapServerHost"`
+	SecurityProtocol    string `json:"securityProtocol"`
+	SASLMechanism       string `json:"saslMechanism,omitempty"`
+	ClientID            string `json:"clientID,omitempty"`
+	ClientSecret        string `json:"clientSecret,omitempty"`
+	TokenURL            string `json:"oauthTokenEndpointURI,omitempty"`
+	RegistryURL         string `json:"serviceRegistryURL,omitempty"`
+}
+
+// templateFuncs escape the values rendered into the templates, in the same way as the credentials files
+var templateFuncs = template.FuncMap{
+	"jaas":       credentials.JAASValue,
+	"properties": credentials.PropertiesValue,
+	"env":        envValue,
+}
+
+// Templates
+var (
+	templateQuarkus = heredoc.Doc(`
+	## Generated by rhoas cli
+	kafka.bootstrap.servers={{.BootstrapServerHost}}
+	{{- if .Local}}
+	kafka.security.protocol=PLAINTEXT
+	{{- else}}
+	kafka.security.protocol=SASL_SSL
+	{{- if .OAuthBearer}}
+	kafka.sasl.mechanism=OAUTHBEARER
+	kafka.sasl.jaas.config=org.apache.kafka.common.security.oauthbearer.OAuthBearerLoginModule required \
+	  oauth.client.id={{.ClientID | jaas | properties}} \
+	  oauth.client.secret={{.ClientSecret | jaas | properties}} \
+	  oauth.token.endpoint.uri={{.TokenURL | jaas | properties}} ;
+	kafka.sasl.login.callback.handler.class=io.strimzi.kafka.oauth.client.JaasClientOauthLoginCallbackHandler
+	{{- else}}
+	kafka.sasl.mechanism=PLAIN
+	kafka.sasl.jaas.config=org.apache.kafka.common.security.plain.PlainLoginModule required \
+	  username={{.ClientID | jaas | properties}} \
+	  password={{.ClientSecret | jaas | properties}} ;
+	{{- end}}
+	{{- end}}
+	{{- if .RegistryURL}}
+	mp.messaging.connector.smallrye-kafka.apicurio.registry.url={{.RegistryURL}}
+	{{- if not .Local}}
+	mp.messaging.connector.smallrye-kafka.apicurio.auth.service.token.endpoint={{.TokenURL}}
+	mp.messaging.connector.smallrye-kafka.apicurio.auth.client.id={{.ClientID | properties}}
+	mp.messaging.connector.smallrye-kafka.apicurio.auth.client.secret={{.ClientSecret | properties}}
+	{{- end}}
+	{{- end}}
+	`)
+
+	templateSpring = heredoc.Doc(`
+	## Generated by rhoas cli
+	spring.kafka.bootstrap-servers={{.BootstrapServerHost}}
+	{{- if .Local}}
+	spring.kafka.properties.security.protocol=PLAINTEXT
+	{{- else}}
+	spring.kafka.properties.security.protocol=SASL_SSL
+	{{- if .OAuthBearer}}
+	spring.kafka.properties.sasl.mechanism=OAUTHBEARER
+	spring.kafka.properties.sasl.jaas.config=org.apache.kafka.common.security.oauthbearer.OAuthBearerLoginModule required \
+	  oauth.client.id={{.ClientID | jaas | properties}} \
+	  oauth.client.secret={{.ClientSecret | jaas | properties}} \
+	  oauth.token.endpoint.uri={{.TokenURL | jaas | properties}} ;
+	spring.kafka.properties.sasl.login.callback.handler.class=io.strimzi.kafka.oauth.client.JaasClientOauthLoginCallbackHandler
+	{{- else}}
+	spring.kafka.properties.sasl.mechanism=PLAIN
+	spring.kafka.properties.sasl.jaas.config=org.apache.kafka.common.security.plain.PlainLoginModule required \
+	  username={{.ClientID | jaas | properties}} \
+	  password={{.ClientSecret | jaas | properties}} ;
+	{{- end}}
+	{{- end}}
+	{{- if .RegistryURL}}
+	spring.kafka.properties.apicurio.registry.url={{.RegistryURL}}
+	{{- if not .Local}}
+	spring.kafka.properties.apicurio.auth.service.token.endpoint={{.TokenURL}}
+	spring.kafka.properties.apicurio.auth.client.id={{.ClientID | properties}}
+	spring.kafka.properties.apicurio.auth.client.secret={{.ClientSecret | properties}}
+	{{- end}}
+	{{- end}}
+	`)
+
+	templateLibrdkafka = heredoc.Doc(`
+	## Generated by rhoas cli
+	bootstrap.servers={{.BootstrapServerHost}}
+	{{- if .Local}}
+	security.protocol=PLAINTEXT
+	{{- else}}
+	security.protocol=SASL_SSL
+	{{- if .OAuthBearer}}
+	sasl.mechanisms=OAUTHBEARER
+	sasl.oauthbearer.method=oidc
+	sasl.oauthbearer.client.id={{.ClientID}}
+	sasl.oauthbearer.client.secret={{.ClientSecret}}
+	sasl.oauthbearer.token.endpoint.url={{.TokenURL}}
+	{{- else}}
+	sasl.mechanisms=PLAIN
+	sasl.username={{.ClientID}}
+	sasl.password={{.ClientSecret}}
+	{{- end}}
+	{{- end}}
+	{{- if .RegistryURL}}
+	## librdkafka does not connect to Service Registry, configure the serializer of your client with this URL
+	## Service Registry URL: {{.RegistryURL}}
+	{{- end}}
+	`)
+
+	templateEnv = heredoc.Doc(`
+	## Generated by rhoas cli
+	KAFKA_HOST={{.BootstrapServerHost}}
+	{{- if not .Local}}
+	KAFKA_SASL_MECHANISM={{if .OAuthBearer}}OAUTHBEARER{{else}}PLAIN{{end}}
+	CLIENT_ID={{.ClientID | env}}
+	CLIENT_SECRET={{.ClientSecret | env}}
+	{{- if .OAuthBearer}}
+	OAUTH_TOKEN_ENDPOINT_URI={{.TokenURL}}
+	{{- end}}
+	{{- end}}
+	{{- if .RegistryURL}}
+	SERVICE_REGISTRY_URL={{.RegistryURL}}
+	{{- end}}
+	`)
+
+	templateConfigMap = heredoc.Doc(`
+	## Generated by rhoas cli
+	apiVersion: v1
+	kind: ConfigMap
+	metadata:
+	  name: {{.Name}}
+	data:
+	  KAFKA_HOST: {{printf "%q" .BootstrapServerHost}}
+	{{- if not .Local}}
+	  KAFKA_SASL_MECHANISM: {{if .OAuthBearer}}OAUTHBEARER{{else}}PLAIN{{end}}
+	{{- if .OAuthBearer}}
+	  OAUTH_TOKEN_ENDPOINT_URI: {{printf "%q" .TokenURL}}
+	{{- end}}
+	{{- end}}
+	{{- if .RegistryURL}}
+	  SERVICE_REGISTRY_URL: {{printf "%q" .RegistryURL}}
+	{{- end}}
+	{{- if not .Local}}
+	---
+	apiVersion: v1
+	kind: Secret
+	metadata:
+	  name: {{.Name}}-credentials
+	type: Opaque
+	stringData:
+	  CLIENT_ID: {{printf "%q" .ClientID}}
+	  CLIENT_SECRET: {{printf "%q" .ClientSecret}}
+	{{- end}}
+	`)
+)
+
+// TokenURL returns the token endpoint of the identity provider which issues service account tokens
+func TokenURL(cfg *config.Config) string {
+	authURL := cfg.MasAuthURL
+	if authURL == "" {
+		authURL = build.ProductionMasAuthURL
+	}
+
+	return strings.TrimSuffix(authURL, "/") + TokenEndpointPath
+}
+
+// Write renders the configuration in the format of configType to w
+func Write(w io.Writer, configType string, cfg *Configuration) error {
+	data := newTemplateData(cfg)
+
+	var fileTemplate string
+	switch configType {
+	case QuarkusType:
+		fileTemplate = templateQuarkus
+	case SpringType:
+		fileTemplate = templateSpring
+	case LibrdkafkaType:
+		fileTemplate = templateLibrdkafka
+	case EnvType:
+		fileTemplate = templateEnv
+	case ConfigMapType:
+		fileTemplate = templateConfigMap
+	case JSONType:
+		return writeJSON(w, data)
+	default:
+		return fmt.Errorf("unsupported configuration type %q", configType)
+	}
+
+	tmpl, err := template.New(configType).Funcs(templateFuncs).Parse(fileTemplate)
+	if err != nil {
+		return err
+	}
+
+	return tmpl.Execute(w, data)
+}
+
+func writeJSON(w io.Writer, data *templateData) error {
+	doc := jsonConfiguration{
+		BootstrapServerHost: data.BootstrapServerHost,
+		SecurityProtocol:    "PLAINTEXT",
+		RegistryURL:         data.RegistryURL,
+	}
+	if !data.Local {
+		doc.SecurityProtocol = "SASL_SSL"
+		doc.SASLMechanism = "PLAIN"
+		doc.ClientID = data.ClientID
+		doc.ClientSecret = data.ClientSecret
+		if data.OAuthBearer {
+			doc.SASLMechanism = "OAUTHBEARER"
+			doc.TokenURL = data.TokenURL
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(doc)
+}
+
+// envValue escapes a value of the env file, except for the placeholders which must be expanded
+func envValue(v string) string {
+	if v == clientIDPlaceholder || v == clientSecretPlaceholder {
+		return v
+	}
+	return credentials.EnvValue(v)
+}
+
+func newTemplateData(cfg *Configuration) *templateData {
+	data := &templateData{
+		Configuration: cfg,
+		ClientID:      clientIDPlaceholder,
+		ClientSecret:  clientSecretPlaceholder,
+		OAuthBearer:   cfg.SASLMechanism != PlainMechanism,
+	}
+	if cfg.Credentials != nil {
+		data.ClientID = cfg.Credentials.ClientID
+		data.ClientSecret = cfg.Credentials.ClientSecret
+	}
+
+	return data
+}
@@ -0,0 +1,265 @@
+package clientconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/redhat-developer/app-services-cli/pkg/serviceaccount/credentials"
+)
+
+const (
+	testHost        = "my-kafka.kafka.example.com:443"
+	testRegistryURL = "https://registry.example.com/t/1234"
+	testTokenURL    = "https://identity.example.com/auth/realms/rhoas/protocol/openid-connect/token"
+)
+
+func newTestConfiguration(mechanism string, creds *credentials.Credentials) *Configuration {
+	return &Configuration{
+		BootstrapServerHost: testHost,
+		RegistryURL:         testRegistryURL,
+		Credentials:         creds,
+		TokenURL:            testTokenURL,
+		SASLMechanism:       mechanism,
+		Name:                "rhoas-config",
+	}
+}
+
+// nolint:funlen
+func TestWrite(t *testing.T) {
+	creds := &credentials.Credentials{ClientID: "srvc-acct-123", ClientSecret: "secret-456"}
+
+	tests := []struct {
+		name        string
+		configType  string
+		mechanism   string
+		creds       *credentials.Credentials
+		local       bool
+		wantLines   []string
+		unwantLines []string
+	}{
+		{
+			name:       "quarkus with oauthbearer",
+			configType: QuarkusType,
+			mechanism:  OAuthBearerMechanism,
+			creds:      creds,
+			wantLines: []string{
+				"kafka.bootstrap.servers=" + testHost,
+				"kafka.sasl.mechanism=OAUTHBEARER",
+				`  oauth.client.id="srvc-acct-123" \`,
+				`  oauth.token.endpoint.uri="` + testTokenURL + `" ;`,
+				"mp.messaging.connector.smallrye-kafka.apicurio.registry.url=" + testRegistryURL,
+			},
+			unwantLines: []string{"kafka.sasl.mechanism=PLAIN"},
+		},
+		{
+			name:       "spring with plain",
+			configType: SpringType,
+			mechanism:  PlainMechanism,
+			creds:      creds,
+			wantLines: []string{
+				"spring.kafka.bootstrap-servers=" + testHost,
+				"spring.kafka.properties.sasl.mechanism=PLAIN",
+				`  username="srvc-acct-123" \`,
+				`  password="secret-456" ;`,
+			},
+			unwantLines: []string{"spring.kafka.properties.sasl.login.callback.handler.class=io.strimzi.kafka.oauth.client.JaasClientOauthLoginCallbackHandler"},
+		},
+		{
+			name:       "librdkafka with oauthbearer",
+			configType: LibrdkafkaType,
+			mechanism:  OAuthBearerMechanism,
+			creds:      creds,
+			wantLines: []string{
+				"bootstrap.servers=" + testHost,
+				"sasl.mechanisms=OAUTHBEARER",
+				"sasl.oauthbearer.method=oidc",
+				"sasl.oauthbearer.client.secret=secret-456",
+				"sasl.oauthbearer.token.endpoint.url=" + testTokenURL,
+			},
+		},
+		{
+			name:       "librdkafka with plain",
+			configType: LibrdkafkaType,
+			mechanism:  PlainMechanism,
+			creds:      creds,
+			wantLines: []string{
+				"sasl.mechanisms=PLAIN",
+				"sasl.username=srvc-acct-123",
+				"sasl.password=secret-456",
+			},
+			unwantLines: []string{"sasl.oauthbearer.method=oidc"},
+		},
+		{
+			name:       "env without credentials references the environment",
+			configType: EnvType,
+			mechanism:  OAuthBearerMechanism,
+			wantLines: []string{
+				"KAFKA_HOST=" + testHost,
+				"CLIENT_ID=${CLIENT_ID}",
+				"CLIENT_SECRET=${CLIENT_SECRET}",
+				"SERVICE_REGISTRY_URL=" + testRegistryURL,
+			},
+		},
+		{
+			name:       "configmap keeps credentials in a secret",
+			configType: ConfigMapType,
+			mechanism:  PlainMechanism,
+			creds:      creds,
+			wantLines: []string{
+				"kind: ConfigMap",
+				"  name: rhoas-config",
+				`  KAFKA_HOST: "` + testHost + `"`,
+				"kind: Secret",
+				"  name: rhoas-config-credentials",
+				`  CLIENT_SECRET: "secret-456"`,
+			},
+			unwantLines: []string{`  OAUTH_TOKEN_ENDPOINT_URI: "` + testTokenURL + `"`},
+		},
+		{
+			name:       "local quarkus uses plaintext without authentication",
+			configType: QuarkusType,
+			mechanism:  OAuthBearerMechanism,
+			local:      true,
+			wantLines: []string{
+				"kafka.security.protocol=PLAINTEXT",
+				"mp.messaging.connector.smallrye-kafka.apicurio.registry.url=" + testRegistryURL,
+			},
+			unwantLines: []string{
+				"kafka.sasl.mechanism=OAUTHBEARER",
+				"mp.messaging.connector.smallrye-kafka.apicurio.auth.client.id=${CLIENT_ID}",
+			},
+		},
+		{
+			name:       "local env has no credentials",
+			configType: EnvType,
+			mechanism:  OAuthBearerMechanism,
+			local:      true,
+			wantLines:  []string{"KAFKA_HOST=" + testHost},
+			unwantLines: []string{
+				"CLIENT_ID=${CLIENT_ID}",
+				"KAFKA_SASL_MECHANISM=OAUTHBEARER",
+			},
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newTestConfiguration(tt.mechanism, tt.creds)
+			cfg.Local = tt.local
+
+			var buf bytes.Buffer
+			if err := Write(&buf, tt.configType, cfg); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+
+			lines := strings.Split(buf.String(), "\n")
+			for _, want := range tt.wantLines {
+				if !containsLine(lines, want) {
+					t.Errorf("expected line %q in:\n%v", want, buf.String())
+				}
+			}
+			for _, unwant := range tt.unwantLines {
+				if containsLine(lines, unwant) {
+					t.Errorf("unexpected line %q in:\n%v", unwant, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestWrite_EscapesCredentials(t *testing.T) {
+	creds := &credentials.Credentials{ClientID: "srvc-acct-123", ClientSecret: `se"cr\et`}
+
+	// the JAAS configuration is a value of the properties file, so it is escaped twice
+	tests := []struct {
+		configType string
+		mechanism  string
+		wantLines  []string
+	}{
+		{
+			configType: QuarkusType,
+			mechanism:  OAuthBearerMechanism,
+			wantLines: []string{
+				`  oauth.client.secret="se\\"cr\\\\et" \`,
+				`mp.messaging.connector.smallrye-kafka.apicurio.auth.client.secret=se"cr\\et`,
+			},
+		},
+		{
+			configType: SpringType,
+			mechanism:  PlainMechanism,
+			wantLines: []string{
+				`  password="se\\"cr\\\\et" ;`,
+				`spring.kafka.properties.apicurio.auth.client.secret=se"cr\\et`,
+			},
+		},
+		{
+			configType: EnvType,
+			mechanism:  OAuthBearerMechanism,
+			wantLines:  []string{`CLIENT_SECRET="se\"cr\\et"`},
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.configType, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, tt.configType, newTestConfiguration(tt.mechanism, creds)); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+
+			lines := strings.Split(buf.String(), "\n")
+			for _, want := range tt.wantLines {
+				if !containsLine(lines, want) {
+					t.Errorf("expected line %q in:\n%v", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestWrite_JSON(t *testing.T) {
+	cfg := newTestConfiguration(PlainMechanism, &credentials.Credentials{ClientID: "id", ClientSecret: "secret"})
+	cfg.RegistryURL = ""
+
+	var buf bytes.Buffer
+	if err := Write(&buf, JSONType, cfg); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	var got map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+
+	want := map[string]string{
+		"bootstrapServerHost": testHost,
+		"securityProtocol":    "SASL_SSL",
+		"saslMechanism":       "PLAIN",
+		"clientID":            "id",
+		"clientSecret":        "secret",
+	}
+	if len(got) != len(want) {
+		t.Errorf("Write() = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%v = %v, want %v", k, got[k], v)
+		}
+	}
+}
+
+func TestWrite_UnsupportedType(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, "xml", newTestConfiguration(OAuthBearerMechanism, nil)); err == nil {
+		t.Error("Write() expected an error for an unsupported type")
+	}
+}
+
+func containsLine(lines []string, want string) bool {
+	for _, line := range lines {
+		if line == want {
+			return true
+		}
+	}
+	return false
+}
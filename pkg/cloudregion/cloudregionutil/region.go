@@ -0,0 +1,61 @@
+package cloudregionutil
+
+import (
+	"encoding/json"
+	"net/http"
+
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+)
+
+// Instance types of Kafka instances
+const (
+	InstanceTypeStandard = "standard"
+	InstanceTypeEval     = "eval"
+)
+
+// Region is a region of a cloud provider, including the instance types it supports,
+// which the management API returns but the API client does not have a field for yet
+type Region struct {
+	ID                     string   `json:"id" yaml:"id"`
+	DisplayName            string   `json:"display_name" yaml:"display_name"`
+	Enabled                bool     `json:"enabled" yaml:"enabled"`
+	SupportedInstanceTypes []string `json:"supported_instance_types,omitempty" yaml:"supported_instance_types,omitempty"`
+}
+
+// MultiAZ returns true if Kafka instances are deployed across multiple availability zones of the region.
+// Standard instances are deployed across multiple availability zones while evaluation instances are not,
+// so this is the case when the region supports standard instances.
+// When the API does not return the supported instance types, every instance is a standard instance
+func (r *Region) MultiAZ() bool {
+	if r.SupportedInstanceTypes == nil {
+		return true
+	}
+	for _, t := range r.SupportedInstanceTypes {
+		if t == InstanceTypeStandard {
+			return true
+		}
+	}
+	return false
+}
+
+// FromResponse returns the regions of a response of the management API.
+// The body of the response is decoded again for the fields the API client does not have,
+// and the regions of the decoded list are used when it is not available
+func FromResponse(res kafkamgmtclient.CloudRegionList, httpRes *http.Response) []Region {
+	var list struct {
+		Items []Region `json:"items"`
+	}
+	if httpRes != nil && httpRes.Body != nil && json.NewDecoder(httpRes.Body).Decode(&list) == nil && len(list.Items) == len(res.GetItems()) {
+		return list.Items
+	}
+
+	regions := make([]Region, len(res.GetItems()))
+	for i, r := range res.GetItems() {
+		regions[i] = Region{
+			ID:          r.GetId(),
+			DisplayName: r.GetDisplayName(),
+			Enabled:     r.GetEnabled(),
+		}
+	}
+	return regions
+}
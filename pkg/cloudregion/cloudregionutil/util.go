@@ -1,16 +1,22 @@
 package cloudregionutil
 
-import (
-	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
-)
-
 // GetEnabledIDs extracts and returns a slice of the unique IDs of all enabled regions
-func GetEnabledIDs(regions []kafkamgmtclient.CloudRegion) []string {
+func GetEnabledIDs(regions []Region) []string {
 	regionIDs := []string{}
 	for _, region := range regions {
-		if region.GetEnabled() {
-			regionIDs = append(regionIDs, region.GetId())
+		if region.Enabled {
+			regionIDs = append(regionIDs, region.ID)
 		}
 	}
 	return regionIDs
 }
+
+// FindByID finds and returns a region from the list by its ID
+func FindByID(regions []Region, id string) *Region {
+	for _, r := range regions {
+		if r.ID == id {
+			return &r
+		}
+	}
+	return nil
+}
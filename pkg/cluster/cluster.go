@@ -2,6 +2,7 @@ package cluster
 
 import (
 	"context"
+	"time"
 )
 
 type ConnectArguments struct {
@@ -10,6 +11,7 @@ type ConnectArguments struct {
 	IgnoreContext           bool
 	SelectedKafka           string
 	Namespace               string
+	Timeout                 time.Duration
 }
 
 // Cluster defines methods used to interact with a cluster
@@ -17,4 +19,8 @@ type Cluster interface {
 	Connect(ctx context.Context, opts *ConnectArguments) error
 	IsRhoasOperatorAvailableOnCluster(ctx context.Context) (bool, error)
 	CurrentNamespace() (string, error)
+	ListOperators(ctx context.Context) ([]OperatorStatus, error)
+	InstallOperators(ctx context.Context, opts *OperatorArguments) error
+	UpgradeOperators(ctx context.Context, opts *OperatorArguments) error
+	UninstallOperators(ctx context.Context, opts *OperatorArguments) error
 }
@@ -0,0 +1,250 @@
+/**
+ * Waits for status conditions on resources created by the CLI
+ */
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redhat-developer/app-services-cli/pkg/common/clierr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+)
+
+// DefaultWaitTimeout is the default time to wait for a resource to report its condition
+const DefaultWaitTimeout = 60 * time.Second
+
+// DefaultWatchRetryDelay is the default minimum time between the start of two watches
+const DefaultWatchRetryDelay = time.Second
+
+var (
+	// ErrWaitTimeout is returned when the condition is not reported before the timeout expires
+	ErrWaitTimeout = clierr.New(clierr.CodeTimeout, errors.New("timed out waiting for the condition"))
+	// ErrResourceDeleted is returned when the resource is deleted while waiting
+	ErrResourceDeleted = errors.New("resource was deleted while waiting for the condition")
+)
+
+// Condition is a status condition reported by a resource
+type Condition struct {
+	Type    string
+	Status  string
+	Reason  string
+	Message string
+}
+
+// ConditionFailedError is returned when the awaited condition is reported with a "False" status
+type ConditionFailedError struct {
+	Condition Condition
+}
+
+func (e *ConditionFailedError) Error() string {
+	return fmt.Sprintf("condition %v is %v: %v", e.Condition.Type, e.Condition.Status, e.Condition.Message)
+}
+
+// ConditionWaiter waits until a resource reports a condition with a "True" status.
+// The current state of the resource is checked before watching,
+// and the watch is re-established from the last seen resource version when it is dropped.
+type ConditionWaiter struct {
+	Client    dynamic.Interface
+	Resource  schema.GroupVersionResource
+	Namespace string
+	Name      string
+	// ConditionType is the type of the condition which marks the resource as ready
+	ConditionType string
+	// Timeout is the maximum time to wait, DefaultWaitTimeout is used when not set
+	Timeout time.Duration
+	// WatchRetryDelay is the minimum time between the start of two watches,
+	// DefaultWatchRetryDelay is used when not set
+	WatchRetryDelay time.Duration
+	// OnCondition is called for every condition which changed since it was last observed
+	OnCondition func(condition Condition)
+
+	observed map[string]Condition
+}
+
+// Wait blocks until the condition is "True".
+// It returns a *ConditionFailedError when the condition is "False",
+// ErrResourceDeleted when the resource is deleted and ErrWaitTimeout when the timeout expires
+func (w *ConditionWaiter) Wait(ctx context.Context) error {
+	timeout := w.Timeout
+	if timeout == 0 {
+		timeout = DefaultWaitTimeout
+	}
+	retryDelay := w.WatchRetryDelay
+	if retryDelay == 0 {
+		retryDelay = DefaultWatchRetryDelay
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	w.observed = map[string]Condition{}
+
+	var resourceVersion string
+	for {
+		// (re)sync the current state when there is no resource version to resume from
+		if resourceVersion == "" {
+			done, rv, err := w.checkCurrentState(ctx)
+			if done || err != nil {
+				return w.mapContextError(ctx, err)
+			}
+			resourceVersion = rv
+		}
+
+		watchStarted := time.Now()
+		watcher, err := w.client().Watch(ctx, metav1.ListOptions{
+			FieldSelector:       fields.OneTermEqualSelector("metadata.name", w.Name).String(),
+			ResourceVersion:     resourceVersion,
+			AllowWatchBookmarks: true,
+		})
+		if err != nil {
+			return w.mapContextError(ctx, err)
+		}
+
+		var done bool
+		done, resourceVersion, err = w.consume(ctx, watcher, resourceVersion)
+		watcher.Stop()
+		if done {
+			return w.mapContextError(ctx, err)
+		}
+
+		// back off when the watch closed right away, so that a failing server is not flooded with watches
+		if wait := retryDelay - time.Since(watchStarted); wait > 0 {
+			select {
+			case <-ctx.Done():
+				return w.mapContextError(ctx, ctx.Err())
+			case <-time.After(wait):
+			}
+		}
+	}
+}
+
+func (w *ConditionWaiter) client() dynamic.ResourceInterface {
+	if w.Namespace == "" {
+		return w.Client.Resource(w.Resource)
+	}
+	return w.Client.Resource(w.Resource).Namespace(w.Namespace)
+}
+
+// checkCurrentState evaluates the resource as it currently exists on the cluster
+func (w *ConditionWaiter) checkCurrentState(ctx context.Context) (bool, string, error) {
+	obj, err := w.client().Get(ctx, w.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, "", nil
+	}
+	if err != nil {
+		return true, "", err
+	}
+
+	done, err := w.evaluate(obj)
+	return done, obj.GetResourceVersion(), err
+}
+
+// consume processes watch events until the condition is resolved or the watch is closed.
+// It returns the last seen resource version, which is empty when the watch must be resynced
+func (w *ConditionWaiter) consume(ctx context.Context, watcher watch.Interface, resourceVersion string) (bool, string, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return true, resourceVersion, ctx.Err()
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				// the server closed the watch, resume from the last resource version
+				return false, resourceVersion, nil
+			}
+
+			switch event.Type {
+			case watch.Error:
+				err := apierrors.FromObject(event.Object)
+				if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+					return false, "", nil
+				}
+				return true, resourceVersion, err
+			case watch.Deleted:
+				return true, resourceVersion, ErrResourceDeleted
+			case watch.Added, watch.Modified, watch.Bookmark:
+				obj, ok := event.Object.(*unstructured.Unstructured)
+				if !ok {
+					continue
+				}
+				resourceVersion = obj.GetResourceVersion()
+				if event.Type == watch.Bookmark {
+					continue
+				}
+				if done, err := w.evaluate(obj); done {
+					return true, resourceVersion, err
+				}
+			}
+		}
+	}
+}
+
+// evaluate reports changed conditions and checks whether the awaited condition is resolved
+func (w *ConditionWaiter) evaluate(obj *unstructured.Unstructured) (bool, error) {
+	for _, condition := range conditionsOf(obj) {
+		if previous, seen := w.observed[condition.Type]; !seen || previous != condition {
+			w.observed[condition.Type] = condition
+			if w.OnCondition != nil {
+				w.OnCondition(condition)
+			}
+		}
+
+		if condition.Type != w.ConditionType {
+			continue
+		}
+		switch condition.Status {
+		case "True":
+			return true, nil
+		case "False":
+			return true, &ConditionFailedError{Condition: condition}
+		}
+	}
+
+	return false, nil
+}
+
+func (w *ConditionWaiter) mapContextError(ctx context.Context, err error) error {
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return ErrWaitTimeout
+	}
+	return err
+}
+
+// conditionsOf reads the status conditions of a resource, ignoring malformed entries
+func conditionsOf(obj *unstructured.Unstructured) []Condition {
+	// read without copying, the conditions are only inspected
+	field, _, _ := unstructured.NestedFieldNoCopy(obj.Object, "status", "conditions")
+	rawConditions, _ := field.([]interface{})
+
+	conditions := make([]Condition, 0, len(rawConditions))
+	for _, raw := range rawConditions {
+		typedCondition, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		condition := Condition{
+			Type:    stringField(typedCondition, "type"),
+			Status:  stringField(typedCondition, "status"),
+			Reason:  stringField(typedCondition, "reason"),
+			Message: stringField(typedCondition, "message"),
+		}
+		if condition.Type == "" {
+			continue
+		}
+		conditions = append(conditions, condition)
+	}
+
+	return conditions
+}
+
+func stringField(m map[string]interface{}, key string) string {
+	value, _ := m[key].(string)
+	return value
+}
@@ -0,0 +1,300 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const (
+	testName      = "my-kafka"
+	testNamespace = "my-namespace"
+)
+
+func newKafkaConnection(resourceVersion string, conditions ...interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": AKCRMeta.APIVersion,
+			"kind":       AKCRMeta.Kind,
+			"metadata": map[string]interface{}{
+				"name":            testName,
+				"namespace":       testNamespace,
+				"resourceVersion": resourceVersion,
+			},
+		},
+	}
+	if len(conditions) > 0 {
+		obj.Object["status"] = map[string]interface{}{"conditions": conditions}
+	}
+	return obj
+}
+
+func condition(conditionType, status, message string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":    conditionType,
+		"status":  status,
+		"message": message,
+	}
+}
+
+// newFakeClient creates a fake dynamic client which serves the given watchers in order
+func newFakeClient(existing *unstructured.Unstructured, watchers ...*watch.FakeWatcher) *dynamicfake.FakeDynamicClient {
+	var objects []runtime.Object
+	if existing != nil {
+		objects = append(objects, existing)
+	}
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{AKCResource: "KafkaConnectionList"}, objects...)
+
+	next := 0
+	client.PrependWatchReactor(AKCResource.Resource, func(action k8stesting.Action) (bool, watch.Interface, error) {
+		if next >= len(watchers) {
+			return true, watch.NewFake(), nil
+		}
+		w := watchers[next]
+		next++
+		return true, w, nil
+	})
+
+	return client
+}
+
+// newPrefilledWatcher creates a watcher with the events already queued.
+// When closed is true the watch is closed after the events, as if dropped by the server
+func newPrefilledWatcher(closed bool, events ...watch.Event) *watch.FakeWatcher {
+	w := watch.NewFakeWithChanSize(len(events), false)
+	for _, e := range events {
+		w.Action(e.Type, e.Object)
+	}
+	if closed {
+		w.Stop()
+	}
+	return w
+}
+
+func watchResourceVersions(client *dynamicfake.FakeDynamicClient) []string {
+	var versions []string
+	for _, action := range client.Actions() {
+		if watchAction, ok := action.(k8stesting.WatchAction); ok {
+			versions = append(versions, watchAction.GetWatchRestrictions().ResourceVersion)
+		}
+	}
+	return versions
+}
+
+func newWaiter(client *dynamicfake.FakeDynamicClient, observed *[]Condition) *ConditionWaiter {
+	return &ConditionWaiter{
+		Client:          client,
+		Resource:        AKCResource,
+		Namespace:       testNamespace,
+		Name:            testName,
+		ConditionType:   "Finished",
+		Timeout:         time.Second,
+		WatchRetryDelay: 10 * time.Millisecond,
+		OnCondition: func(c Condition) {
+			*observed = append(*observed, c)
+		},
+	}
+}
+
+func TestConditionWaiter_ReadyBeforeWatching(t *testing.T) {
+	client := newFakeClient(newKafkaConnection("1", condition("Finished", "True", "")))
+
+	var observed []Condition
+	if err := newWaiter(client, &observed).Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	if versions := watchResourceVersions(client); len(versions) != 0 {
+		t.Errorf("expected no watch to be started, got %v", versions)
+	}
+	if len(observed) != 1 {
+		t.Errorf("expected 1 observed condition, got %v", observed)
+	}
+}
+
+func TestConditionWaiter_ReportsIntermediateConditions(t *testing.T) {
+	w := newPrefilledWatcher(false,
+		watch.Event{Type: watch.Modified, Object: newKafkaConnection("2", condition("Finished", "Unknown", "reconciling"))},
+		watch.Event{Type: watch.Modified, Object: newKafkaConnection("3",
+			condition("AcquiredCredentials", "True", ""),
+			condition("Finished", "True", ""))},
+	)
+	client := newFakeClient(newKafkaConnection("1"), w)
+
+	var observed []Condition
+	if err := newWaiter(client, &observed).Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	want := []Condition{
+		{Type: "Finished", Status: "Unknown", Message: "reconciling"},
+		{Type: "AcquiredCredentials", Status: "True"},
+		{Type: "Finished", Status: "True"},
+	}
+	if len(observed) != len(want) {
+		t.Fatalf("observed conditions = %v, want %v", observed, want)
+	}
+	for i := range want {
+		if observed[i] != want[i] {
+			t.Errorf("observed[%v] = %v, want %v", i, observed[i], want[i])
+		}
+	}
+
+	if versions := watchResourceVersions(client); len(versions) != 1 || versions[0] != "1" {
+		t.Errorf("watch resource versions = %v, want [1]", versions)
+	}
+}
+
+func TestConditionWaiter_ResumesDroppedWatch(t *testing.T) {
+	first := newPrefilledWatcher(true,
+		watch.Event{Type: watch.Modified, Object: newKafkaConnection("5", condition("Finished", "Unknown", ""))},
+	)
+	second := newPrefilledWatcher(false,
+		watch.Event{Type: watch.Modified, Object: newKafkaConnection("6", condition("Finished", "True", ""))},
+	)
+	client := newFakeClient(newKafkaConnection("4"), first, second)
+
+	var observed []Condition
+	if err := newWaiter(client, &observed).Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	versions := watchResourceVersions(client)
+	if len(versions) != 2 || versions[0] != "4" || versions[1] != "5" {
+		t.Errorf("watch resource versions = %v, want [4 5]", versions)
+	}
+}
+
+func TestConditionWaiter_BacksOffClosedWatch(t *testing.T) {
+	closed := newPrefilledWatcher(true)
+	ready := newPrefilledWatcher(false,
+		watch.Event{Type: watch.Modified, Object: newKafkaConnection("2", condition("Finished", "True", ""))},
+	)
+	client := newFakeClient(newKafkaConnection("1"), closed, ready)
+
+	var observed []Condition
+	waiter := newWaiter(client, &observed)
+	waiter.WatchRetryDelay = 200 * time.Millisecond
+
+	start := time.Now()
+	if err := waiter.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < waiter.WatchRetryDelay {
+		t.Errorf("the watch was re-established after %v, want at least %v", elapsed, waiter.WatchRetryDelay)
+	}
+
+	// the closed watch keeps being retried until the timeout expires
+	client = newFakeClient(newKafkaConnection("1"), newPrefilledWatcher(true), newPrefilledWatcher(true), newPrefilledWatcher(true))
+	waiter = newWaiter(client, &observed)
+	waiter.Timeout = 300 * time.Millisecond
+	waiter.WatchRetryDelay = 200 * time.Millisecond
+	if err := waiter.Wait(context.Background()); !errors.Is(err, ErrWaitTimeout) {
+		t.Errorf("Wait() error = %v, want %v", err, ErrWaitTimeout)
+	}
+	if versions := watchResourceVersions(client); len(versions) != 2 {
+		t.Errorf("watch resource versions = %v, want 2 watches", versions)
+	}
+}
+
+func TestConditionWaiter_ResyncsExpiredWatch(t *testing.T) {
+	expired := apierrors.NewResourceExpired("too old resource version")
+	first := newPrefilledWatcher(false,
+		watch.Event{Type: watch.Error, Object: &expired.ErrStatus},
+	)
+	second := newPrefilledWatcher(false,
+		watch.Event{Type: watch.Modified, Object: newKafkaConnection("9", condition("Finished", "True", ""))},
+	)
+	client := newFakeClient(newKafkaConnection("7"), first, second)
+
+	var observed []Condition
+	if err := newWaiter(client, &observed).Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	// the resource is fetched again, so the second watch starts from its current version
+	versions := watchResourceVersions(client)
+	if len(versions) != 2 || versions[0] != "7" || versions[1] != "7" {
+		t.Errorf("watch resource versions = %v, want [7 7]", versions)
+	}
+}
+
+func TestConditionWaiter_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		events  []watch.Event
+		wantErr func(err error) bool
+	}{
+		{
+			name: "condition is false",
+			events: []watch.Event{
+				{Type: watch.Modified, Object: newKafkaConnection("2", condition("Finished", "False", "invalid credentials"))},
+			},
+			wantErr: func(err error) bool {
+				var conditionErr *ConditionFailedError
+				return errors.As(err, &conditionErr) && conditionErr.Condition.Message == "invalid credentials"
+			},
+		},
+		{
+			name: "resource is deleted",
+			events: []watch.Event{
+				{Type: watch.Deleted, Object: newKafkaConnection("2")},
+			},
+			wantErr: func(err error) bool {
+				return errors.Is(err, ErrResourceDeleted)
+			},
+		},
+		{
+			name: "malformed conditions are ignored until the timeout",
+			events: []watch.Event{
+				{Type: watch.Modified, Object: newKafkaConnection("2", "not-a-condition", map[string]interface{}{"type": 5})},
+			},
+			wantErr: func(err error) bool {
+				return errors.Is(err, ErrWaitTimeout)
+			},
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeClient(newKafkaConnection("1"), newPrefilledWatcher(false, tt.events...))
+
+			var observed []Condition
+			waiter := newWaiter(client, &observed)
+			waiter.Timeout = 100 * time.Millisecond
+
+			err := waiter.Wait(context.Background())
+			if !tt.wantErr(err) {
+				t.Errorf("Wait() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestConditionWaiter_WaitsForMissingResource(t *testing.T) {
+	w := newPrefilledWatcher(false,
+		watch.Event{Type: watch.Added, Object: newKafkaConnection("1")},
+		watch.Event{Type: watch.Modified, Object: newKafkaConnection("2", condition("Finished", "True", ""))},
+	)
+	client := newFakeClient(nil, w)
+
+	var observed []Condition
+	if err := newWaiter(client, &observed).Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	// there is no resource version to resume from when the resource does not exist yet
+	if versions := watchResourceVersions(client); len(versions) != 1 || versions[0] != "" {
+		t.Errorf("watch resource versions = %q, want [\"\"]", versions)
+	}
+}
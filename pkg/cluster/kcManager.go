@@ -5,17 +5,14 @@ package cluster
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/redhat-developer/app-services-cli/pkg/localize"
 	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/fields"
-	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/watch"
 )
 
 var (
@@ -82,7 +79,7 @@ func getKafkaConnectionsAPIURL(namespace string) string {
 	return fmt.Sprintf("/apis/rhoas.redhat.com/v1alpha1/namespaces/%v/kafkaconnections", namespace)
 }
 
-func watchForKafkaStatus(c *KubernetesCluster, crName string, namespace string) error {
+func watchForKafkaStatus(c *KubernetesCluster, crName string, namespace string, timeout time.Duration) error {
 	c.logger.Info(c.localizer.MustLocalize("cluster.kubernetes.watchForKafkaStatus.log.info.wait"))
 
 	templateEntries := []*localize.TemplateEntry{
@@ -94,53 +91,35 @@ func watchForKafkaStatus(c *KubernetesCluster, crName string, namespace string)
 	}
 	fmt.Fprint(c.io.Out, c.localizer.MustLocalize("cluster.kubernetes.watchForKafkaStatus.binding", templateEntries...))
 
-	w, err := c.dynamicClient.Resource(AKCResource).Namespace(namespace).Watch(context.TODO(), metav1.ListOptions{
-		FieldSelector: fields.OneTermEqualSelector("metadata.name", crName).String(),
-	})
-	if err != nil {
-		return err
+	waiter := &ConditionWaiter{
+		Client:        c.dynamicClient,
+		Resource:      AKCResource,
+		Namespace:     namespace,
+		Name:          crName,
+		ConditionType: "Finished",
+		Timeout:       timeout,
+		OnCondition: func(condition Condition) {
+			c.logger.Info(c.localizer.MustLocalize("cluster.kubernetes.watchForKafkaStatus.log.info.condition",
+				localize.NewEntry("Type", condition.Type),
+				localize.NewEntry("Status", condition.Status),
+				localize.NewEntry("Reason", condition.Reason),
+				localize.NewEntry("Message", condition.Message)))
+		},
 	}
 
-	for {
-		select {
-		case event := <-w.ResultChan():
-			if event.Type == watch.Modified {
-				unstructuredObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(event.Object)
-				if err != nil {
-					return err
-				}
-				conditions, found, err := unstructured.NestedSlice(unstructuredObj, "status", "conditions")
-				if err != nil {
-					return err
-				}
-
-				if found {
-					for _, condition := range conditions {
-						typedCondition, ok := condition.(map[string]interface{})
-						if !ok {
-							return fmt.Errorf(c.localizer.MustLocalize("cluster.kubernetes.watchForKafkaStatus.error.format"), typedCondition)
-						}
-						if typedCondition["type"].(string) == "Finished" {
-							if typedCondition["status"].(string) == "False" {
-								w.Stop()
-								return fmt.Errorf(c.localizer.MustLocalize("cluster.kubernetes.watchForKafkaStatus.error.status"), typedCondition["message"])
-							}
-							if typedCondition["status"].(string) == "True" {
-								c.logger.Info(c.localizer.MustLocalize("cluster.kubernetes.watchForKafkaStatus.log.info.success", localize.NewEntry("Name", crName), localize.NewEntry("Namespace", namespace)))
-
-								w.Stop()
-								return nil
-							}
-						}
-					}
-					w.Stop()
-				}
-			}
-
-		case <-time.After(60 * time.Second):
-			w.Stop()
-			return fmt.Errorf(c.localizer.MustLocalize("cluster.kubernetes.watchForKafkaStatus.error.timeout"))
-		}
+	err := waiter.Wait(context.Background())
+
+	var conditionErr *ConditionFailedError
+	switch {
+	case err == nil:
+		c.logger.Info(c.localizer.MustLocalize("cluster.kubernetes.watchForKafkaStatus.log.info.success", localize.NewEntry("Name", crName), localize.NewEntry("Namespace", namespace)))
+		return nil
+	case errors.As(err, &conditionErr):
+		return fmt.Errorf(c.localizer.MustLocalize("cluster.kubernetes.watchForKafkaStatus.error.status"), conditionErr.Condition.Message)
+	case errors.Is(err, ErrWaitTimeout):
+		return errors.New(c.localizer.MustLocalize("cluster.kubernetes.watchForKafkaStatus.error.timeout", localize.NewEntry("Timeout", timeout)))
+	default:
+		return err
 	}
 }
 
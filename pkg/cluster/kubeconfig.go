@@ -0,0 +1,150 @@
+package cluster
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/redhat-developer/app-services-cli/pkg/localize"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// KubeConfigOptions selects the kubeconfig, context and cluster used to connect to Kubernetes
+type KubeConfigOptions struct {
+	// Kubeconfig is the path to a kubeconfig file.
+	// When empty, the paths in the KUBECONFIG environment variable are merged,
+	// falling back to ~/.kube/config
+	Kubeconfig string
+	// Context is the name of the kubeconfig context to use instead of the current context
+	Context string
+	// Cluster is the name of the kubeconfig cluster to use instead of the one set in the context
+	Cluster string
+}
+
+// KubernetesClients contains the clients used to interact with a Kubernetes cluster
+type KubernetesClients struct {
+	clientset     *kubernetes.Clientset
+	dynamicClient dynamic.Interface
+	restConfig    *rest.Config
+	clientConfig  clientcmd.ClientConfig
+}
+
+// NewKubernetesClients creates the Kubernetes clients from the kubeconfig selected by opts.
+// When no kubeconfig can be found and the CLI is running inside a pod,
+// the in-cluster service account configuration is used
+func NewKubernetesClients(opts *KubeConfigOptions, localizer localize.Localizer) (*KubernetesClients, error) {
+	clientConfig, err := newClientConfig(opts)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", localizer.MustLocalize("cluster.kubernetes.error.configNotFoundError"), err)
+	}
+
+	restConfig, err := clientConfig.ClientConfig()
+	if clientcmd.IsEmptyConfig(err) {
+		return nil, fmt.Errorf("%v: %w", localizer.MustLocalize("cluster.kubernetes.error.configNotFoundError"), err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", localizer.MustLocalize("cluster.kubernetes.error.loadConfigError"), err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", localizer.MustLocalize("cluster.kubernetes.error.loadConfigError"), err)
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", localizer.MustLocalize("cluster.kubernetes.error.loadConfigError"), err)
+	}
+
+	return &KubernetesClients{
+		clientset:     clientset,
+		dynamicClient: dynamicClient,
+		restConfig:    restConfig,
+		clientConfig:  clientConfig,
+	}, nil
+}
+
+// KubeConfigContexts returns the names of the contexts in the kubeconfig selected by opts
+func KubeConfigContexts(opts *KubeConfigOptions) ([]string, error) {
+	rawConfig, err := rawKubeConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(rawConfig.Contexts))
+	for name := range rawConfig.Contexts {
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// KubeConfigClusters returns the names of the clusters in the kubeconfig selected by opts
+func KubeConfigClusters(opts *KubeConfigOptions) ([]string, error) {
+	rawConfig, err := rawKubeConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(rawConfig.Clusters))
+	for name := range rawConfig.Clusters {
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+func newClientConfig(opts *KubeConfigOptions) (clientcmd.ClientConfig, error) {
+	if opts == nil {
+		opts = &KubeConfigOptions{}
+	}
+
+	// the default loading rules merge every path in $KUBECONFIG
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if opts.Kubeconfig != "" {
+		if _, err := os.Stat(opts.Kubeconfig); err != nil {
+			return nil, err
+		}
+		loadingRules.ExplicitPath = opts.Kubeconfig
+	}
+
+	overrides := &clientcmd.ConfigOverrides{
+		CurrentContext: opts.Context,
+	}
+	overrides.Context.Cluster = opts.Cluster
+
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides), nil
+}
+
+func rawKubeConfig(opts *KubeConfigOptions) (*clientcmdapi.Config, error) {
+	clientConfig, err := newClientConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rawConfig, err := clientConfig.RawConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return &rawConfig, nil
+}
+
+// Clientset returns the client of the Kubernetes API
+func (c *KubernetesClients) Clientset() kubernetes.Interface {
+	return c.clientset
+}
+
+// DynamicClient returns the client of the custom resources
+func (c *KubernetesClients) DynamicClient() dynamic.Interface {
+	return c.dynamicClient
+}
+
+// CurrentNamespace returns the namespace of the kubeconfig context
+func (c *KubernetesClients) CurrentNamespace() (string, error) {
+	namespace, _, err := c.clientConfig.Namespace()
+	return namespace, err
+}
@@ -0,0 +1,121 @@
+package cluster
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const kubeconfigTmpl = `apiVersion: v1
+kind: Config
+clusters:
+- name: %[1]v-cluster
+  cluster:
+    server: https://%[1]v.example.com:6443
+contexts:
+- name: %[1]v
+  context:
+    cluster: %[1]v-cluster
+    namespace: %[1]v-ns
+    user: %[1]v-user
+current-context: %[1]v
+users:
+- name: %[1]v-user
+  user:
+    token: %[1]v-token
+`
+
+func writeKubeconfig(t *testing.T, dir string, name string) string {
+	path := filepath.Join(dir, name+".yaml")
+	data := []byte(fmt.Sprintf(kubeconfigTmpl, name))
+	if err := ioutil.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// nolint:funlen
+func TestNewClientConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubeconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	first := writeKubeconfig(t, dir, "first")
+	second := writeKubeconfig(t, dir, "second")
+
+	tests := []struct {
+		name          string
+		envKubeconfig string
+		opts          *KubeConfigOptions
+		wantHost      string
+		wantNamespace string
+	}{
+		{
+			name:          "uses the current context of the first file in KUBECONFIG",
+			envKubeconfig: first + string(os.PathListSeparator) + second,
+			opts:          &KubeConfigOptions{},
+			wantHost:      "https://first.example.com:6443",
+			wantNamespace: "first-ns",
+		},
+		{
+			name:          "selects a context from a merged KUBECONFIG file",
+			envKubeconfig: first + string(os.PathListSeparator) + second,
+			opts:          &KubeConfigOptions{Context: "second"},
+			wantHost:      "https://second.example.com:6443",
+			wantNamespace: "second-ns",
+		},
+		{
+			name:          "overrides the cluster of the current context",
+			envKubeconfig: first + string(os.PathListSeparator) + second,
+			opts:          &KubeConfigOptions{Cluster: "second-cluster"},
+			wantHost:      "https://second.example.com:6443",
+			wantNamespace: "first-ns",
+		},
+		{
+			name:          "explicit kubeconfig takes precedence over KUBECONFIG",
+			envKubeconfig: first,
+			opts:          &KubeConfigOptions{Kubeconfig: second},
+			wantHost:      "https://second.example.com:6443",
+			wantNamespace: "second-ns",
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("KUBECONFIG", tt.envKubeconfig)
+			defer os.Unsetenv("KUBECONFIG")
+
+			clientConfig, err := newClientConfig(tt.opts)
+			if err != nil {
+				t.Fatalf("newClientConfig() error = %v", err)
+			}
+
+			restConfig, err := clientConfig.ClientConfig()
+			if err != nil {
+				t.Fatalf("ClientConfig() error = %v", err)
+			}
+			if restConfig.Host != tt.wantHost {
+				t.Errorf("Host = %v, want %v", restConfig.Host, tt.wantHost)
+			}
+
+			namespace, _, err := clientConfig.Namespace()
+			if err != nil {
+				t.Fatalf("Namespace() error = %v", err)
+			}
+			if namespace != tt.wantNamespace {
+				t.Errorf("Namespace = %v, want %v", namespace, tt.wantNamespace)
+			}
+		})
+	}
+}
+
+func TestNewClientConfig_MissingExplicitKubeconfig(t *testing.T) {
+	_, err := newClientConfig(&KubeConfigOptions{Kubeconfig: filepath.Join(os.TempDir(), "does-not-exist.yaml")})
+	if err == nil {
+		t.Error("newClientConfig() expected an error for a missing kubeconfig file")
+	}
+}
@@ -5,8 +5,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
 	"time"
 
 	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
@@ -15,19 +13,21 @@ import (
 	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
 	"github.com/redhat-developer/app-services-cli/pkg/kafka/kafkaerr"
 	"github.com/redhat-developer/app-services-cli/pkg/localize"
+	"github.com/redhat-developer/app-services-cli/pkg/serviceaccount/serviceaccountutil"
 
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 
 	"github.com/dgrijalva/jwt-go"
 
 	"github.com/redhat-developer/app-services-cli/internal/build"
-	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/redhat-developer/app-services-cli/pkg/color"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/redhat-developer/app-services-cli/internal/config"
@@ -41,12 +41,13 @@ type KubernetesCluster struct {
 	config     config.IConfig
 	logger     logging.Logger
 
-	clientset          *kubernetes.Clientset
-	clientconfig       clientcmd.ClientConfig
-	dynamicClient      dynamic.Interface
-	io                 *iostreams.IOStreams
-	localizer          localize.Localizer
-	kubeconfigLocation string
+	clientset     *kubernetes.Clientset
+	discovery     discovery.DiscoveryInterface
+	clientconfig  clientcmd.ClientConfig
+	restConfig    *rest.Config
+	dynamicClient dynamic.Interface
+	io            *iostreams.IOStreams
+	localizer     localize.Localizer
 }
 
 /*  #nosec */
@@ -59,53 +60,24 @@ var serviceAccountSecretName = "rh-cloud-services-service-account"
 func NewKubernetesClusterConnection(connection connection.Connection,
 	config config.IConfig,
 	logger logging.Logger,
-	kubeconfig string,
+	kubeConfig *KubeConfigOptions,
 	io *iostreams.IOStreams, localizer localize.Localizer) (Cluster, error) {
-	if kubeconfig == "" {
-		kubeconfig = os.Getenv("KUBECONFIG")
-	}
-
-	if kubeconfig == "" {
-		home, _ := os.UserHomeDir()
-		kubeconfig = filepath.Join(home, ".kube", "config")
-	}
-
-	_, err := os.Stat(kubeconfig)
-	if err != nil {
-		return nil, fmt.Errorf("%v: %w", localizer.MustLocalize("cluster.kubernetes.error.configNotFoundError"), err)
-	}
-
-	kubeClientConfig, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
-	if err != nil {
-		return nil, fmt.Errorf("%v: %w", localizer.MustLocalize("cluster.kubernetes.error.loadConfigError"), err)
-	}
-
-	// create the clientset for using Rest Client
-	clientset, err := kubernetes.NewForConfig(kubeClientConfig)
-	if err != nil {
-		return nil, fmt.Errorf("%v: %w", localizer.MustLocalize("cluster.kubernetes.error.loadConfigError"), err)
-	}
-
-	// Used for namespaces and general queries
-	clientconfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig},
-		&clientcmd.ConfigOverrides{ClusterInfo: clientcmdapi.Cluster{Server: ""}})
-
-	dynamicClient, err := dynamic.NewForConfig(kubeClientConfig)
+	clients, err := NewKubernetesClients(kubeConfig, localizer)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %w", localizer.MustLocalize("cluster.kubernetes.error.loadConfigError"), err)
+		return nil, err
 	}
 
 	k8sCluster := &KubernetesCluster{
-		connection,
-		config,
-		logger,
-		clientset,
-		clientconfig,
-		dynamicClient,
-		io,
-		localizer,
-		kubeconfig,
+		connection:    connection,
+		config:        config,
+		logger:        logger,
+		clientset:     clients.clientset,
+		discovery:     clients.clientset.Discovery(),
+		clientconfig:  clients.clientConfig,
+		restConfig:    clients.restConfig,
+		dynamicClient: clients.dynamicClient,
+		io:            io,
+		localizer:     localizer,
 	}
 
 	return k8sCluster, nil
@@ -180,7 +152,7 @@ func (c *KubernetesCluster) Connect(ctx context.Context, cmdOptions *ConnectArgu
 		return err
 	}
 
-	err = c.createKafkaConnectionCustomResource(ctx, currentNamespace, &kafkaInstance)
+	err = c.createKafkaConnectionCustomResource(ctx, currentNamespace, &kafkaInstance, cmdOptions.Timeout)
 	if err != nil {
 		return err
 	}
@@ -189,7 +161,7 @@ func (c *KubernetesCluster) Connect(ctx context.Context, cmdOptions *ConnectArgu
 }
 
 // createKafkaConnectionCustomResource creates a new "KafkaConnection" CR
-func (c *KubernetesCluster) createKafkaConnectionCustomResource(ctx context.Context, namespace string, kafkaInstance *kafkamgmtclient.KafkaRequest) error {
+func (c *KubernetesCluster) createKafkaConnectionCustomResource(ctx context.Context, namespace string, kafkaInstance *kafkamgmtclient.KafkaRequest, timeout time.Duration) error {
 	crName := kafkaInstance.GetName()
 	kafkaID := kafkaInstance.GetId()
 
@@ -212,7 +184,7 @@ func (c *KubernetesCluster) createKafkaConnectionCustomResource(ctx context.Cont
 
 	c.logger.Info(c.localizer.MustLocalize("cluster.kubernetes.createKafkaCR.log.info.customResourceCreated", localize.NewEntry("Name", crName)))
 
-	return watchForKafkaStatus(c, crName, namespace)
+	return watchForKafkaStatus(c, crName, namespace, timeout)
 }
 
 // IsRhoasOperatorAvailableOnCluster checks the cluster to see if a KafkaConnection CRD is installed
@@ -311,7 +283,7 @@ func (c *KubernetesCluster) createServiceAccount(ctx context.Context) (*kafkamgm
 	t := time.Now()
 
 	api := c.connection.API()
-	serviceAcct := &kafkamgmtclient.ServiceAccountRequest{Name: fmt.Sprintf("rhoascli-%v", t.Unix())}
+	serviceAcct := &kafkamgmtclient.ServiceAccountRequest{Name: serviceaccountutil.NewGeneratedName(t)}
 	req := api.ServiceAccount().CreateServiceAccount(ctx)
 	req = req.ServiceAccountRequest(*serviceAcct)
 	res, _, err := req.Execute()
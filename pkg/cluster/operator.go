@@ -0,0 +1,669 @@
+/**
+ * Handles installation, upgrade and removal of the operators required by the CLI
+ */
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/redhat-developer/app-services-cli/pkg/localize"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+//go:embed manifests
+var operatorManifests embed.FS
+
+const (
+	// OperatorInstallMethodOLM is used when the operator is managed by an OLM Subscription
+	OperatorInstallMethodOLM = "OLM"
+	// OperatorInstallMethodManifests is used when the operator was installed from the bundled manifests
+	OperatorInstallMethodManifests = "manifests"
+
+	// RHOASOperatorName is the display name of the RHOAS Operator
+	RHOASOperatorName = "RHOAS Operator"
+	// ServiceBindingOperatorName is the display name of the Service Binding Operator
+	ServiceBindingOperatorName = "Service Binding Operator"
+
+	// DefaultOperatorTimeout is the default time to wait for the operator CRDs to become established
+	DefaultOperatorTimeout = 2 * time.Minute
+
+	olmGroupVersion       = "operators.coreos.com/v1alpha1"
+	openshiftGroupVersion = "config.openshift.io/v1"
+	versionLabel          = "app.kubernetes.io/version"
+)
+
+var (
+	subscriptionResource = schema.GroupVersionResource{Group: "operators.coreos.com", Version: "v1alpha1", Resource: "subscriptions"}
+	csvResource          = schema.GroupVersionResource{Group: "operators.coreos.com", Version: "v1alpha1", Resource: "clusterserviceversions"}
+	installPlanResource  = schema.GroupVersionResource{Group: "operators.coreos.com", Version: "v1alpha1", Resource: "installplans"}
+	crdResource          = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+)
+
+// OperatorArguments contains the options for installing, upgrading and uninstalling operators
+type OperatorArguments struct {
+	ForceCreationWithoutAsk bool
+	Timeout                 time.Duration
+}
+
+// OperatorStatus describes the state of an operator on the cluster
+type OperatorStatus struct {
+	Name      string
+	Installed bool
+	Version   string
+	Method    string
+}
+
+// olmPackage identifies an operator package in an OLM catalog
+type olmPackage struct {
+	name    string
+	channel string
+	source  string
+}
+
+// olmTarget describes where Subscriptions are created for a flavour of OLM
+type olmTarget struct {
+	namespace       string
+	sourceNamespace string
+	openshift       bool
+}
+
+type operatorSpec struct {
+	name       string
+	openshift  olmPackage
+	upstream   olmPackage
+	manifests  string
+	namespace  string
+	deployment string
+	crds       []string
+}
+
+var operatorSpecs = []operatorSpec{
+	{
+		name:       RHOASOperatorName,
+		openshift:  olmPackage{name: "rhoas-operator", channel: "beta", source: "community-operators"},
+		upstream:   olmPackage{name: "rhoas-operator", channel: "beta", source: "operatorhubio-catalog"},
+		manifests:  "manifests/rhoas-operator.yaml",
+		namespace:  "rhoas-operator",
+		deployment: "rhoas-operator",
+		crds:       []string{"kafkaconnections.rhoas.redhat.com"},
+	},
+	{
+		name:       ServiceBindingOperatorName,
+		openshift:  olmPackage{name: "rh-service-binding-operator", channel: "preview", source: "redhat-operators"},
+		upstream:   olmPackage{name: "service-binding-operator", channel: "beta", source: "operatorhubio-catalog"},
+		manifests:  "manifests/service-binding-operator.yaml",
+		namespace:  "service-binding-operator",
+		deployment: "service-binding-operator",
+		crds:       []string{"servicebindings.binding.operators.coreos.com"},
+	},
+}
+
+var (
+	openshiftOLM = &olmTarget{namespace: "openshift-operators", sourceNamespace: "openshift-marketplace", openshift: true}
+	upstreamOLM  = &olmTarget{namespace: "operators", sourceNamespace: "olm"}
+)
+
+func (o *olmTarget) packageFor(spec *operatorSpec) olmPackage {
+	if o.openshift {
+		return spec.openshift
+	}
+	return spec.upstream
+}
+
+// ListOperators returns the status of each operator used by the CLI
+func (c *KubernetesCluster) ListOperators(ctx context.Context) ([]OperatorStatus, error) {
+	olm, err := c.detectOLM()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]OperatorStatus, 0, len(operatorSpecs))
+	for i := range operatorSpecs {
+		status, err := c.operatorStatus(ctx, &operatorSpecs[i], olm)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, *status)
+	}
+
+	return statuses, nil
+}
+
+// InstallOperators installs the RHOAS Operator and the Service Binding Operator,
+// using OLM when it is available on the cluster and the bundled manifests otherwise
+func (c *KubernetesCluster) InstallOperators(ctx context.Context, opts *OperatorArguments) error {
+	olm, err := c.detectOLM()
+	if err != nil {
+		return err
+	}
+
+	var pending []*operatorSpec
+	for i := range operatorSpecs {
+		spec := &operatorSpecs[i]
+		status, err := c.operatorStatus(ctx, spec, olm)
+		if err != nil {
+			return err
+		}
+		if status.Installed {
+			c.logger.Info(c.localizer.MustLocalize("cluster.operator.log.info.alreadyInstalled", localize.NewEntry("Name", spec.name), localize.NewEntry("Version", status.Version)))
+			continue
+		}
+		pending = append(pending, spec)
+	}
+
+	if len(pending) == 0 {
+		c.logger.Info(c.localizer.MustLocalize("cluster.operator.log.info.nothingToInstall"))
+		return nil
+	}
+
+	c.logger.Info(c.localizer.MustLocalize("cluster.operator.log.info.installPlan",
+		localize.NewEntry("Operators", operatorNames(pending)),
+		localize.NewEntry("Method", c.methodDescription(olm))))
+
+	if ok, err := c.confirmOperatorChange(opts); !ok || err != nil {
+		return err
+	}
+
+	for _, spec := range pending {
+		if olm != nil {
+			err = c.createSubscription(ctx, spec, olm)
+		} else {
+			err = c.applyManifests(ctx, spec)
+		}
+		if err != nil {
+			return fmt.Errorf("%v: %w", c.localizer.MustLocalize("cluster.operator.error.installFailed", localize.NewEntry("Name", spec.name)), err)
+		}
+		c.logger.Info(c.localizer.MustLocalize("cluster.operator.log.info.installStarted", localize.NewEntry("Name", spec.name)))
+	}
+
+	if err = c.waitForOperators(ctx, pending, opts.Timeout); err != nil {
+		return err
+	}
+
+	return c.reportOperators(ctx, olm, pending, "cluster.operator.log.info.installSuccess")
+}
+
+// UpgradeOperators upgrades the installed operators to the latest version.
+// For OLM installations any pending install plan is approved,
+// otherwise the bundled manifests are reapplied
+func (c *KubernetesCluster) UpgradeOperators(ctx context.Context, opts *OperatorArguments) error {
+	olm, err := c.detectOLM()
+	if err != nil {
+		return err
+	}
+
+	installed, methods, err := c.installedOperators(ctx, olm)
+	if err != nil {
+		return err
+	}
+
+	if len(installed) == 0 {
+		return errors.New(c.localizer.MustLocalize("cluster.operator.error.notInstalled"))
+	}
+
+	c.logger.Info(c.localizer.MustLocalize("cluster.operator.log.info.upgradePlan", localize.NewEntry("Operators", operatorNames(installed))))
+
+	if ok, err := c.confirmOperatorChange(opts); !ok || err != nil {
+		return err
+	}
+
+	for i, spec := range installed {
+		if methods[i] == OperatorInstallMethodOLM {
+			err = c.approveInstallPlan(ctx, spec, olm)
+		} else {
+			err = c.applyManifests(ctx, spec)
+		}
+		if err != nil {
+			return fmt.Errorf("%v: %w", c.localizer.MustLocalize("cluster.operator.error.upgradeFailed", localize.NewEntry("Name", spec.name)), err)
+		}
+	}
+
+	if err = c.waitForOperators(ctx, installed, opts.Timeout); err != nil {
+		return err
+	}
+
+	return c.reportOperators(ctx, olm, installed, "cluster.operator.log.info.upgradeSuccess")
+}
+
+// UninstallOperators removes the installed operators from the cluster.
+// Custom resource definitions are retained so that existing resources are not lost.
+func (c *KubernetesCluster) UninstallOperators(ctx context.Context, opts *OperatorArguments) error {
+	olm, err := c.detectOLM()
+	if err != nil {
+		return err
+	}
+
+	installed, methods, err := c.installedOperators(ctx, olm)
+	if err != nil {
+		return err
+	}
+
+	if len(installed) == 0 {
+		return errors.New(c.localizer.MustLocalize("cluster.operator.error.notInstalled"))
+	}
+
+	c.logger.Info(c.localizer.MustLocalize("cluster.operator.log.info.uninstallPlan", localize.NewEntry("Operators", operatorNames(installed))))
+
+	if ok, err := c.confirmOperatorChange(opts); !ok || err != nil {
+		return err
+	}
+
+	for i, spec := range installed {
+		if methods[i] == OperatorInstallMethodOLM {
+			err = c.deleteSubscription(ctx, spec, olm)
+		} else {
+			err = c.deleteManifests(ctx, spec)
+		}
+		if err != nil {
+			return fmt.Errorf("%v: %w", c.localizer.MustLocalize("cluster.operator.error.uninstallFailed", localize.NewEntry("Name", spec.name)), err)
+		}
+		c.logger.Info(c.localizer.MustLocalize("cluster.operator.log.info.uninstallSuccess", localize.NewEntry("Name", spec.name)))
+	}
+
+	c.logger.Info(c.localizer.MustLocalize("cluster.operator.log.info.crdsRetained"))
+
+	return nil
+}
+
+func (c *KubernetesCluster) confirmOperatorChange(opts *OperatorArguments) (bool, error) {
+	if opts.ForceCreationWithoutAsk {
+		return true, nil
+	}
+
+	var shouldContinue bool
+	confirm := &survey.Confirm{
+		Message: c.localizer.MustLocalize("cluster.kubernetes.connect.input.confirm.message"),
+	}
+	if err := survey.AskOne(confirm, &shouldContinue); err != nil {
+		return false, err
+	}
+
+	if !shouldContinue {
+		c.logger.Debug(c.localizer.MustLocalize("cluster.operator.log.debug.cancelled"))
+	}
+
+	return shouldContinue, nil
+}
+
+// installedOperators returns the operators which are installed on the cluster
+// along with the method which was used to install each of them
+func (c *KubernetesCluster) installedOperators(ctx context.Context, olm *olmTarget) ([]*operatorSpec, []string, error) {
+	var installed []*operatorSpec
+	var methods []string
+	for i := range operatorSpecs {
+		spec := &operatorSpecs[i]
+		status, err := c.operatorStatus(ctx, spec, olm)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !status.Installed {
+			c.logger.Info(c.localizer.MustLocalize("cluster.operator.log.info.notInstalled", localize.NewEntry("Name", spec.name)))
+			continue
+		}
+		installed = append(installed, spec)
+		methods = append(methods, status.Method)
+	}
+
+	return installed, methods, nil
+}
+
+func (c *KubernetesCluster) reportOperators(ctx context.Context, olm *olmTarget, specs []*operatorSpec, messageID string) error {
+	for _, spec := range specs {
+		status, err := c.operatorStatus(ctx, spec, olm)
+		if err != nil {
+			return err
+		}
+		c.logger.Info(c.localizer.MustLocalize(messageID, localize.NewEntry("Name", spec.name), localize.NewEntry("Version", status.Version)))
+	}
+
+	return nil
+}
+
+// detectOLM checks if Operator Lifecycle Manager is available on the cluster
+// It returns nil when OLM is not installed
+func (c *KubernetesCluster) detectOLM() (*olmTarget, error) {
+	hasOLM, err := c.hasAPIResource(olmGroupVersion, "subscriptions")
+	if err != nil || !hasOLM {
+		return nil, err
+	}
+
+	isOpenShift, err := c.hasAPIResource(openshiftGroupVersion, "clusterversions")
+	if err != nil {
+		return nil, err
+	}
+	if isOpenShift {
+		return openshiftOLM, nil
+	}
+
+	return upstreamOLM, nil
+}
+
+func (c *KubernetesCluster) hasAPIResource(groupVersion string, resource string) (bool, error) {
+	resources, err := c.discovery.ServerResourcesForGroupVersion(groupVersion)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	for _, r := range resources.APIResources {
+		if r.Name == resource {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (c *KubernetesCluster) methodDescription(olm *olmTarget) string {
+	if olm != nil {
+		return c.localizer.MustLocalize("cluster.operator.method.olm")
+	}
+	return c.localizer.MustLocalize("cluster.operator.method.manifests")
+}
+
+// operatorStatus looks for an OLM Subscription for the operator,
+// falling back to the deployment created from the bundled manifests
+func (c *KubernetesCluster) operatorStatus(ctx context.Context, spec *operatorSpec, olm *olmTarget) (*OperatorStatus, error) {
+	status := &OperatorStatus{Name: spec.name}
+
+	if olm != nil {
+		pkg := olm.packageFor(spec)
+		sub, err := c.dynamicClient.Resource(subscriptionResource).Namespace(olm.namespace).Get(ctx, pkg.name, metav1.GetOptions{})
+		if err == nil {
+			status.Installed = true
+			status.Method = OperatorInstallMethodOLM
+			status.Version = c.csvVersion(ctx, olm.namespace, sub)
+			return status, nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	deployment, err := c.dynamicClient.Resource(deploymentResource).Namespace(spec.namespace).Get(ctx, spec.deployment, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return status, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	status.Installed = true
+	status.Method = OperatorInstallMethodManifests
+	status.Version = deployment.GetLabels()[versionLabel]
+
+	return status, nil
+}
+
+// csvVersion returns the version of the ClusterServiceVersion installed by a Subscription
+func (c *KubernetesCluster) csvVersion(ctx context.Context, namespace string, sub *unstructured.Unstructured) string {
+	csvName, _, _ := unstructured.NestedString(sub.Object, "status", "installedCSV")
+	if csvName == "" {
+		return ""
+	}
+
+	csv, err := c.dynamicClient.Resource(csvResource).Namespace(namespace).Get(ctx, csvName, metav1.GetOptions{})
+	if err != nil {
+		c.logger.Debug(err)
+		return ""
+	}
+
+	version, _, _ := unstructured.NestedString(csv.Object, "spec", "version")
+	return version
+}
+
+func (c *KubernetesCluster) createSubscription(ctx context.Context, spec *operatorSpec, olm *olmTarget) error {
+	pkg := olm.packageFor(spec)
+	sub := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": olmGroupVersion,
+			"kind":       "Subscription",
+			"metadata": map[string]interface{}{
+				"name":      pkg.name,
+				"namespace": olm.namespace,
+			},
+			"spec": map[string]interface{}{
+				"name":                pkg.name,
+				"channel":             pkg.channel,
+				"source":              pkg.source,
+				"sourceNamespace":     olm.sourceNamespace,
+				"installPlanApproval": "Automatic",
+			},
+		},
+	}
+
+	_, err := c.dynamicClient.Resource(subscriptionResource).Namespace(olm.namespace).Create(ctx, sub, metav1.CreateOptions{})
+	return err
+}
+
+// approveInstallPlan approves the install plan referenced by the Subscription, if it is awaiting approval
+func (c *KubernetesCluster) approveInstallPlan(ctx context.Context, spec *operatorSpec, olm *olmTarget) error {
+	pkg := olm.packageFor(spec)
+	sub, err := c.dynamicClient.Resource(subscriptionResource).Namespace(olm.namespace).Get(ctx, pkg.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	planName, _, _ := unstructured.NestedString(sub.Object, "status", "installPlanRef", "name")
+	if planName == "" {
+		c.logger.Info(c.localizer.MustLocalize("cluster.operator.log.info.upToDate", localize.NewEntry("Name", spec.name)))
+		return nil
+	}
+
+	plans := c.dynamicClient.Resource(installPlanResource).Namespace(olm.namespace)
+	plan, err := plans.Get(ctx, planName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	approved, _, _ := unstructured.NestedBool(plan.Object, "spec", "approved")
+	if approved {
+		c.logger.Info(c.localizer.MustLocalize("cluster.operator.log.info.upToDate", localize.NewEntry("Name", spec.name)))
+		return nil
+	}
+
+	if err = unstructured.SetNestedField(plan.Object, true, "spec", "approved"); err != nil {
+		return err
+	}
+	_, err = plans.Update(ctx, plan, metav1.UpdateOptions{})
+
+	return err
+}
+
+// deleteSubscription removes the Subscription and the ClusterServiceVersion it installed
+func (c *KubernetesCluster) deleteSubscription(ctx context.Context, spec *operatorSpec, olm *olmTarget) error {
+	pkg := olm.packageFor(spec)
+	subs := c.dynamicClient.Resource(subscriptionResource).Namespace(olm.namespace)
+	sub, err := subs.Get(ctx, pkg.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if err = subs.Delete(ctx, pkg.name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	csvName, _, _ := unstructured.NestedString(sub.Object, "status", "installedCSV")
+	if csvName == "" {
+		return nil
+	}
+
+	err = c.dynamicClient.Resource(csvResource).Namespace(olm.namespace).Delete(ctx, csvName, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+// applyManifests creates the bundled resources of the operator, updating any that already exist
+func (c *KubernetesCluster) applyManifests(ctx context.Context, spec *operatorSpec) error {
+	objects, err := loadManifests(spec.manifests)
+	if err != nil {
+		return err
+	}
+
+	mapper := c.restMapper()
+
+	for _, obj := range objects {
+		resource, err := c.resourceFor(mapper, obj)
+		if err != nil {
+			return err
+		}
+
+		existing, err := resource.Get(ctx, obj.GetName(), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			c.logger.Debugf("Creating %v %q", obj.GetKind(), obj.GetName())
+			_, err = resource.Create(ctx, obj, metav1.CreateOptions{})
+		} else if err == nil {
+			c.logger.Debugf("Updating %v %q", obj.GetKind(), obj.GetName())
+			obj.SetResourceVersion(existing.GetResourceVersion())
+			_, err = resource.Update(ctx, obj, metav1.UpdateOptions{})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteManifests removes the bundled resources of the operator in reverse order, keeping the CRDs
+func (c *KubernetesCluster) deleteManifests(ctx context.Context, spec *operatorSpec) error {
+	objects, err := loadManifests(spec.manifests)
+	if err != nil {
+		return err
+	}
+
+	mapper := c.restMapper()
+
+	for i := len(objects) - 1; i >= 0; i-- {
+		obj := objects[i]
+		if obj.GetKind() == "CustomResourceDefinition" {
+			continue
+		}
+
+		resource, err := c.resourceFor(mapper, obj)
+		if err != nil {
+			return err
+		}
+
+		c.logger.Debugf("Deleting %v %q", obj.GetKind(), obj.GetName())
+		err = resource.Delete(ctx, obj.GetName(), metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restMapper returns a mapper which discovers the resources of the cluster on first use
+func (c *KubernetesCluster) restMapper() meta.RESTMapper {
+	return restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(c.discovery))
+}
+
+func (c *KubernetesCluster) resourceFor(mapper meta.RESTMapper, obj *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return c.dynamicClient.Resource(mapping.Resource).Namespace(obj.GetNamespace()), nil
+	}
+
+	return c.dynamicClient.Resource(mapping.Resource), nil
+}
+
+// waitForOperators waits until the CRDs of each operator are established
+func (c *KubernetesCluster) waitForOperators(ctx context.Context, specs []*operatorSpec, timeout time.Duration) error {
+	if timeout == 0 {
+		timeout = DefaultOperatorTimeout
+	}
+
+	for _, spec := range specs {
+		for _, crdName := range spec.crds {
+			c.logger.Info(c.localizer.MustLocalize("cluster.operator.log.info.waitingForCRD", localize.NewEntry("Name", crdName)))
+			err := c.waitForCRDEstablished(ctx, crdName, timeout)
+			if errors.Is(err, ErrWaitTimeout) {
+				return errors.New(c.localizer.MustLocalize("cluster.operator.error.crdTimeout", localize.NewEntry("Name", crdName), localize.NewEntry("Timeout", timeout)))
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *KubernetesCluster) waitForCRDEstablished(ctx context.Context, name string, timeout time.Duration) error {
+	waiter := &ConditionWaiter{
+		Client:        c.dynamicClient,
+		Resource:      crdResource,
+		Name:          name,
+		ConditionType: "Established",
+		Timeout:       timeout,
+		OnCondition: func(condition Condition) {
+			c.logger.Debugf("%v %v: %v", name, condition.Type, condition.Status)
+		},
+	}
+
+	return waiter.Wait(ctx)
+}
+
+// loadManifests decodes the multi-document YAML file from the embedded manifests
+func loadManifests(path string) ([]*unstructured.Unstructured, error) {
+	data, err := operatorManifests.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := k8syaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+
+	var objects []*unstructured.Unstructured
+	for {
+		obj := &unstructured.Unstructured{}
+		err = decoder.Decode(&obj.Object)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode %v: %w", path, err)
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+		objects = append(objects, obj)
+	}
+
+	return objects, nil
+}
+
+func operatorNames(specs []*operatorSpec) string {
+	names := make([]string, len(specs))
+	for i, spec := range specs {
+		names[i] = spec.name
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,371 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redhat-developer/app-services-cli/pkg/localize/goi18n"
+	"github.com/redhat-developer/app-services-cli/pkg/logging"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	discoveryfake "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestLoadManifests(t *testing.T) {
+	for i := range operatorSpecs {
+		spec := operatorSpecs[i]
+		t.Run(spec.name, func(t *testing.T) {
+			objects, err := loadManifests(spec.manifests)
+			if err != nil {
+				t.Fatalf("loadManifests() error = %v", err)
+			}
+
+			crds := map[string]bool{}
+			var version string
+			for _, obj := range objects {
+				switch obj.GetKind() {
+				case "CustomResourceDefinition":
+					crds[obj.GetName()] = true
+				case "Deployment":
+					if obj.GetName() == spec.deployment && obj.GetNamespace() == spec.namespace {
+						version = obj.GetLabels()[versionLabel]
+					}
+				}
+			}
+
+			for _, crd := range spec.crds {
+				if !crds[crd] {
+					t.Errorf("manifests are missing custom resource definition %q", crd)
+				}
+			}
+			if version == "" {
+				t.Errorf("manifests are missing deployment %v/%v with a %q label", spec.namespace, spec.deployment, versionLabel)
+			}
+		})
+	}
+}
+
+// notFoundDiscovery reports the missing group versions as not found, like the API server
+type notFoundDiscovery struct {
+	*discoveryfake.FakeDiscovery
+}
+
+func (d notFoundDiscovery) ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error) {
+	for _, resources := range d.Resources {
+		if resources.GroupVersion == groupVersion {
+			return resources, nil
+		}
+	}
+	return nil, apierrors.NewNotFound(schema.GroupResource{}, groupVersion)
+}
+
+func apiResources(groupVersion string, resources ...metav1.APIResource) *metav1.APIResourceList {
+	return &metav1.APIResourceList{GroupVersion: groupVersion, APIResources: resources}
+}
+
+// newOperatorCluster creates a cluster backed by fake clients.
+// OLM is discovered when olm is set, on OpenShift when openshift is also set.
+// The CRDs are reported as established as soon as they are created or updated
+func newOperatorCluster(t *testing.T, olm bool, openshift bool, objects ...runtime.Object) (*KubernetesCluster, *dynamicfake.FakeDynamicClient) {
+	resources := []*metav1.APIResourceList{
+		apiResources("v1",
+			metav1.APIResource{Name: "namespaces", Kind: "Namespace"},
+			metav1.APIResource{Name: "serviceaccounts", Kind: "ServiceAccount", Namespaced: true}),
+		apiResources("apps/v1", metav1.APIResource{Name: "deployments", Kind: "Deployment", Namespaced: true}),
+		apiResources("rbac.authorization.k8s.io/v1",
+			metav1.APIResource{Name: "clusterroles", Kind: "ClusterRole"},
+			metav1.APIResource{Name: "clusterrolebindings", Kind: "ClusterRoleBinding"}),
+		apiResources("apiextensions.k8s.io/v1", metav1.APIResource{Name: "customresourcedefinitions", Kind: "CustomResourceDefinition"}),
+	}
+	if olm {
+		resources = append(resources, apiResources(olmGroupVersion,
+			metav1.APIResource{Name: "subscriptions", Kind: "Subscription", Namespaced: true},
+			metav1.APIResource{Name: "clusterserviceversions", Kind: "ClusterServiceVersion", Namespaced: true},
+			metav1.APIResource{Name: "installplans", Kind: "InstallPlan", Namespaced: true}))
+	}
+	if openshift {
+		resources = append(resources, apiResources(openshiftGroupVersion, metav1.APIResource{Name: "clusterversions", Kind: "ClusterVersion"}))
+	}
+
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+	// the status of the CRDs is kept by the API server when the manifests are reapplied
+	for _, verb := range []string{"create", "update"} {
+		client.PrependReactor(verb, crdResource.Resource, func(action k8stesting.Action) (bool, runtime.Object, error) {
+			obj := action.(interface{ GetObject() runtime.Object }).GetObject().(*unstructured.Unstructured)
+			err := unstructured.SetNestedSlice(obj.Object, []interface{}{condition("Established", "True", "")}, "status", "conditions")
+			return false, nil, err
+		})
+	}
+
+	localizer, err := goi18n.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger, err := logging.NewStdLoggerBuilder().Streams(&bytes.Buffer{}, &bytes.Buffer{}).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &KubernetesCluster{
+		discovery:     notFoundDiscovery{&discoveryfake.FakeDiscovery{Fake: &k8stesting.Fake{Resources: resources}}},
+		dynamicClient: client,
+		logger:        logger,
+		localizer:     localizer,
+	}
+	return c, client
+}
+
+func newObject(apiVersion string, kind string, namespace string, name string, fields map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+	}}
+	for k, v := range fields {
+		obj.Object[k] = v
+	}
+	return obj
+}
+
+// newEstablishedCRDs returns the CRDs of the operators as created by OLM
+func newEstablishedCRDs() []runtime.Object {
+	var crds []runtime.Object
+	for _, spec := range operatorSpecs {
+		for _, name := range spec.crds {
+			crds = append(crds, newObject("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", name, map[string]interface{}{
+				"status": map[string]interface{}{"conditions": []interface{}{condition("Established", "True", "")}},
+			}))
+		}
+	}
+	return crds
+}
+
+var testOperatorArguments = &OperatorArguments{ForceCreationWithoutAsk: true, Timeout: 5 * time.Second}
+
+func TestOperatorsWithManifests(t *testing.T) {
+	c, client := newOperatorCluster(t, false, false)
+	ctx := context.Background()
+
+	if err := c.UpgradeOperators(ctx, testOperatorArguments); err == nil {
+		t.Error("expected an error when upgrading operators which are not installed")
+	}
+
+	if err := c.InstallOperators(ctx, testOperatorArguments); err != nil {
+		t.Fatalf("InstallOperators() error = %v", err)
+	}
+
+	statuses, err := c.ListOperators(ctx)
+	if err != nil {
+		t.Fatalf("ListOperators() error = %v", err)
+	}
+	for _, status := range statuses {
+		if !status.Installed || status.Method != OperatorInstallMethodManifests || status.Version == "" {
+			t.Errorf("unexpected status after the installation: %+v", status)
+		}
+	}
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "create" && action.GetResource() == subscriptionResource {
+			t.Errorf("unexpected Subscription created without OLM: %v", action)
+		}
+	}
+
+	// upgrading reapplies the manifests, reverting the changes made to the deployments
+	spec := &operatorSpecs[0]
+	deployments := client.Resource(deploymentResource).Namespace(spec.namespace)
+	deployment, err := deployments.Get(ctx, spec.deployment, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	version := deployment.GetLabels()[versionLabel]
+	deployment.SetLabels(map[string]string{versionLabel: "0.0.1"})
+	if _, err = deployments.Update(ctx, deployment, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = c.UpgradeOperators(ctx, testOperatorArguments); err != nil {
+		t.Fatalf("UpgradeOperators() error = %v", err)
+	}
+	if deployment, err = deployments.Get(ctx, spec.deployment, metav1.GetOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := deployment.GetLabels()[versionLabel]; got != version {
+		t.Errorf("version after the upgrade = %v, want %v", got, version)
+	}
+
+	// uninstalling removes everything but the CRDs
+	if err = c.UninstallOperators(ctx, testOperatorArguments); err != nil {
+		t.Fatalf("UninstallOperators() error = %v", err)
+	}
+	for _, spec := range operatorSpecs {
+		if _, err = client.Resource(deploymentResource).Namespace(spec.namespace).Get(ctx, spec.deployment, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+			t.Errorf("expected the deployment %v to be deleted, got %v", spec.deployment, err)
+		}
+		for _, name := range spec.crds {
+			if _, err = client.Resource(crdResource).Get(ctx, name, metav1.GetOptions{}); err != nil {
+				t.Errorf("expected the CRD %v to be retained, got %v", name, err)
+			}
+		}
+	}
+}
+
+func TestInstallOperatorsWithOLM(t *testing.T) {
+	tests := []struct {
+		name      string
+		openshift bool
+		target    *olmTarget
+	}{
+		{name: "OpenShift", openshift: true, target: openshiftOLM},
+		{name: "upstream", openshift: false, target: upstreamOLM},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, client := newOperatorCluster(t, true, tt.openshift, newEstablishedCRDs()...)
+			ctx := context.Background()
+
+			if err := c.InstallOperators(ctx, testOperatorArguments); err != nil {
+				t.Fatalf("InstallOperators() error = %v", err)
+			}
+
+			for i := range operatorSpecs {
+				pkg := tt.target.packageFor(&operatorSpecs[i])
+				sub, err := client.Resource(subscriptionResource).Namespace(tt.target.namespace).Get(ctx, pkg.name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("expected a Subscription for %v: %v", pkg.name, err)
+				}
+				spec, _, _ := unstructured.NestedStringMap(sub.Object, "spec")
+				if spec["channel"] != pkg.channel || spec["source"] != pkg.source || spec["sourceNamespace"] != tt.target.sourceNamespace {
+					t.Errorf("unexpected Subscription spec: %v", spec)
+				}
+				if _, err = client.Resource(deploymentResource).Namespace(operatorSpecs[i].namespace).Get(ctx, operatorSpecs[i].deployment, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+					t.Errorf("unexpected deployment created from the manifests along with OLM: %v", err)
+				}
+			}
+
+			statuses, err := c.ListOperators(ctx)
+			if err != nil {
+				t.Fatalf("ListOperators() error = %v", err)
+			}
+			for _, status := range statuses {
+				if !status.Installed || status.Method != OperatorInstallMethodOLM {
+					t.Errorf("unexpected status after the installation: %+v", status)
+				}
+			}
+		})
+	}
+}
+
+// newSubscription returns the Subscription of the first operator on OpenShift,
+// which installed the CSV of the given version and references the install plan
+func newSubscription(version string, plan string) []runtime.Object {
+	spec := &operatorSpecs[0]
+	pkg := openshiftOLM.packageFor(spec)
+	csvName := pkg.name + ".v" + version
+
+	status := map[string]interface{}{"installedCSV": csvName}
+	if plan != "" {
+		status["installPlanRef"] = map[string]interface{}{"name": plan}
+	}
+
+	return []runtime.Object{
+		newObject(olmGroupVersion, "Subscription", openshiftOLM.namespace, pkg.name, map[string]interface{}{"status": status}),
+		newObject(olmGroupVersion, "ClusterServiceVersion", openshiftOLM.namespace, csvName, map[string]interface{}{
+			"spec": map[string]interface{}{"version": version},
+		}),
+	}
+}
+
+func TestListOperatorsWithOLM(t *testing.T) {
+	c, _ := newOperatorCluster(t, true, true, newSubscription("0.9.0", "")...)
+
+	statuses, err := c.ListOperators(context.Background())
+	if err != nil {
+		t.Fatalf("ListOperators() error = %v", err)
+	}
+
+	want := []OperatorStatus{
+		{Name: RHOASOperatorName, Installed: true, Version: "0.9.0", Method: OperatorInstallMethodOLM},
+		{Name: ServiceBindingOperatorName},
+	}
+	if len(statuses) != len(want) {
+		t.Fatalf("ListOperators() = %+v, want %+v", statuses, want)
+	}
+	for i := range want {
+		if statuses[i] != want[i] {
+			t.Errorf("ListOperators()[%v] = %+v, want %+v", i, statuses[i], want[i])
+		}
+	}
+}
+
+func TestUpgradeOperatorsWithOLM(t *testing.T) {
+	const planName = "install-abcde"
+
+	tests := []struct {
+		name         string
+		plan         string
+		approved     *bool
+		wantApproved bool
+	}{
+		{name: "pending install plan is approved", plan: planName, approved: boolPtr(false), wantApproved: true},
+		{name: "approved install plan is left as is", plan: planName, approved: boolPtr(true), wantApproved: true},
+		{name: "no install plan", plan: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := append(newEstablishedCRDs(), newSubscription("0.9.0", tt.plan)...)
+			if tt.approved != nil {
+				objects = append(objects, newObject(olmGroupVersion, "InstallPlan", openshiftOLM.namespace, tt.plan, map[string]interface{}{
+					"spec": map[string]interface{}{"approved": *tt.approved},
+				}))
+			}
+			c, client := newOperatorCluster(t, true, true, objects...)
+			ctx := context.Background()
+
+			if err := c.UpgradeOperators(ctx, testOperatorArguments); err != nil {
+				t.Fatalf("UpgradeOperators() error = %v", err)
+			}
+
+			if tt.approved == nil {
+				return
+			}
+			plan, err := client.Resource(installPlanResource).Namespace(openshiftOLM.namespace).Get(ctx, tt.plan, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if approved, _, _ := unstructured.NestedBool(plan.Object, "spec", "approved"); approved != tt.wantApproved {
+				t.Errorf("install plan approved = %v, want %v", approved, tt.wantApproved)
+			}
+		})
+	}
+}
+
+func TestUninstallOperatorsWithOLM(t *testing.T) {
+	objects := newSubscription("0.9.0", "")
+	c, client := newOperatorCluster(t, true, true, objects...)
+	ctx := context.Background()
+
+	if err := c.UninstallOperators(ctx, testOperatorArguments); err != nil {
+		t.Fatalf("UninstallOperators() error = %v", err)
+	}
+
+	sub, csv := objects[0].(*unstructured.Unstructured), objects[1].(*unstructured.Unstructured)
+	if _, err := client.Resource(subscriptionResource).Namespace(openshiftOLM.namespace).Get(ctx, sub.GetName(), metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the Subscription to be deleted, got %v", err)
+	}
+	if _, err := client.Resource(csvResource).Namespace(openshiftOLM.namespace).Get(ctx, csv.GetName(), metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the ClusterServiceVersion to be deleted, got %v", err)
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -5,8 +5,6 @@ import (
 	"crypto/rand"
 	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/redhat-developer/app-services-cli/pkg/color"
@@ -19,19 +17,9 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/client-go/dynamic"
-	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
-	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
-type KubernetesClients struct {
-	dynamicClient dynamic.Interface
-	restConfig    *rest.Config
-	clientConfig  *clientcmd.ClientConfig
-}
-
 var deploymentResource = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
 
 type ServiceBindingOptions struct {
@@ -43,16 +31,17 @@ type ServiceBindingOptions struct {
 	ForceUseSDK             bool
 	BindingName             string
 	BindAsFiles             bool
+	KubeConfig              KubeConfigOptions
 }
 
 func ExecuteServiceBinding(logger logging.Logger, localizer localize.Localizer, options *ServiceBindingOptions) error {
-	clients, err := client(localizer)
+	clients, err := NewKubernetesClients(&options.KubeConfig, localizer)
 	if err != nil {
 		return err
 	}
 	ns := options.Namespace
 	if ns == "" {
-		ns, _, err = (*clients.clientConfig).Namespace()
+		ns, _, err = clients.clientConfig.Namespace()
 		if err != nil {
 			return err
 		}
@@ -229,38 +218,3 @@ func fetchAppNameFromCluster(clients *KubernetesClients, localizer localize.Loca
 	}
 	return appNames[selectedAppIndex], nil
 }
-
-func client(localizer localize.Localizer) (*KubernetesClients, error) {
-	kubeconfig := os.Getenv("KUBECONFIG")
-
-	if kubeconfig == "" {
-		home, _ := os.UserHomeDir()
-		kubeconfig = filepath.Join(home, ".kube", "config")
-	}
-
-	_, err := os.Stat(kubeconfig)
-	if err != nil {
-		return nil, fmt.Errorf("%v: %w", localizer.MustLocalize("cluster.kubernetes.error.configNotFoundError"), err)
-	}
-
-	restConfig, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
-	if err != nil {
-		return nil, fmt.Errorf("%v: %w", localizer.MustLocalize("cluster.kubernetes.error.loadConfigError"), err)
-	}
-
-	if err != nil {
-		return nil, fmt.Errorf("%v: %w", localizer.MustLocalize("cluster.kubernetes.error.loadConfigError"), err)
-	}
-
-	dynamicClient, err := dynamic.NewForConfig(restConfig)
-	if err != nil {
-		return nil, fmt.Errorf("%v: %w", localizer.MustLocalize("cluster.kubernetes.error.loadConfigError"), err)
-	}
-
-	// Used for namespaces and general queries
-	clientconfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig},
-		&clientcmd.ConfigOverrides{ClusterInfo: clientcmdapi.Cluster{Server: ""}})
-
-	return &KubernetesClients{dynamicClient, restConfig, &clientconfig}, nil
-}
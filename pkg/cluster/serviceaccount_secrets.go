@@ -0,0 +1,26 @@
+package cluster
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServiceAccountSecretClientIDs returns the client IDs of the service accounts stored in the secrets
+// which "rhoas cluster connect" creates, in every namespace of the cluster
+func (c *KubernetesClients) ServiceAccountSecretClientIDs(ctx context.Context) ([]string, error) {
+	secrets, err := c.clientset.CoreV1().Secrets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: "metadata.name=" + serviceAccountSecretName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	clientIDs := []string{}
+	for _, secret := range secrets.Items {
+		if clientID := string(secret.Data["client-id"]); clientID != "" {
+			clientIDs = append(clientIDs, clientID)
+		}
+	}
+	return clientIDs, nil
+}
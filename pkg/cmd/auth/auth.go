@@ -0,0 +1,24 @@
+package auth
+
+import (
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/auth/status"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
+	"github.com/spf13/cobra"
+)
+
+// NewAuthCommand creates a new command sub-group to inspect the authentication session
+func NewAuthCommand(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     f.Localizer.MustLocalize("auth.cmd.use"),
+		Short:   f.Localizer.MustLocalize("auth.cmd.shortDescription"),
+		Long:    f.Localizer.MustLocalize("auth.cmd.longDescription"),
+		Example: f.Localizer.MustLocalize("auth.cmd.example"),
+		Args:    cobra.ExactArgs(1),
+	}
+
+	cmd.AddCommand(
+		status.NewStatusCommand(f),
+	)
+
+	return cmd
+}
@@ -0,0 +1,326 @@
+package status
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/redhat-developer/app-services-cli/internal/build"
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/ams"
+	"github.com/redhat-developer/app-services-cli/pkg/auth/token"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/flag"
+	flagutil "github.com/redhat-developer/app-services-cli/pkg/cmdutil/flags"
+	"github.com/redhat-developer/app-services-cli/pkg/connection"
+	"github.com/redhat-developer/app-services-cli/pkg/dump"
+	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
+	"github.com/redhat-developer/app-services-cli/pkg/localize"
+	"github.com/redhat-developer/app-services-cli/pkg/logging"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// SessionStatus describes the authentication session of the CLI
+type SessionStatus struct {
+	Profile       string       `json:"profile,omitempty" yaml:"profile,omitempty"`
+	APIURL        string       `json:"api_url,omitempty" yaml:"api_url,omitempty"`
+	AuthURL       string       `json:"auth_url,omitempty" yaml:"auth_url,omitempty"`
+	MasAuthURL    string       `json:"mas_auth_url,omitempty" yaml:"mas_auth_url,omitempty"`
+	SSO           *TokenStatus `json:"sso,omitempty" yaml:"sso,omitempty"`
+	MASSSO        *TokenStatus `json:"mas_sso,omitempty" yaml:"mas_sso,omitempty"`
+	TermsAccepted *bool        `json:"terms_accepted,omitempty" yaml:"terms_accepted,omitempty"`
+	TermsURL      string       `json:"terms_url,omitempty" yaml:"terms_url,omitempty"`
+	Usable        bool         `json:"usable" yaml:"usable"`
+	Problems      []string     `json:"problems,omitempty" yaml:"problems,omitempty"`
+}
+
+// TokenStatus describes the tokens issued by an identity provider
+type TokenStatus struct {
+	Username            string     `json:"username,omitempty" yaml:"username,omitempty"`
+	OrgID               string     `json:"org_id,omitempty" yaml:"org_id,omitempty"`
+	AccountID           string     `json:"account_id,omitempty" yaml:"account_id,omitempty"`
+	Issuer              string     `json:"issuer,omitempty" yaml:"issuer,omitempty"`
+	Scopes              []string   `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+	AccessTokenExpiry   *time.Time `json:"access_token_expiry,omitempty" yaml:"access_token_expiry,omitempty"`
+	AccessTokenExpired  bool       `json:"access_token_expired" yaml:"access_token_expired"`
+	HasRefreshToken     bool       `json:"has_refresh_token" yaml:"has_refresh_token"`
+	RefreshTokenExpiry  *time.Time `json:"refresh_token_expiry,omitempty" yaml:"refresh_token_expiry,omitempty"`
+	RefreshTokenExpired bool       `json:"refresh_token_expired" yaml:"refresh_token_expired"`
+	// Valid is true when the tokens can be used, possibly after being refreshed
+	Valid bool `json:"valid" yaml:"valid"`
+}
+
+type Options struct {
+	IO         *iostreams.IOStreams
+	Config     config.IConfig
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	localizer  localize.Localizer
+
+	outputFormat string
+}
+
+// NewStatusCommand creates a command to inspect the authentication session
+func NewStatusCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		IO:         f.IOStreams,
+		Config:     f.Config,
+		Connection: f.Connection,
+		Logger:     f.Logger,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.MustLocalize("auth.status.cmd.use"),
+		Short:   opts.localizer.MustLocalize("auth.status.cmd.shortDescription"),
+		Long:    opts.localizer.MustLocalize("auth.status.cmd.longDescription"),
+		Example: opts.localizer.MustLocalize("auth.status.cmd.example"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if opts.outputFormat != "" && !flagutil.IsValidInput(opts.outputFormat, flagutil.ValidOutputFormats...) {
+				return flag.InvalidValueError("output", opts.outputFormat, flagutil.ValidOutputFormats...)
+			}
+
+			return runStatus(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", "", opts.localizer.MustLocalize("auth.status.flag.output.description"))
+
+	flagutil.EnableOutputFlagCompletion(cmd)
+
+	return cmd
+}
+
+// nolint:funlen
+func runStatus(opts *Options) error {
+	cfg, err := opts.Config.Load()
+	if err != nil {
+		return err
+	}
+
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	status := &SessionStatus{
+		Profile:    cfg.Profile,
+		APIURL:     valueOrDefault(cfg.APIUrl, build.ProductionAPIURL),
+		AuthURL:    valueOrDefault(cfg.AuthURL, build.ProductionAuthURL),
+		MasAuthURL: valueOrDefault(cfg.MasAuthURL, build.ProductionMasAuthURL),
+	}
+
+	switch {
+	case cfg.IsLocalProfile():
+		// the local development environment does not use authentication
+		logger.Debug(opts.localizer.MustLocalize("auth.status.log.debug.localProfile"))
+	case cfg.AccessToken == "" && cfg.RefreshToken == "":
+		status.Problems = append(status.Problems, opts.localizer.MustLocalize("auth.status.problem.notLoggedIn"))
+	default:
+		conn, connErr := opts.Connection(connection.DefaultConfigSkipMasAuth)
+		if connErr != nil {
+			status.Problems = append(status.Problems, connErr.Error())
+			break
+		}
+
+		accepted, termsURL, termsErr := ams.CheckTermsAccepted(conn)
+		if termsErr != nil {
+			status.Problems = append(status.Problems, opts.localizer.MustLocalize("auth.status.problem.termsCheckFailed", localize.NewEntry("Error", termsErr)))
+		} else {
+			status.TermsAccepted = &accepted
+			if !accepted {
+				status.TermsURL = termsURL
+				status.Problems = append(status.Problems, opts.localizer.MustLocalize("auth.status.problem.termsNotAccepted", localize.NewEntry("TermsURL", termsURL)))
+			}
+		}
+
+		// the tokens may have been refreshed by the connection
+		if cfg, err = opts.Config.Load(); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	if !cfg.IsLocalProfile() {
+		if status.SSO, err = inspectTokens(cfg.AccessToken, cfg.RefreshToken, now); err != nil {
+			status.Problems = append(status.Problems, err.Error())
+		} else if status.SSO != nil && !status.SSO.Valid {
+			status.Problems = append(status.Problems, opts.localizer.MustLocalize("auth.status.problem.sessionExpired"))
+		}
+
+		// the MAS-SSO session is only needed by some commands, so it does not make the session unusable
+		if status.MASSSO, err = inspectTokens(cfg.MasAccessToken, cfg.MasRefreshToken, now); err != nil {
+			logger.Debug(opts.localizer.MustLocalize("auth.status.log.debug.invalidMasToken"), err)
+		}
+	}
+
+	status.Usable = len(status.Problems) == 0
+
+	stdout := opts.IO.Out
+	switch opts.outputFormat {
+	case dump.JSONFormat:
+		data, _ := json.MarshalIndent(status, "", "  ")
+		_ = dump.JSON(stdout, data)
+	case dump.YAMLFormat, dump.YMLFormat:
+		data, _ := yaml.Marshal(status)
+		_ = dump.YAML(stdout, data)
+	default:
+		printStatus(stdout, opts.localizer, status, now)
+	}
+
+	if !status.Usable {
+		return errors.New(opts.localizer.MustLocalize("auth.status.error.sessionUnusable"))
+	}
+
+	return nil
+}
+
+// inspectTokens describes the access and refresh tokens of an identity provider.
+// The claims are read from the access token, or from the refresh token when there is no access token
+func inspectTokens(accessToken string, refreshToken string, now time.Time) (*TokenStatus, error) {
+	if accessToken == "" && refreshToken == "" {
+		return nil, nil
+	}
+
+	claimsToken := accessToken
+	if claimsToken == "" {
+		claimsToken = refreshToken
+	}
+	parsed, err := token.Parse(claimsToken)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := token.MapClaims(parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &TokenStatus{
+		Username:        stringClaim(claims, "preferred_username"),
+		OrgID:           stringClaim(claims, "org_id"),
+		AccountID:       stringClaim(claims, "account_id"),
+		Issuer:          stringClaim(claims, "iss"),
+		Scopes:          strings.Fields(stringClaim(claims, "scope")),
+		HasRefreshToken: refreshToken != "",
+	}
+
+	if accessToken != "" {
+		status.AccessTokenExpiry, status.AccessTokenExpired, err = expiry(accessToken, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if refreshToken != "" {
+		status.RefreshTokenExpiry, status.RefreshTokenExpired, err = expiry(refreshToken, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// an expired access token is refreshed with the refresh token
+	status.Valid = (accessToken != "" && !status.AccessTokenExpired) || (refreshToken != "" && !status.RefreshTokenExpired)
+
+	return status, nil
+}
+
+// expiry returns when the token expires, or nil if it does not expire
+func expiry(tokenStr string, now time.Time) (expiresAt *time.Time, expired bool, err error) {
+	expires, left, err := token.GetExpiry(tokenStr, now)
+	if err != nil || !expires {
+		return nil, false, err
+	}
+
+	t := now.Add(left).Truncate(time.Second)
+	return &t, left <= 0, nil
+}
+
+func stringClaim(claims map[string]interface{}, name string) string {
+	if value, ok := claims[name]; ok && value != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return ""
+}
+
+func valueOrDefault(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+func printStatus(w io.Writer, localizer localize.Localizer, status *SessionStatus, now time.Time) {
+	label := func(name string) string {
+		return localizer.MustLocalize("auth.status.label." + name)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	if status.Profile != "" {
+		fmt.Fprintf(tw, "%v:\t%v\n", label("profile"), status.Profile)
+	}
+	fmt.Fprintf(tw, "%v:\t%v\n", label("apiURL"), status.APIURL)
+	fmt.Fprintf(tw, "%v:\t%v\n", label("authURL"), status.AuthURL)
+	fmt.Fprintf(tw, "%v:\t%v\n", label("masAuthURL"), status.MasAuthURL)
+	if status.TermsAccepted != nil {
+		fmt.Fprintf(tw, "%v:\t%v\n", label("termsAccepted"), yesNo(localizer, *status.TermsAccepted))
+	}
+
+	for _, session := range []struct {
+		title  string
+		tokens *TokenStatus
+	}{
+		{label("sso"), status.SSO},
+		{label("masSSO"), status.MASSSO},
+	} {
+		if session.tokens == nil {
+			continue
+		}
+		t := session.tokens
+		fmt.Fprintf(tw, "\n%v\n", session.title)
+		fmt.Fprintf(tw, "  %v:\t%v\n", label("username"), t.Username)
+		fmt.Fprintf(tw, "  %v:\t%v\n", label("orgID"), t.OrgID)
+		fmt.Fprintf(tw, "  %v:\t%v\n", label("accountID"), t.AccountID)
+		fmt.Fprintf(tw, "  %v:\t%v\n", label("issuer"), t.Issuer)
+		fmt.Fprintf(tw, "  %v:\t%v\n", label("scopes"), strings.Join(t.Scopes, " "))
+		fmt.Fprintf(tw, "  %v:\t%v\n", label("accessTokenExpiry"), formatExpiry(localizer, t.AccessTokenExpiry, now))
+		if t.HasRefreshToken {
+			fmt.Fprintf(tw, "  %v:\t%v\n", label("refreshTokenExpiry"), formatExpiry(localizer, t.RefreshTokenExpiry, now))
+		}
+		fmt.Fprintf(tw, "  %v:\t%v\n", label("valid"), yesNo(localizer, t.Valid))
+	}
+
+	_ = tw.Flush()
+
+	if len(status.Problems) > 0 {
+		fmt.Fprintf(w, "\n%v:\n", label("problems"))
+		for _, problem := range status.Problems {
+			fmt.Fprintf(w, "  - %v\n", problem)
+		}
+	}
+}
+
+func formatExpiry(localizer localize.Localizer, expiresAt *time.Time, now time.Time) string {
+	if expiresAt == nil {
+		return localizer.MustLocalize("auth.status.value.never")
+	}
+	if !expiresAt.After(now) {
+		return localizer.MustLocalize("auth.status.value.expired", localize.NewEntry("Time", expiresAt.Local().Format(time.RFC1123)))
+	}
+	return localizer.MustLocalize("auth.status.value.expiresIn",
+		localize.NewEntry("Time", expiresAt.Local().Format(time.RFC1123)),
+		localize.NewEntry("Duration", expiresAt.Sub(now).Round(time.Second)),
+	)
+}
+
+func yesNo(localizer localize.Localizer, value bool) string {
+	if value {
+		return localizer.MustLocalize("auth.status.value.yes")
+	}
+	return localizer.MustLocalize("auth.status.value.no")
+}
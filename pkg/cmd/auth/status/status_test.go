@@ -0,0 +1,77 @@
+package status
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+func newToken(t *testing.T, claims jwt.MapClaims) string {
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestInspectTokens(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+
+	accessToken := newToken(t, jwt.MapClaims{
+		"preferred_username": "jdoe",
+		"org_id":             "12345",
+		"account_id":         "67890",
+		"iss":                "https://sso.redhat.com/auth/realms/redhat-external",
+		"scope":              "openid offline_access",
+		"exp":                now.Add(10 * time.Minute).Unix(),
+	})
+	offlineToken := newToken(t, jwt.MapClaims{"typ": "Offline"})
+	expiredToken := newToken(t, jwt.MapClaims{"exp": now.Add(-time.Minute).Unix()})
+
+	t.Run("no tokens", func(t *testing.T) {
+		got, err := inspectTokens("", "", now)
+		if err != nil || got != nil {
+			t.Errorf("inspectTokens() = %v, %v, want nil, nil", got, err)
+		}
+	})
+
+	t.Run("valid access token and offline token", func(t *testing.T) {
+		got, err := inspectTokens(accessToken, offlineToken, now)
+		if err != nil {
+			t.Fatalf("inspectTokens() error = %v", err)
+		}
+
+		accessTokenExpiry := now.Add(10 * time.Minute)
+		want := &TokenStatus{
+			Username:          "jdoe",
+			OrgID:             "12345",
+			AccountID:         "67890",
+			Issuer:            "https://sso.redhat.com/auth/realms/redhat-external",
+			Scopes:            []string{"openid", "offline_access"},
+			AccessTokenExpiry: &accessTokenExpiry,
+			HasRefreshToken:   true,
+			Valid:             true,
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("inspectTokens() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("expired tokens", func(t *testing.T) {
+		got, err := inspectTokens(expiredToken, expiredToken, now)
+		if err != nil {
+			t.Fatalf("inspectTokens() error = %v", err)
+		}
+		if !got.AccessTokenExpired || !got.RefreshTokenExpired || got.Valid {
+			t.Errorf("expected expired tokens, got %+v", got)
+		}
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		if _, err := inspectTokens("not-a-token", "", now); err == nil {
+			t.Error("expected an error for an invalid token")
+		}
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/redhat-developer/app-services-cli/internal/config"
 	"github.com/redhat-developer/app-services-cli/pkg/cluster"
+	clusterflags "github.com/redhat-developer/app-services-cli/pkg/cmd/cluster/flags"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
 	"github.com/redhat-developer/app-services-cli/pkg/connection"
 	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
@@ -22,8 +23,8 @@ type Options struct {
 	IO         *iostreams.IOStreams
 	localizer  localize.Localizer
 
-	kubeconfigLocation string
-	namespace          string
+	kubeConfig cluster.KubeConfigOptions
+	namespace  string
 
 	forceCreationWithoutAsk bool
 	ignoreContext           bool
@@ -60,7 +61,7 @@ func NewBindCommand(f *factory.Factory) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVarP(&opts.kubeconfigLocation, "kubeconfig", "", "", opts.localizer.MustLocalize("cluster.common.flag.kubeconfig.description"))
+	clusterflags.AddKubeConfigFlags(cmd, &opts.kubeConfig, opts.localizer)
 	cmd.Flags().StringVarP(&opts.appName, "app-name", "", "", opts.localizer.MustLocalize("cluster.bind.flag.appName"))
 	cmd.Flags().StringVarP(&opts.bindingName, "binding-name", "", "", opts.localizer.MustLocalize("cluster.bind.flag.bindName"))
 	cmd.Flags().BoolVarP(&opts.forceCreationWithoutAsk, "yes", "y", false, opts.localizer.MustLocalize("cluster.common.flag.yes.description"))
@@ -121,6 +122,7 @@ func runBind(opts *Options) error {
 		ForceUseSDK:             opts.forceSDK,
 		BindingName:             opts.bindingName,
 		BindAsFiles:             true,
+		KubeConfig:              opts.kubeConfig,
 	})
 
 	return err
@@ -3,6 +3,7 @@ package cluster
 import (
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/cluster/bind"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/cluster/connect"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/cluster/operator"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/cluster/status"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
 	"github.com/spf13/cobra"
@@ -21,6 +22,7 @@ func NewClusterCommand(f *factory.Factory) *cobra.Command {
 		status.NewStatusCommand(f),
 		connect.NewConnectCommand(f),
 		bind.NewBindCommand(f),
+		operator.NewOperatorCommand(f),
 	)
 
 	return cmd
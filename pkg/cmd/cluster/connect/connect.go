@@ -3,10 +3,12 @@ package connect
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/redhat-developer/app-services-cli/internal/build"
 	"github.com/redhat-developer/app-services-cli/internal/config"
 	"github.com/redhat-developer/app-services-cli/pkg/cluster"
+	clusterflags "github.com/redhat-developer/app-services-cli/pkg/cmd/cluster/flags"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
 	"github.com/redhat-developer/app-services-cli/pkg/connection"
 	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
@@ -23,13 +25,14 @@ type Options struct {
 	IO         *iostreams.IOStreams
 	localizer  localize.Localizer
 
-	kubeconfigLocation string
-	namespace          string
+	kubeConfig cluster.KubeConfigOptions
+	namespace  string
 
 	offlineAccessToken      string
 	forceCreationWithoutAsk bool
 	ignoreContext           bool
 	selectedKafka           string
+	timeout                 time.Duration
 }
 
 func NewConnectCommand(f *factory.Factory) *cobra.Command {
@@ -55,11 +58,12 @@ func NewConnectCommand(f *factory.Factory) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVarP(&opts.kubeconfigLocation, "kubeconfig", "", "", opts.localizer.MustLocalize("cluster.common.flag.kubeconfig.description"))
+	clusterflags.AddKubeConfigFlags(cmd, &opts.kubeConfig, opts.localizer)
 	cmd.Flags().StringVarP(&opts.offlineAccessToken, "token", "", "", opts.localizer.MustLocalize("cluster.common.flag.offline.token.description", localize.NewEntry("OfflineTokenURL", build.OfflineTokenURL)))
 	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "", opts.localizer.MustLocalize("cluster.common.flag.namespace.description"))
 	cmd.Flags().BoolVarP(&opts.forceCreationWithoutAsk, "yes", "y", false, opts.localizer.MustLocalize("cluster.common.flag.yes.description"))
 	cmd.Flags().BoolVarP(&opts.ignoreContext, "ignore-context", "", false, opts.localizer.MustLocalize("cluster.common.flag.ignoreContext.description"))
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", cluster.DefaultWaitTimeout, opts.localizer.MustLocalize("cluster.connect.flag.timeout.description"))
 
 	return cmd
 }
@@ -75,7 +79,7 @@ func runConnect(opts *Options) error {
 		return err
 	}
 
-	clusterConn, err := cluster.NewKubernetesClusterConnection(connection, opts.Config, logger, opts.kubeconfigLocation, opts.IO, opts.localizer)
+	clusterConn, err := cluster.NewKubernetesClusterConnection(connection, opts.Config, logger, &opts.kubeConfig, opts.IO, opts.localizer)
 	if err != nil {
 		return err
 	}
@@ -106,6 +110,7 @@ func runConnect(opts *Options) error {
 		IgnoreContext:           opts.ignoreContext,
 		SelectedKafka:           opts.selectedKafka,
 		Namespace:               opts.namespace,
+		Timeout:                 opts.timeout,
 	}
 
 	err = clusterConn.Connect(context.Background(), arguments)
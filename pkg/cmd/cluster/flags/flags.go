@@ -0,0 +1,33 @@
+// flags package contains the command line flags shared by the cluster commands
+package flags
+
+import (
+	"github.com/redhat-developer/app-services-cli/pkg/cluster"
+	"github.com/redhat-developer/app-services-cli/pkg/localize"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// FlagKubeconfig is a flag representing the path to a kubeconfig file
+	FlagKubeconfig = "kubeconfig"
+	// FlagContext is a flag representing the name of a kubeconfig context
+	FlagContext = "context"
+	// FlagCluster is a flag representing the name of a kubeconfig cluster
+	FlagCluster = "cluster"
+)
+
+// AddKubeConfigFlags adds the flags which select the kubeconfig, context and cluster to use
+func AddKubeConfigFlags(cmd *cobra.Command, opts *cluster.KubeConfigOptions, localizer localize.Localizer) {
+	cmd.Flags().StringVar(&opts.Kubeconfig, FlagKubeconfig, "", localizer.MustLocalize("cluster.common.flag.kubeconfig.description"))
+	cmd.Flags().StringVar(&opts.Context, FlagContext, "", localizer.MustLocalize("cluster.common.flag.context.description"))
+	cmd.Flags().StringVar(&opts.Cluster, FlagCluster, "", localizer.MustLocalize("cluster.common.flag.cluster.description"))
+
+	_ = cmd.RegisterFlagCompletionFunc(FlagContext, func(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		contexts, _ := cluster.KubeConfigContexts(opts)
+		return contexts, cobra.ShellCompDirectiveNoFileComp
+	})
+	_ = cmd.RegisterFlagCompletionFunc(FlagCluster, func(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		clusters, _ := cluster.KubeConfigClusters(opts)
+		return clusters, cobra.ShellCompDirectiveNoFileComp
+	})
+}
@@ -0,0 +1,85 @@
+package install
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/cluster"
+	clusterflags "github.com/redhat-developer/app-services-cli/pkg/cmd/cluster/flags"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
+	"github.com/redhat-developer/app-services-cli/pkg/connection"
+	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
+	"github.com/redhat-developer/app-services-cli/pkg/localize"
+	"github.com/redhat-developer/app-services-cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+type Options struct {
+	Config     config.IConfig
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	IO         *iostreams.IOStreams
+	localizer  localize.Localizer
+
+	kubeConfig cluster.KubeConfigOptions
+
+	forceCreationWithoutAsk bool
+	timeout                 time.Duration
+}
+
+// NewInstallCommand creates a command to install the operators used by the CLI
+func NewInstallCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		Config:     f.Config,
+		Connection: f.Connection,
+		Logger:     f.Logger,
+		IO:         f.IOStreams,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.MustLocalize("cluster.operator.install.cmd.use"),
+		Short:   opts.localizer.MustLocalize("cluster.operator.install.cmd.shortDescription"),
+		Long:    opts.localizer.MustLocalize("cluster.operator.install.cmd.longDescription"),
+		Example: opts.localizer.MustLocalize("cluster.operator.install.cmd.example"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !opts.forceCreationWithoutAsk && !opts.IO.CanPrompt() {
+				return errors.New(opts.localizer.MustLocalize("flag.error.requiredWhenNonInteractive", localize.NewEntry("Flag", "yes")))
+			}
+			return runInstall(opts)
+		},
+	}
+
+	clusterflags.AddKubeConfigFlags(cmd, &opts.kubeConfig, opts.localizer)
+	cmd.Flags().BoolVarP(&opts.forceCreationWithoutAsk, "yes", "y", false, opts.localizer.MustLocalize("cluster.operator.common.flag.yes.description"))
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", cluster.DefaultOperatorTimeout, opts.localizer.MustLocalize("cluster.operator.common.flag.timeout.description"))
+
+	return cmd
+}
+
+func runInstall(opts *Options) error {
+	conn, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
+	if err != nil {
+		return err
+	}
+
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	clusterConn, err := cluster.NewKubernetesClusterConnection(conn, opts.Config, logger, &opts.kubeConfig, opts.IO, opts.localizer)
+	if err != nil {
+		return err
+	}
+
+	arguments := &cluster.OperatorArguments{
+		ForceCreationWithoutAsk: opts.forceCreationWithoutAsk,
+		Timeout:                 opts.timeout,
+	}
+
+	return clusterConn.InstallOperators(context.Background(), arguments)
+}
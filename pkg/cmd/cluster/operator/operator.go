@@ -0,0 +1,28 @@
+package operator
+
+import (
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/cluster/operator/install"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/cluster/operator/uninstall"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/cluster/operator/upgrade"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
+	"github.com/spf13/cobra"
+)
+
+// NewOperatorCommand creates a new command sub-group to manage the operators on the cluster
+func NewOperatorCommand(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     f.Localizer.MustLocalize("cluster.operator.cmd.use"),
+		Short:   f.Localizer.MustLocalize("cluster.operator.cmd.shortDescription"),
+		Long:    f.Localizer.MustLocalize("cluster.operator.cmd.longDescription"),
+		Example: f.Localizer.MustLocalize("cluster.operator.cmd.example"),
+		Args:    cobra.MinimumNArgs(1),
+	}
+
+	cmd.AddCommand(
+		install.NewInstallCommand(f),
+		upgrade.NewUpgradeCommand(f),
+		uninstall.NewUninstallCommand(f),
+	)
+
+	return cmd
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/redhat-developer/app-services-cli/internal/config"
 	"github.com/redhat-developer/app-services-cli/pkg/cluster"
+	clusterflags "github.com/redhat-developer/app-services-cli/pkg/cmd/cluster/flags"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
 	"github.com/redhat-developer/app-services-cli/pkg/connection"
 	"github.com/redhat-developer/app-services-cli/pkg/logging"
@@ -27,7 +28,7 @@ type Options struct {
 	IO         *iostreams.IOStreams
 	localizer  localize.Localizer
 
-	kubeconfig string
+	kubeConfig cluster.KubeConfigOptions
 }
 
 func NewStatusCommand(f *factory.Factory) *cobra.Command {
@@ -50,7 +51,7 @@ func NewStatusCommand(f *factory.Factory) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVarP(&opts.kubeconfig, "kubeconfig", "", "", opts.localizer.MustLocalize("cluster.common.flag.kubeconfig.description"))
+	clusterflags.AddKubeConfigFlags(cmd, &opts.kubeConfig, opts.localizer)
 
 	return cmd
 }
@@ -66,24 +67,26 @@ func runStatus(opts *Options) error {
 		return err
 	}
 
-	clusterConn, err := cluster.NewKubernetesClusterConnection(connection, opts.Config, logger, opts.kubeconfig, opts.IO, opts.localizer)
+	clusterConn, err := cluster.NewKubernetesClusterConnection(connection, opts.Config, logger, &opts.kubeConfig, opts.IO, opts.localizer)
 	if err != nil {
 		return err
 	}
 
-	var operatorStatus string
-	// Add versioning in future
 	isCRDInstalled, err := clusterConn.IsRhoasOperatorAvailableOnCluster(context.Background())
 	if isCRDInstalled && err != nil {
 		logger.Debug(err)
 	}
 
-	if isCRDInstalled {
-		operatorStatus = color.Success(opts.localizer.MustLocalize("cluster.common.operatorInstalledMessage"))
-	} else {
-		operatorStatus = color.Error(opts.localizer.MustLocalize("cluster.common.operatorNotInstalledMessage"))
+	operators, err := clusterConn.ListOperators(context.Background())
+	if err != nil {
+		logger.Debug(err)
 	}
 
+	rhoasOperator := findOperator(operators, cluster.RHOASOperatorName)
+	// the CRD may be present even when the operator was not installed by the CLI
+	rhoasOperator.Installed = rhoasOperator.Installed || isCRDInstalled
+	serviceBindingOperator := findOperator(operators, cluster.ServiceBindingOperatorName)
+
 	currentNamespace, err := clusterConn.CurrentNamespace()
 	if err != nil {
 		return err
@@ -93,8 +96,31 @@ func runStatus(opts *Options) error {
 		opts.IO.Out,
 		opts.localizer.MustLocalize("cluster.status.statusMessage",
 			localize.NewEntry("Namespace", color.Info(currentNamespace)),
-			localize.NewEntry("OperatorStatus", operatorStatus)),
+			localize.NewEntry("OperatorStatus", operatorStatusText(opts.localizer, rhoasOperator)),
+			localize.NewEntry("ServiceBindingOperatorStatus", operatorStatusText(opts.localizer, serviceBindingOperator))),
 	)
 
 	return nil
 }
+
+func findOperator(operators []cluster.OperatorStatus, name string) cluster.OperatorStatus {
+	for _, o := range operators {
+		if o.Name == name {
+			return o
+		}
+	}
+
+	return cluster.OperatorStatus{Name: name}
+}
+
+func operatorStatusText(localizer localize.Localizer, operator cluster.OperatorStatus) string {
+	if !operator.Installed {
+		return color.Error(localizer.MustLocalize("cluster.common.operatorNotInstalledMessage"))
+	}
+
+	if operator.Version == "" {
+		return color.Success(localizer.MustLocalize("cluster.common.operatorInstalledMessage"))
+	}
+
+	return color.Success(localizer.MustLocalize("cluster.common.operatorInstalledVersionMessage", localize.NewEntry("Version", operator.Version)))
+}
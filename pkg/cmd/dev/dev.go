@@ -0,0 +1,26 @@
+package dev
+
+import (
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/dev/devinit"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/dev/fakeserver"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
+	"github.com/spf13/cobra"
+)
+
+// NewDevCommand creates a new command sub-group for local development
+func NewDevCommand(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     f.Localizer.MustLocalize("dev.cmd.use"),
+		Short:   f.Localizer.MustLocalize("dev.cmd.shortDescription"),
+		Long:    f.Localizer.MustLocalize("dev.cmd.longDescription"),
+		Example: f.Localizer.MustLocalize("dev.cmd.example"),
+		Args:    cobra.ExactArgs(1),
+	}
+
+	cmd.AddCommand(
+		devinit.NewInitCommand(f),
+		fakeserver.NewFakeServerCommand(f),
+	)
+
+	return cmd
+}
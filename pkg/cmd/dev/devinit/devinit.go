@@ -0,0 +1,142 @@
+package devinit
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/clientconfig"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
+	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
+	"github.com/redhat-developer/app-services-cli/pkg/localdev"
+	"github.com/redhat-developer/app-services-cli/pkg/localize"
+	"github.com/redhat-developer/app-services-cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+type Options struct {
+	IO        *iostreams.IOStreams
+	Config    config.IConfig
+	Logger    func() (logging.Logger, error)
+	localizer localize.Localizer
+
+	dir          string
+	overwrite    bool
+	kafkaPort    int
+	adminPort    int
+	registryPort int
+}
+
+// NewInitCommand creates a command to scaffold a local development environment
+func NewInitCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		IO:        f.IOStreams,
+		Config:    f.Config,
+		Logger:    f.Logger,
+		localizer: f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.MustLocalize("dev.init.cmd.use"),
+		Short:   opts.localizer.MustLocalize("dev.init.cmd.shortDescription"),
+		Long:    opts.localizer.MustLocalize("dev.init.cmd.longDescription"),
+		Example: opts.localizer.MustLocalize("dev.init.cmd.example"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runInit(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.dir, "dir", ".", opts.localizer.MustLocalize("dev.init.flag.dir.description"))
+	cmd.Flags().BoolVar(&opts.overwrite, "overwrite", false, opts.localizer.MustLocalize("dev.init.flag.overwrite.description"))
+	cmd.Flags().IntVar(&opts.kafkaPort, "kafka-port", localdev.DefaultKafkaPort, opts.localizer.MustLocalize("dev.init.flag.kafkaPort.description"))
+	cmd.Flags().IntVar(&opts.adminPort, "admin-port", localdev.DefaultAdminPort, opts.localizer.MustLocalize("dev.init.flag.adminPort.description"))
+	cmd.Flags().IntVar(&opts.registryPort, "registry-port", localdev.DefaultRegistryPort, opts.localizer.MustLocalize("dev.init.flag.registryPort.description"))
+
+	return cmd
+}
+
+func runInit(opts *Options) error {
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	dir, err := filepath.Abs(opts.dir)
+	if err != nil {
+		return err
+	}
+
+	env := &localdev.Environment{
+		KafkaPort:    opts.kafkaPort,
+		AdminPort:    opts.adminPort,
+		RegistryPort: opts.registryPort,
+	}
+
+	composeFile := filepath.Join(dir, localdev.ComposeFileName)
+	profile := env.Profile(composeFile)
+
+	files := map[string]func(w io.Writer) error{
+		composeFile: env.WriteComposeFile,
+	}
+	for configType, name := range localdev.ClientConfigFiles {
+		configType := configType
+		files[filepath.Join(dir, name)] = func(w io.Writer) error {
+			return clientconfig.Write(w, configType, localdev.ClientConfiguration(profile))
+		}
+	}
+
+	// check every file before writing any of them
+	if !opts.overwrite {
+		for path := range files {
+			if _, err = os.Stat(path); err == nil {
+				return errors.New(opts.localizer.MustLocalize("dev.init.error.fileAlreadyExists", localize.NewEntry("FilePath", path)))
+			}
+		}
+	}
+
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	for path, write := range files {
+		if err = writeFile(path, write); err != nil {
+			return fmt.Errorf("%v: %w", opts.localizer.MustLocalize("dev.init.error.couldNotWriteFile", localize.NewEntry("FilePath", path)), err)
+		}
+		logger.Debug(opts.localizer.MustLocalize("dev.init.log.debug.fileWritten", localize.NewEntry("FilePath", path)))
+	}
+
+	err = opts.Config.Update(func(cfg *config.Config) error {
+		cfg.UseLocalProfile(profile)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info(opts.localizer.MustLocalize("dev.init.log.info.initialized",
+		localize.NewEntry("Dir", dir),
+		localize.NewEntry("ComposeFile", composeFile),
+		localize.NewEntry("BootstrapServerHost", profile.BootstrapServerHost),
+		localize.NewEntry("RegistryURL", profile.RegistryURL),
+	))
+
+	return nil
+}
+
+func writeFile(path string, write func(w io.Writer) error) (err error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	return write(file)
+}
@@ -0,0 +1,99 @@
+package fakeserver
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/api/fake"
+	"github.com/redhat-developer/app-services-cli/pkg/cache"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
+	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
+	"github.com/redhat-developer/app-services-cli/pkg/localize"
+	"github.com/redhat-developer/app-services-cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+// DefaultPort is the port the fake control plane listens on by default
+const DefaultPort = 8000
+
+type Options struct {
+	IO        *iostreams.IOStreams
+	Config    config.IConfig
+	Logger    func() (logging.Logger, error)
+	localizer localize.Localizer
+
+	port  int
+	login bool
+}
+
+// NewFakeServerCommand creates a command to run an in-memory fake of the control plane
+func NewFakeServerCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		IO:        f.IOStreams,
+		Config:    f.Config,
+		Logger:    f.Logger,
+		localizer: f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.MustLocalize("dev.fakeServer.cmd.use"),
+		Short:   opts.localizer.MustLocalize("dev.fakeServer.cmd.shortDescription"),
+		Long:    opts.localizer.MustLocalize("dev.fakeServer.cmd.longDescription"),
+		Example: opts.localizer.MustLocalize("dev.fakeServer.cmd.example"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runFakeServer(opts)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.port, "port", DefaultPort, opts.localizer.MustLocalize("dev.fakeServer.flag.port.description"))
+	cmd.Flags().BoolVar(&opts.login, "login", false, opts.localizer.MustLocalize("dev.fakeServer.flag.login.description"))
+
+	return cmd
+}
+
+func runFakeServer(opts *Options) error {
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	server, err := fake.Listen(fmt.Sprintf("127.0.0.1:%v", opts.port))
+	if err != nil {
+		return err
+	}
+	defer server.Close()
+
+	if opts.login {
+		err = opts.Config.Update(func(cfg *config.Config) error {
+			server.Login(cfg)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		// the cached responses belong to the previous session
+		if err = cache.Clear(opts.Config); err != nil {
+			logger.Debug("Unable to clear the cache:", err)
+		}
+		logger.Info(opts.localizer.MustLocalize("dev.fakeServer.log.info.loggedIn"))
+	}
+
+	logger.Info(opts.localizer.MustLocalize("dev.fakeServer.log.info.started",
+		localize.NewEntry("URL", server.URL),
+		localize.NewEntry("AuthURL", server.AuthURL()),
+		localize.NewEntry("MASAuthURL", server.MASAuthURL()),
+		localize.NewEntry("Token", server.OfflineToken()),
+	))
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	<-interrupt
+
+	logger.Info(opts.localizer.MustLocalize("dev.fakeServer.log.info.stopped"))
+
+	return nil
+}
@@ -2,11 +2,16 @@ package factory
 
 import (
 	"context"
+	"io"
 	"net/http"
+	"os"
 
 	"github.com/redhat-developer/app-services-cli/internal/build"
 	"github.com/redhat-developer/app-services-cli/internal/config"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/debug"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/logflags"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/loghttp"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/retry"
 	"github.com/redhat-developer/app-services-cli/pkg/connection"
 	"github.com/redhat-developer/app-services-cli/pkg/httputil"
 	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
@@ -20,10 +25,10 @@ import (
 
 // nolint:funlen
 func New(cliVersion string, localizer localize.Localizer) *Factory {
-	io := iostreams.System()
+	ioStreams := iostreams.System()
 
 	var logger logging.Logger
-	var conn connection.Connection
+	var logFile *os.File
 	cfgFile := config.NewFile()
 
 	loggerFunc := func() (logging.Logger, error) {
@@ -31,10 +36,34 @@ func New(cliVersion string, localizer localize.Localizer) *Factory {
 			return logger, nil
 		}
 
-		loggerBuilder := logging.NewStdLoggerBuilder()
-		loggerBuilder = loggerBuilder.Streams(io.Out, io.ErrOut)
-
 		debugEnabled := debug.Enabled()
+
+		// the logs are written to the log file in addition to the error stream
+		errStream := ioStreams.ErrOut
+		if logFilePath := logflags.File(); logFilePath != "" {
+			if logFile == nil || logFile.Name() != logFilePath {
+				// #nosec G304
+				f, err := os.OpenFile(logFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+				if err != nil {
+					return nil, err
+				}
+				logFile = f
+			}
+			errStream = io.MultiWriter(ioStreams.ErrOut, logFile)
+		}
+
+		if logflags.Format() == logflags.JSONFormat {
+			return logging.NewJSONLoggerBuilder().
+				Stream(errStream).
+				Debug(debugEnabled).
+				Field("command", logflags.CommandPath()).
+				Field("version", cliVersion).
+				Field("pid", os.Getpid()).
+				Build()
+		}
+
+		loggerBuilder := logging.NewStdLoggerBuilder()
+		loggerBuilder = loggerBuilder.Streams(ioStreams.Out, errStream)
 		loggerBuilder = loggerBuilder.Debug(debugEnabled)
 
 		logger, err := loggerBuilder.Build()
@@ -45,88 +74,134 @@ func New(cliVersion string, localizer localize.Localizer) *Factory {
 		return logger, nil
 	}
 
-	connectionFunc := func(connectionCfg *connection.Config) (connection.Connection, error) {
-		if conn != nil {
-			return conn, nil
-		}
+	// connectionFor creates the connections to the API of the environment of a config file
+	connectionFor := func(cfgFile config.IConfig) ConnectionFunc {
+		var conn connection.Connection
 
-		cfg, err := cfgFile.Load()
-		if err != nil {
-			return nil, err
-		}
+		return func(connectionCfg *connection.Config) (connection.Connection, error) {
+			if conn != nil {
+				return conn, nil
+			}
 
-		builder := connection.NewBuilder()
+			cfg, err := cfgFile.Load()
+			if err != nil {
+				return nil, err
+			}
 
-		if cfg.AccessToken != "" {
-			builder.WithAccessToken(cfg.AccessToken)
-		}
-		if cfg.RefreshToken != "" {
-			builder.WithRefreshToken(cfg.RefreshToken)
-		}
-		if cfg.MasAccessToken != "" {
-			builder.WithMASAccessToken(cfg.MasAccessToken)
-		}
-		if cfg.MasRefreshToken != "" {
-			builder.WithMASRefreshToken(cfg.MasRefreshToken)
-		}
-		if cfg.ClientID != "" {
-			builder.WithClientID(cfg.ClientID)
-		}
-		if cfg.Scopes != nil {
-			builder.WithScopes(cfg.Scopes...)
-		}
-		if cfg.APIUrl != "" {
-			builder.WithURL(cfg.APIUrl)
-		}
-		if cfg.AuthURL == "" {
-			cfg.AuthURL = build.ProductionAuthURL
-		}
-		builder.WithAuthURL(cfg.AuthURL)
+			// create a logger if it has not already been created
+			logger, err = loggerFunc()
+			if err != nil {
+				return nil, err
+			}
 
-		if cfg.MasAuthURL == "" {
-			cfg.MasAuthURL = build.ProductionMasAuthURL
-		}
-		builder.WithMASAuthURL(cfg.MasAuthURL)
+			var har *httputil.HARRecorder
+			if harFile := loghttp.HARFile(); harFile != "" {
+				har = &httputil.HARRecorder{Filename: harFile, CreatorVersion: cliVersion}
+			}
 
-		builder.WithInsecure(cfg.Insecure)
+			// every attempt of a retried request is logged
+			transportWrapper := func(a http.RoundTripper) http.RoundTripper {
+				var roundTripper http.RoundTripper = &httputil.LoggingRoundTripper{
+					Proxied: a,
+					Logger:  logger,
+				}
+				// the traces include the error responses, so they replace the logs of the errors,
+				// while the HAR file is recorded along with the logs
+				if loghttp.Enabled() {
+					roundTripper = &httputil.TracingRoundTripper{Proxied: a, Logger: logger, HAR: har}
+				} else if har != nil {
+					roundTripper = &httputil.TracingRoundTripper{Proxied: roundTripper, HAR: har}
+				}
+
+				return &httputil.RetryRoundTripper{
+					Proxied:    roundTripper,
+					Logger:     logger,
+					MaxRetries: retry.MaxRetries(cfg),
+				}
+			}
 
-		builder.WithConfig(cfgFile)
+			// the local development environment does not require authentication
+			if cfg.IsLocalProfile() {
+				conn = connection.NewLocalConnection(cfg.LocalProfile, logger, transportWrapper)
+				return conn, nil
+			}
 
-		// create a logger if it has not already been created
-		logger, err = loggerFunc()
-		if err != nil {
-			return nil, err
-		}
+			builder := connection.NewBuilder()
 
-		transportWrapper := func(a http.RoundTripper) http.RoundTripper {
-			return &httputil.LoggingRoundTripper{
-				Proxied: a,
-				Logger:  logger,
+			if cfg.AccessToken != "" {
+				builder.WithAccessToken(cfg.AccessToken)
 			}
-		}
+			if cfg.RefreshToken != "" {
+				builder.WithRefreshToken(cfg.RefreshToken)
+			}
+			if cfg.MasAccessToken != "" {
+				builder.WithMASAccessToken(cfg.MasAccessToken)
+			}
+			if cfg.MasRefreshToken != "" {
+				builder.WithMASRefreshToken(cfg.MasRefreshToken)
+			}
+			if cfg.ClientID != "" {
+				builder.WithClientID(cfg.ClientID)
+			}
+			if cfg.Scopes != nil {
+				builder.WithScopes(cfg.Scopes...)
+			}
+			if cfg.APIUrl != "" {
+				builder.WithURL(cfg.APIUrl)
+			}
+			if cfg.AuthURL == "" {
+				cfg.AuthURL = build.ProductionAuthURL
+			}
+			builder.WithAuthURL(cfg.AuthURL)
 
-		builder.WithTransportWrapper(transportWrapper)
+			if cfg.MasAuthURL == "" {
+				cfg.MasAuthURL = build.ProductionMasAuthURL
+			}
+			builder.WithMASAuthURL(cfg.MasAuthURL)
 
-		builder.WithConnectionConfig(connectionCfg)
+			builder.WithInsecure(cfg.Insecure)
 
-		conn, err = builder.Build()
-		if err != nil {
-			return nil, err
-		}
+			if cfg.CAFile != "" {
+				trustedCAs, caErr := connection.LoadTrustedCAs(cfg.CAFile)
+				if caErr != nil {
+					return nil, caErr
+				}
+				builder.WithTrustedCAs(trustedCAs)
+			}
+			if cfg.CertFile != "" {
+				clientCert, certErr := connection.LoadClientCertificate(cfg.CertFile, cfg.KeyFile)
+				if certErr != nil {
+					return nil, certErr
+				}
+				builder.WithClientCertificate(clientCert)
+			}
 
-		err = conn.RefreshTokens(context.TODO())
-		if err != nil {
-			return nil, err
-		}
+			builder.WithConfig(cfgFile)
+
+			builder.WithTransportWrapper(transportWrapper)
 
-		return conn, nil
+			builder.WithConnectionConfig(connectionCfg)
+
+			conn, err = builder.Build()
+			if err != nil {
+				return nil, err
+			}
+
+			err = conn.RefreshTokens(context.TODO())
+			if err != nil {
+				return nil, err
+			}
+
+			return conn, nil
+		}
 	}
 
 	return &Factory{
-		IOStreams:  io,
-		Config:     cfgFile,
-		Connection: connectionFunc,
-		Logger:     loggerFunc,
-		Localizer:  localizer,
+		IOStreams:     ioStreams,
+		Config:        cfgFile,
+		Connection:    connectionFor(cfgFile),
+		ConnectionFor: connectionFor,
+		Logger:        loggerFunc,
+		Localizer:     localizer,
 	}
 }
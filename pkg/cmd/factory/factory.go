@@ -17,6 +17,8 @@ type Factory struct {
 	Config config.IConfig
 	// Creates a connection to the API
 	Connection ConnectionFunc
+	// Creates the connections to the API of the environment of another config file
+	ConnectionFor func(cfg config.IConfig) ConnectionFunc
 	// Returns a logger to create leveled logs in the application
 	Logger func() (logging.Logger, error)
 	// Localizer provides text to the commands
@@ -2,6 +2,8 @@ package flag
 
 import (
 	"fmt"
+
+	"github.com/redhat-developer/app-services-cli/pkg/common/clierr"
 )
 
 type Error struct {
@@ -16,6 +18,11 @@ func (e *Error) Unwrap() error {
 	return e.Err
 }
 
+// ErrorCode returns the code of invalid flag values
+func (e *Error) ErrorCode() clierr.Code {
+	return clierr.CodeValidation
+}
+
 // InvalidValueError returns an error when an invalid flag value is provided
 func InvalidValueError(flag string, val interface{}, validOptions ...string) *Error {
 	var chooseFromStr string
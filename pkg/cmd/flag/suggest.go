@@ -0,0 +1,78 @@
+package flag
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/redhat-developer/app-services-cli/pkg/localize"
+)
+
+// maxSuggestionDistance is the maximum number of edits between a value and a suggestion for it
+const maxSuggestionDistance = 2
+
+// Suggest returns the candidate closest to the value, to suggest it when the value is invalid.
+// It returns an empty string when no candidate is close enough
+func Suggest(value string, candidates []string) string {
+	value = strings.ToLower(value)
+
+	suggestion, bestDistance := "", maxSuggestionDistance+1
+	for _, candidate := range candidates {
+		c := strings.ToLower(candidate)
+		distance := levenshtein(value, c)
+		// a truncated value is close to the candidate, whatever its length
+		if value != "" && strings.HasPrefix(c, value) && distance > maxSuggestionDistance {
+			distance = maxSuggestionDistance
+		}
+		if distance < bestDistance {
+			suggestion, bestDistance = candidate, distance
+		}
+	}
+	return suggestion
+}
+
+// InvalidValueWithSuggestionError returns an error when an invalid flag value is provided,
+// suggesting the valid option closest to the value when there is one
+func InvalidValueWithSuggestionError(localizer localize.Localizer, flagName string, val string, validOptions ...string) error {
+	err := InvalidValueError(flagName, val, validOptions...)
+
+	suggestion := Suggest(val, validOptions)
+	if suggestion == "" {
+		return err
+	}
+	return &Error{
+		Err: fmt.Errorf("%w. %v", err.Err, localizer.MustLocalize("common.error.didYouMean", localize.NewEntry("Suggestion", suggestion))),
+	}
+}
+
+// levenshtein returns the number of single character edits needed to change a into b
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func min(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
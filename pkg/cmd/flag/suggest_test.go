@@ -0,0 +1,29 @@
+package flag
+
+import "testing"
+
+func TestSuggest(t *testing.T) {
+	regions := []string{"us-east-1", "eu-west-1", "us-central1"}
+
+	tests := []struct {
+		value      string
+		candidates []string
+		want       string
+	}{
+		{value: "us-east-2", candidates: regions, want: "us-east-1"},
+		{value: "eu-wst-1", candidates: regions, want: "eu-west-1"},
+		{value: "US-EAST-1", candidates: regions, want: "us-east-1"},
+		{value: "us-cent", candidates: regions, want: "us-central1"},
+		{value: "ap-south-1", candidates: regions, want: ""},
+		{value: "asw", candidates: []string{"aws", "gcp"}, want: "aws"},
+		{value: "azure", candidates: []string{"aws", "gcp"}, want: ""},
+		{value: "aws", candidates: []string{}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := Suggest(tt.value, tt.candidates); got != tt.want {
+				t.Errorf("Suggest(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
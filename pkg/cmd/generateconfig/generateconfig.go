@@ -0,0 +1,213 @@
+package generateconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/clientconfig"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/flag"
+	flagutil "github.com/redhat-developer/app-services-cli/pkg/cmdutil/flags"
+	"github.com/redhat-developer/app-services-cli/pkg/connection"
+	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
+	"github.com/redhat-developer/app-services-cli/pkg/kafka"
+	"github.com/redhat-developer/app-services-cli/pkg/localdev"
+	"github.com/redhat-developer/app-services-cli/pkg/localize"
+	"github.com/redhat-developer/app-services-cli/pkg/logging"
+	"github.com/redhat-developer/app-services-cli/pkg/serviceaccount/credentials"
+	"github.com/redhat-developer/app-services-cli/pkg/serviceaccount/serviceaccountutil"
+	"github.com/redhat-developer/app-services-cli/pkg/serviceregistry"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	"github.com/spf13/cobra"
+)
+
+const defaultConfigMapName = "rhoas-config"
+
+type Options struct {
+	IO         *iostreams.IOStreams
+	Config     config.IConfig
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	localizer  localize.Localizer
+
+	configType           string
+	saslMechanism        string
+	createServiceAccount bool
+	filename             string
+	overwrite            bool
+	configMapName        string
+}
+
+// NewGenerateConfigCommand creates a command to generate the configuration
+// used by applications to connect to the selected services
+func NewGenerateConfigCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		IO:         f.IOStreams,
+		Config:     f.Config,
+		Connection: f.Connection,
+		Logger:     f.Logger,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.MustLocalize("generateConfig.cmd.use"),
+		Short:   opts.localizer.MustLocalize("generateConfig.cmd.shortDescription"),
+		Long:    opts.localizer.MustLocalize("generateConfig.cmd.longDescription"),
+		Example: opts.localizer.MustLocalize("generateConfig.cmd.example"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if opts.configType == "" {
+				return errors.New(opts.localizer.MustLocalize("generateConfig.error.typeRequired"))
+			}
+			if !flagutil.IsValidInput(opts.configType, clientconfig.Types...) {
+				return flag.InvalidValueError("type", opts.configType, clientconfig.Types...)
+			}
+			if !flagutil.IsValidInput(opts.saslMechanism, clientconfig.SASLMechanisms...) {
+				return flag.InvalidValueError("sasl-mechanism", opts.saslMechanism, clientconfig.SASLMechanisms...)
+			}
+
+			// the path is expanded once, so that the file which is checked is the file which is written
+			opts.filename = os.ExpandEnv(opts.filename)
+
+			return runGenerateConfig(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.configType, "type", "", opts.localizer.MustLocalize("generateConfig.flag.type.description"))
+	cmd.Flags().StringVar(&opts.saslMechanism, "sasl-mechanism", clientconfig.OAuthBearerMechanism, opts.localizer.MustLocalize("generateConfig.flag.saslMechanism.description"))
+	cmd.Flags().BoolVar(&opts.createServiceAccount, "create-service-account", false, opts.localizer.MustLocalize("generateConfig.flag.createServiceAccount.description"))
+	cmd.Flags().StringVar(&opts.filename, "file-location", "", opts.localizer.MustLocalize("generateConfig.flag.fileLocation.description"))
+	cmd.Flags().BoolVar(&opts.overwrite, "overwrite", false, opts.localizer.MustLocalize("generateConfig.flag.overwrite.description"))
+	cmd.Flags().StringVar(&opts.configMapName, "configmap-name", defaultConfigMapName, opts.localizer.MustLocalize("generateConfig.flag.configMapName.description"))
+
+	flagutil.EnableStaticFlagCompletion(cmd, "type", clientconfig.Types)
+	flagutil.EnableStaticFlagCompletion(cmd, "sasl-mechanism", clientconfig.SASLMechanisms)
+
+	return cmd
+}
+
+// nolint:funlen
+func runGenerateConfig(opts *Options) error {
+	cfg, err := opts.Config.Load()
+	if err != nil {
+		return err
+	}
+
+	if !cfg.HasKafka() {
+		return errors.New(opts.localizer.MustLocalize("generateConfig.error.noKafkaSelected"))
+	}
+
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	// fail before anything is created when the file cannot be written
+	if opts.filename != "" {
+		if _, err = os.Stat(opts.filename); err == nil && !opts.overwrite {
+			return errors.New(opts.localizer.MustLocalize("generateConfig.error.fileAlreadyExists", localize.NewEntry("FilePath", opts.filename)))
+		}
+	}
+
+	// the local environment does not use authentication, so there is nothing to fetch
+	if cfg.IsLocalProfile() {
+		configuration := localdev.ClientConfiguration(cfg.LocalProfile)
+		configuration.Name = opts.configMapName
+		return writeConfiguration(opts, logger, configuration)
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
+	if err != nil {
+		return err
+	}
+
+	api := conn.API()
+	ctx := context.Background()
+
+	kafkaInstance, _, err := kafka.GetKafkaByID(ctx, api.Kafka(), cfg.Services.Kafka.ClusterID)
+	if err != nil {
+		return err
+	}
+
+	if kafkaInstance.GetBootstrapServerHost() == "" {
+		return errors.New(opts.localizer.MustLocalize("generateConfig.error.kafkaNotReady", localize.NewEntry("Name", kafkaInstance.GetName())))
+	}
+
+	configuration := &clientconfig.Configuration{
+		BootstrapServerHost: kafkaInstance.GetBootstrapServerHost(),
+		TokenURL:            clientconfig.TokenURL(cfg),
+		SASLMechanism:       opts.saslMechanism,
+		Name:                opts.configMapName,
+	}
+
+	if cfg.Services.ServiceRegistry != nil && cfg.Services.ServiceRegistry.InstanceID != "" {
+		registry, _, registryErr := serviceregistry.GetServiceRegistryByID(ctx, api.ServiceRegistryMgmt(), cfg.Services.ServiceRegistry.InstanceID)
+		if registryErr != nil {
+			return registryErr
+		}
+		configuration.RegistryURL = registry.GetRegistryUrl()
+	} else {
+		logger.Debug(opts.localizer.MustLocalize("generateConfig.log.debug.noServiceRegistrySelected"))
+	}
+
+	if opts.createServiceAccount {
+		configuration.Credentials, err = createServiceAccount(ctx, api.ServiceAccount(), opts.localizer)
+		if err != nil {
+			return err
+		}
+		logger.Info(opts.localizer.MustLocalize("generateConfig.log.info.serviceAccountCreated", localize.NewEntry("ClientID", configuration.Credentials.ClientID)))
+	} else {
+		logger.Info(opts.localizer.MustLocalize("generateConfig.log.info.credentialsFromEnvironment"))
+	}
+
+	return writeConfiguration(opts, logger, configuration)
+}
+
+// writeConfiguration writes the configuration to the standard output, or to a file when set
+func writeConfiguration(opts *Options, logger logging.Logger, configuration *clientconfig.Configuration) error {
+	if opts.filename == "" {
+		return clientconfig.Write(opts.IO.Out, opts.configType, configuration)
+	}
+
+	if err := writeFile(opts.filename, opts.configType, configuration); err != nil {
+		return fmt.Errorf("%v: %w", opts.localizer.MustLocalize("generateConfig.error.couldNotSaveFile"), err)
+	}
+
+	logger.Info(opts.localizer.MustLocalize("generateConfig.log.info.fileSaved", localize.NewEntry("FilePath", opts.filename)))
+
+	return nil
+}
+
+func createServiceAccount(ctx context.Context, api kafkamgmtclient.SecurityApi, localizer localize.Localizer) (*credentials.Credentials, error) {
+	serviceAcct := kafkamgmtclient.ServiceAccountRequest{Name: serviceaccountutil.NewGeneratedName(time.Now())}
+
+	res, _, err := api.CreateServiceAccount(ctx).ServiceAccountRequest(serviceAcct).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", localizer.MustLocalize("serviceAccount.create.error.couldNotCreate"), err)
+	}
+
+	return &credentials.Credentials{
+		ClientID:     res.GetClientId(),
+		ClientSecret: res.GetClientSecret(),
+	}, nil
+}
+
+func writeFile(filename string, configType string, configuration *clientconfig.Configuration) (err error) {
+	// the file may contain credentials, so it is only readable by the owner
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer func(f io.Closer) {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}(file)
+
+	return clientconfig.Write(file, configType, configuration)
+}
@@ -3,13 +3,18 @@ package delete
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/cache"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
 	"github.com/redhat-developer/app-services-cli/pkg/cmdutil"
+	"github.com/redhat-developer/app-services-cli/pkg/cmdutil/bulk"
+	"github.com/redhat-developer/app-services-cli/pkg/common/clierr"
 	"github.com/redhat-developer/app-services-cli/pkg/connection"
 	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
+	"github.com/redhat-developer/app-services-cli/pkg/kafka/consumergroup"
 	"github.com/redhat-developer/app-services-cli/pkg/localize"
 	"github.com/redhat-developer/app-services-cli/pkg/logging"
 	"github.com/spf13/cobra"
@@ -19,6 +24,7 @@ type Options struct {
 	kafkaID     string
 	id          string
 	skipConfirm bool
+	bulk        bulk.Options
 
 	IO         *iostreams.IOStreams
 	Config     config.IConfig
@@ -44,21 +50,36 @@ func NewDeleteConsumerGroupCommand(f *factory.Factory) *cobra.Command {
 		Example: opts.localizer.MustLocalize("kafka.consumerGroup.delete.cmd.example"),
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
-			if opts.kafkaID != "" {
-				return runCmd(opts)
+			if opts.bulk.Enabled() {
+				if opts.id != "" {
+					return errors.New(opts.localizer.MustLocalize("bulk.error.nameWithSelection", localize.NewEntry("Field", opts.localizer.MustLocalize("kafka.consumerGroup.delete.bulk.field"))))
+				}
+				if err = opts.bulk.Validate(opts.localizer); err != nil {
+					return err
+				}
+			} else if opts.id == "" {
+				return errors.New(opts.localizer.MustLocalize("bulk.error.nameRequired",
+					localize.NewEntry("Field", opts.localizer.MustLocalize("kafka.consumerGroup.delete.bulk.field")),
+					localize.NewEntry("Kind", opts.localizer.MustLocalize("kafka.consumerGroup.delete.bulk.kind")),
+				))
 			}
 
-			cfg, err := opts.Config.Load()
-			if err != nil {
-				return err
-			}
+			if opts.kafkaID == "" {
+				cfg, err := opts.Config.Load()
+				if err != nil {
+					return err
+				}
 
-			if !cfg.HasKafka() {
-				return errors.New(opts.localizer.MustLocalize("kafka.consumerGroup.common.error.noKafkaSelected"))
-			}
+				if !cfg.HasKafka() {
+					return errors.New(opts.localizer.MustLocalize("kafka.consumerGroup.common.error.noKafkaSelected"))
+				}
 
-			opts.kafkaID = cfg.Services.Kafka.ClusterID
+				opts.kafkaID = cfg.Services.Kafka.ClusterID
+			}
 
+			if opts.bulk.Enabled() {
+				return runBulkDelete(opts)
+			}
 			return runCmd(opts)
 		},
 	}
@@ -66,7 +87,7 @@ func NewDeleteConsumerGroupCommand(f *factory.Factory) *cobra.Command {
 	opts.localizer.MustLocalize("kafka.consumerGroup.common.flag.id.description", localize.NewEntry("Action", "delete"))
 	cmd.Flags().BoolVarP(&opts.skipConfirm, "yes", "y", false, opts.localizer.MustLocalize("kafka.consumerGroup.delete.flag.yes.description"))
 	cmd.Flags().StringVar(&opts.id, "id", "", opts.localizer.MustLocalize("kafka.consumerGroup.common.flag.id.description", localize.NewEntry("Action", "delete")))
-	_ = cmd.MarkFlagRequired("id")
+	bulk.AddFlags(cmd, &opts.bulk, opts.localizer, opts.localizer.MustLocalize("kafka.consumerGroup.delete.bulk.kind"), false)
 
 	// flag based completions for ID
 	_ = cmd.RegisterFlagCompletionFunc("id", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -104,7 +125,7 @@ func runCmd(opts *Options) error {
 			return err
 		}
 		if httpRes.StatusCode == 404 {
-			return errors.New(opts.localizer.MustLocalize("kafka.consumerGroup.common.error.notFoundError", cgIDPair, kafkaNameTmplPair))
+			return clierr.New(clierr.CodeNotFound, errors.New(opts.localizer.MustLocalize("kafka.consumerGroup.common.error.notFoundError", cgIDPair, kafkaNameTmplPair)))
 		}
 	}
 
@@ -135,21 +156,73 @@ func runCmd(opts *Options) error {
 
 		switch httpRes.StatusCode {
 		case 401:
-			return errors.New(opts.localizer.MustLocalize("kafka.consumerGroup.common.error.unauthorized", operationTmplPair))
+			return clierr.New(clierr.CodeNotLoggedIn, errors.New(opts.localizer.MustLocalize("kafka.consumerGroup.common.error.unauthorized", operationTmplPair)))
 		case 403:
-			return errors.New(opts.localizer.MustLocalize("kafka.consumerGroup.common.error.forbidden", operationTmplPair))
+			return clierr.New(clierr.CodeForbidden, errors.New(opts.localizer.MustLocalize("kafka.consumerGroup.common.error.forbidden", operationTmplPair)))
 		case 423:
-			return errors.New(opts.localizer.MustLocalize("kafka.consumerGroup.delete.error.locked"))
+			return clierr.New(clierr.CodeConflict, errors.New(opts.localizer.MustLocalize("kafka.consumerGroup.delete.error.locked")))
 		case 500:
-			return errors.New(opts.localizer.MustLocalize("kafka.consumerGroup.common.error.internalServerError"))
+			return clierr.New(clierr.CodeServiceUnavailable, errors.New(opts.localizer.MustLocalize("kafka.consumerGroup.common.error.internalServerError")))
 		case 503:
-			return errors.New(opts.localizer.MustLocalize("kafka.consumerGroup.common.error.unableToConnectToKafka", localize.NewEntry("Name", kafkaInstance.GetName())))
+			return clierr.New(clierr.CodeServiceUnavailable, errors.New(opts.localizer.MustLocalize("kafka.consumerGroup.common.error.unableToConnectToKafka", localize.NewEntry("Name", kafkaInstance.GetName()))))
 		default:
 			return err
 		}
 	}
 
+	cmdutil.InvalidateCache(opts.Config, logger, cache.ConsumerGroupsNamespace(opts.kafkaID))
+
 	logger.Info(opts.localizer.MustLocalize("kafka.consumerGroup.delete.log.info.consumerGroupDeleted", localize.NewEntry("ConsumerGroupID", opts.id), kafkaNameTmplPair))
 
 	return nil
 }
+
+// runBulkDelete deletes the consumer groups selected by the bulk deletion flags
+func runBulkDelete(opts *Options) error {
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigRequireMasAuth)
+	if err != nil {
+		return err
+	}
+
+	api, _, err := conn.API().KafkaAdmin(opts.kafkaID)
+	if err != nil {
+		return err
+	}
+
+	groups, err := consumergroup.ListConsumerGroups(context.Background(), api)
+	if err != nil {
+		return err
+	}
+
+	resources := make([]bulk.Resource, len(groups))
+	for i, g := range groups {
+		resources[i] = bulk.Resource{ID: g.GetGroupId(), Name: g.GetGroupId()}
+	}
+
+	deletion := &bulk.Deletion{
+		IO:        opts.IO,
+		Logger:    logger,
+		Localizer: opts.localizer,
+		Kind:      opts.localizer.MustLocalize("kafka.consumerGroup.delete.bulk.kind"),
+		Resources: opts.bulk.Match(resources, time.Now()),
+		Force:     opts.skipConfirm,
+		Delete: func(ctx context.Context, r bulk.Resource) error {
+			httpRes, err := api.GroupsApi.DeleteConsumerGroupById(ctx, r.ID).Execute()
+			if httpRes != nil && httpRes.StatusCode == 423 {
+				return clierr.New(clierr.CodeConflict, errors.New(opts.localizer.MustLocalize("kafka.consumerGroup.delete.error.locked")))
+			}
+			return err
+		},
+	}
+	deleted, err := deletion.Run()
+	if len(deleted) > 0 {
+		cmdutil.InvalidateCache(opts.Config, logger, cache.ConsumerGroupsNamespace(opts.kafkaID))
+	}
+
+	return err
+}
@@ -8,9 +8,11 @@ import (
 
 	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
 
+	"github.com/redhat-developer/app-services-cli/pkg/common/clierr"
 	"github.com/redhat-developer/app-services-cli/pkg/localize"
 
 	"github.com/redhat-developer/app-services-cli/pkg/ams"
+	"github.com/redhat-developer/app-services-cli/pkg/cache"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/flag"
 	flagutil "github.com/redhat-developer/app-services-cli/pkg/cmdutil/flags"
 	"github.com/redhat-developer/app-services-cli/pkg/connection"
@@ -116,6 +118,14 @@ func NewCreateCommand(f *factory.Factory) *cobra.Command {
 		return cmdutil.FetchCloudProviders(f)
 	})
 
+	_ = cmd.RegisterFlagCompletionFunc(flags.FlagRegion, func(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		provider, _ := cmd.Flags().GetString(flags.FlagProvider)
+		if provider == "" {
+			provider = defaultProvider
+		}
+		return cmdutil.FetchCloudRegions(f, provider)
+	})
+
 	flagutil.EnableOutputFlagCompletion(cmd)
 
 	return cmd
@@ -128,11 +138,6 @@ func runCreate(opts *Options) error {
 		return err
 	}
 
-	cfg, err := opts.Config.Load()
-	if err != nil {
-		return err
-	}
-
 	connection, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
 	if err != nil {
 		return err
@@ -149,6 +154,13 @@ func runCreate(opts *Options) error {
 		return nil
 	}
 
+	// the quota only explains which type of instance is provisioned, so the instance is created even when it cannot be fetched
+	if quotas, quotaErr := ams.GetQuotas(connection); quotaErr != nil {
+		logger.Debug(opts.localizer.MustLocalize("kafka.create.log.debug.couldNotCheckQuota"), quotaErr)
+	} else {
+		logger.Info(ams.DescribeKafkaInstanceType(opts.localizer, quotas))
+	}
+
 	var payload *kafkamgmtclient.KafkaRequestPayload
 	if opts.interactive {
 		logger.Debug()
@@ -166,11 +178,18 @@ func runCreate(opts *Options) error {
 			opts.region = defaultRegion
 		}
 
+		region, err := validateProviderAndRegion(opts, connection)
+		if err != nil {
+			return err
+		}
+		// instances are only deployed across multiple availability zones in the regions which support them
+		multiAZ := opts.multiAZ && region.MultiAZ()
+
 		payload = &kafkamgmtclient.KafkaRequestPayload{
 			Name:          opts.name,
 			Region:        &opts.region,
 			CloudProvider: &opts.provider,
-			MultiAz:       &opts.multiAZ,
+			MultiAz:       &multiAZ,
 		}
 	}
 
@@ -184,13 +203,15 @@ func runCreate(opts *Options) error {
 	response, httpRes, err := a.Execute()
 
 	if httpRes.StatusCode == 409 {
-		return errors.New(opts.localizer.MustLocalize("kafka.create.error.conflictError", localize.NewEntry("Name", payload.Name)))
+		return clierr.New(clierr.CodeConflict, errors.New(opts.localizer.MustLocalize("kafka.create.error.conflictError", localize.NewEntry("Name", payload.Name))))
 	}
 
 	if err != nil {
 		return err
 	}
 
+	cmdutil.InvalidateCache(opts.Config, logger, cache.KafkasNamespace)
+
 	logger.Info(opts.localizer.MustLocalize("kafka.create.info.successMessage", localize.NewEntry("Name", response.GetName())))
 
 	switch opts.outputFormat {
@@ -208,8 +229,11 @@ func runCreate(opts *Options) error {
 
 	if opts.autoUse {
 		logger.Debug("Auto-use is set, updating the current instance")
-		cfg.Services.Kafka = kafkaCfg
-		if err := opts.Config.Save(cfg); err != nil {
+		err = opts.Config.Update(func(cfg *config.Config) error {
+			cfg.Services.Kafka = kafkaCfg
+			return nil
+		})
+		if err != nil {
 			return fmt.Errorf("%v: %w", opts.localizer.MustLocalize("kafka.common.error.couldNotUseKafka"), err)
 		}
 	} else {
@@ -219,6 +243,35 @@ func runCreate(opts *Options) error {
 	return nil
 }
 
+// validateProviderAndRegion checks that Kafka instances can be created in the cloud provider and region set by the flags,
+// so that a mistyped value is reported along with the valid values instead of the error of the API.
+// It returns the region when it is valid
+func validateProviderAndRegion(opts *Options, conn connection.Connection) (*cloudregionutil.Region, error) {
+	cloudProviders, err := cmdutil.GetCloudProviders(opts.Config, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	providerNames := cloudproviderutil.GetEnabledNames(cloudProviders)
+	cloudProvider := cloudproviderutil.FindByName(cloudProviders, opts.provider)
+	if cloudProvider == nil || !cloudProvider.GetEnabled() {
+		return nil, flag.InvalidValueWithSuggestionError(opts.localizer, flags.FlagProvider, opts.provider, providerNames...)
+	}
+
+	regions, err := cmdutil.GetCloudRegions(opts.Config, conn, cloudProvider.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	regionIDs := cloudregionutil.GetEnabledIDs(regions)
+	region := cloudregionutil.FindByID(regions, opts.region)
+	if region == nil || !region.Enabled {
+		return nil, flag.InvalidValueWithSuggestionError(opts.localizer, flags.FlagRegion, opts.region, regionIDs...)
+	}
+
+	return region, nil
+}
+
 // Show a prompt to allow the user to interactively insert the data for their Kafka
 func promptKafkaPayload(opts *Options) (payload *kafkamgmtclient.KafkaRequestPayload, err error) {
 	connection, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
@@ -226,8 +279,6 @@ func promptKafkaPayload(opts *Options) (payload *kafkamgmtclient.KafkaRequestPay
 		return nil, err
 	}
 
-	api := connection.API()
-
 	validator := &pkgKafka.Validator{
 		Localizer:  opts.localizer,
 		Connection: opts.Connection,
@@ -254,12 +305,11 @@ func promptKafkaPayload(opts *Options) (payload *kafkamgmtclient.KafkaRequestPay
 	}
 
 	// fetch all cloud available providers
-	cloudProviderResponse, _, err := api.Kafka().GetCloudProviders(context.Background()).Execute()
+	cloudProviders, err := cmdutil.GetCloudProviders(opts.Config, connection)
 	if err != nil {
 		return nil, err
 	}
 
-	cloudProviders := cloudProviderResponse.GetItems()
 	cloudProviderNames := cloudproviderutil.GetEnabledNames(cloudProviders)
 
 	cloudProviderPrompt := &survey.Select{
@@ -275,13 +325,11 @@ func promptKafkaPayload(opts *Options) (payload *kafkamgmtclient.KafkaRequestPay
 	// get the selected provider type from the name selected
 	selectedCloudProvider := cloudproviderutil.FindByName(cloudProviders, answers.CloudProvider)
 
-	// nolint
-	cloudRegionResponse, _, err := api.Kafka().GetCloudProviderRegions(context.Background(), selectedCloudProvider.GetId()).Execute()
+	regions, err := cmdutil.GetCloudRegions(opts.Config, connection, selectedCloudProvider.GetId())
 	if err != nil {
 		return nil, err
 	}
 
-	regions := cloudRegionResponse.GetItems()
 	regionIDs := cloudregionutil.GetEnabledIDs(regions)
 
 	regionPrompt := &survey.Select{
@@ -4,7 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/redhat-developer/app-services-cli/pkg/cache"
+	"github.com/redhat-developer/app-services-cli/pkg/cmdutil"
+	"github.com/redhat-developer/app-services-cli/pkg/cmdutil/bulk"
 	"github.com/redhat-developer/app-services-cli/pkg/connection"
 	"github.com/redhat-developer/app-services-cli/pkg/localize"
 
@@ -25,6 +29,7 @@ type options struct {
 	id    string
 	name  string
 	force bool
+	bulk  bulk.Options
 
 	IO         *iostreams.IOStreams
 	Config     config.IConfig
@@ -62,6 +67,16 @@ func NewDeleteCommand(f *factory.Factory) *cobra.Command {
 				return errors.New(opts.localizer.MustLocalize("service.error.idAndNameCannotBeUsed"))
 			}
 
+			if opts.bulk.Enabled() {
+				if opts.name != "" || opts.id != "" {
+					return errors.New(opts.localizer.MustLocalize("bulk.error.nameWithSelection", localize.NewEntry("Field", opts.localizer.MustLocalize("kafka.delete.bulk.field"))))
+				}
+				if err := opts.bulk.Validate(opts.localizer); err != nil {
+					return err
+				}
+				return runBulkDelete(opts)
+			}
+
 			if opts.id != "" || opts.name != "" {
 				return runDelete(opts)
 			}
@@ -84,6 +99,7 @@ func NewDeleteCommand(f *factory.Factory) *cobra.Command {
 
 	cmd.Flags().StringVar(&opts.id, "id", "", opts.localizer.MustLocalize("kafka.delete.flag.id"))
 	cmd.Flags().BoolVarP(&opts.force, "yes", "y", false, opts.localizer.MustLocalize("kafka.delete.flag.yes"))
+	bulk.AddFlags(cmd, &opts.bulk, opts.localizer, opts.localizer.MustLocalize("kafka.delete.bulk.kind"), true)
 
 	return cmd
 }
@@ -94,11 +110,6 @@ func runDelete(opts *options) error {
 		return err
 	}
 
-	cfg, err := opts.Config.Load()
-	if err != nil {
-		return err
-	}
-
 	connection, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
 	if err != nil {
 		return err
@@ -149,21 +160,95 @@ func runDelete(opts *options) error {
 		return err
 	}
 
+	cmdutil.InvalidateCache(opts.Config, logger, cache.KafkasNamespace, cache.TopicsNamespace(response.GetId()), cache.ConsumerGroupsNamespace(response.GetId()))
+
 	logger.Info(opts.localizer.MustLocalize("kafka.delete.log.info.deleteSuccess", localize.NewEntry("Name", kafkaName)))
 
-	currentKafka := cfg.Services.Kafka
-	// this is not the current cluster, our work here is done
-	if currentKafka == nil || currentKafka.ClusterID != response.GetId() {
+	return opts.Config.Update(func(cfg *config.Config) error {
+		currentKafka := cfg.Services.Kafka
+		// this is not the current cluster, our work here is done
+		if currentKafka == nil || currentKafka.ClusterID != response.GetId() {
+			return nil
+		}
+
+		// the Kafka that was deleted is set as the user's current cluster
+		// since it was deleted it should be removed from the config
+		cfg.Services.Kafka = nil
 		return nil
+	})
+}
+
+// runBulkDelete deletes the Kafka instances selected by the bulk deletion flags
+func runBulkDelete(opts *options) error {
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := opts.Config.Load()
+	if err != nil {
+		return err
 	}
+	if err = opts.bulk.ResolveOwner(cfg, opts.localizer); err != nil {
+		return err
+	}
+
+	api := conn.API()
 
-	// the Kafka that was deleted is set as the user's current cluster
-	// since it was deleted it should be removed from the config
-	cfg.Services.Kafka = nil
-	err = opts.Config.Save(cfg)
+	kafkas, err := kafka.ListKafkas(context.Background(), api.Kafka())
 	if err != nil {
 		return err
 	}
 
-	return nil
+	resources := make([]bulk.Resource, len(kafkas))
+	for i, k := range kafkas {
+		resources[i] = bulk.Resource{
+			ID:        k.GetId(),
+			Name:      k.GetName(),
+			Owner:     k.GetOwner(),
+			CreatedAt: k.GetCreatedAt(),
+		}
+	}
+
+	deletion := &bulk.Deletion{
+		IO:        opts.IO,
+		Logger:    logger,
+		Localizer: opts.localizer,
+		Kind:      opts.localizer.MustLocalize("kafka.delete.bulk.kind"),
+		Resources: opts.bulk.Match(resources, time.Now()),
+		Force:     opts.force,
+		Delete: func(ctx context.Context, r bulk.Resource) error {
+			_, _, err := api.Kafka().DeleteKafkaById(ctx, r.ID).Async(true).Execute()
+			return err
+		},
+	}
+	deleted, err := deletion.Run()
+	if len(deleted) == 0 {
+		return err
+	}
+
+	namespaces := []string{cache.KafkasNamespace}
+	deletedIDs := map[string]bool{}
+	for _, r := range deleted {
+		namespaces = append(namespaces, cache.TopicsNamespace(r.ID), cache.ConsumerGroupsNamespace(r.ID))
+		deletedIDs[r.ID] = true
+	}
+	cmdutil.InvalidateCache(opts.Config, logger, namespaces...)
+
+	updateErr := opts.Config.Update(func(cfg *config.Config) error {
+		// the current Kafka instance is removed from the config when it was deleted
+		if cfg.Services.Kafka != nil && deletedIDs[cfg.Services.Kafka.ClusterID] {
+			cfg.Services.Kafka = nil
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return updateErr
 }
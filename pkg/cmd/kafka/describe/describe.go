@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 
+	"github.com/redhat-developer/app-services-cli/pkg/cache"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/kafka/flags"
 	flagutil "github.com/redhat-developer/app-services-cli/pkg/cmdutil/flags"
 	"github.com/redhat-developer/app-services-cli/pkg/connection"
 	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
@@ -26,6 +28,7 @@ type Options struct {
 	id           string
 	name         string
 	outputFormat string
+	cached       bool
 
 	IO         *iostreams.IOStreams
 	Config     config.IConfig
@@ -89,6 +92,7 @@ func NewDescribeCommand(f *factory.Factory) *cobra.Command {
 
 	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", "json", opts.localizer.MustLocalize("kafka.common.flag.output.description"))
 	cmd.Flags().StringVar(&opts.id, "id", "", opts.localizer.MustLocalize("kafka.describe.flag.id"))
+	cmd.Flags().BoolVar(&opts.cached, flags.FlagCached, false, opts.localizer.MustLocalize("kafka.common.flag.cached.description"))
 
 	flagutil.EnableOutputFlagCompletion(cmd)
 
@@ -96,6 +100,25 @@ func NewDescribeCommand(f *factory.Factory) *cobra.Command {
 }
 
 func runDescribe(opts *Options) error {
+	cfg, err := opts.Config.Load()
+	if err != nil {
+		return err
+	}
+
+	responseCache, err := cache.New(opts.Config)
+	if err != nil {
+		return err
+	}
+	cacheKey := cache.Key(cfg.APIUrl, "id", opts.id)
+	if opts.name != "" {
+		cacheKey = cache.Key(cfg.APIUrl, "name", opts.name)
+	}
+
+	var kafkaInstance *kafkamgmtclient.KafkaRequest
+	if opts.cached && responseCache.Get(cache.KafkasNamespace, cacheKey, &kafkaInstance) {
+		return printKafka(kafkaInstance, opts)
+	}
+
 	connection, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
 	if err != nil {
 		return err
@@ -103,7 +126,6 @@ func runDescribe(opts *Options) error {
 
 	api := connection.API()
 
-	var kafkaInstance *kafkamgmtclient.KafkaRequest
 	ctx := context.Background()
 	if opts.name != "" {
 		kafkaInstance, _, err = kafka.GetKafkaByName(ctx, api.Kafka(), opts.name)
@@ -117,6 +139,9 @@ func runDescribe(opts *Options) error {
 		}
 	}
 
+	// the response is cached even when --cached is not set, so the next run can use it
+	_ = responseCache.Set(cache.KafkasNamespace, cacheKey, kafkaInstance, cache.KafkasTTL)
+
 	return printKafka(kafkaInstance, opts)
 }
 
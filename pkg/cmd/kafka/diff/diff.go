@@ -0,0 +1,219 @@
+package diff
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/flag"
+	"github.com/redhat-developer/app-services-cli/pkg/cmdutil"
+	flagutil "github.com/redhat-developer/app-services-cli/pkg/cmdutil/flags"
+	"github.com/redhat-developer/app-services-cli/pkg/common/clierr"
+	"github.com/redhat-developer/app-services-cli/pkg/connection"
+	"github.com/redhat-developer/app-services-cli/pkg/dump"
+	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
+	"github.com/redhat-developer/app-services-cli/pkg/kafka"
+	kafkadiff "github.com/redhat-developer/app-services-cli/pkg/kafka/diff"
+	"github.com/redhat-developer/app-services-cli/pkg/kafka/kafkaerr"
+	"github.com/redhat-developer/app-services-cli/pkg/localize"
+	"github.com/redhat-developer/app-services-cli/pkg/logging"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type options struct {
+	instanceA    string
+	instanceB    string
+	configB      string
+	outputFormat string
+
+	IO            *iostreams.IOStreams
+	Connection    factory.ConnectionFunc
+	ConnectionFor func(cfg config.IConfig) factory.ConnectionFunc
+	Logger        func() (logging.Logger, error)
+	localizer     localize.Localizer
+}
+
+// instance identifies a compared Kafka instance
+type instance struct {
+	ID   string `json:"id" yaml:"id"`
+	Name string `json:"name" yaml:"name"`
+}
+
+// report is the result of the comparison printed as JSON or YAML
+type report struct {
+	A           instance               `json:"a" yaml:"a"`
+	B           instance               `json:"b" yaml:"b"`
+	Equal       bool                   `json:"equal" yaml:"equal"`
+	Differences []kafkadiff.Difference `json:"differences" yaml:"differences"`
+}
+
+// NewDiffCommand creates a new command to compare two Kafka instances
+func NewDiffCommand(f *factory.Factory) *cobra.Command {
+	opts := &options{
+		IO:            f.IOStreams,
+		Connection:    f.Connection,
+		ConnectionFor: f.ConnectionFor,
+		Logger:        f.Logger,
+		localizer:     f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.MustLocalize("kafka.diff.cmd.use"),
+		Short:   opts.localizer.MustLocalize("kafka.diff.cmd.shortDescription"),
+		Long:    opts.localizer.MustLocalize("kafka.diff.cmd.longDescription"),
+		Example: opts.localizer.MustLocalize("kafka.diff.cmd.example"),
+		Args:    cobra.ExactArgs(2),
+		// Dynamic completion of the Kafka names
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 && opts.configB != "" {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return cmdutil.FilterValidKafkas(f, toComplete)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.outputFormat != "" && !flagutil.IsValidInput(opts.outputFormat, flagutil.ValidOutputFormats...) {
+				return flag.InvalidValueError("output", opts.outputFormat, flagutil.ValidOutputFormats...)
+			}
+
+			opts.instanceA = args[0]
+			opts.instanceB = args[1]
+
+			return runDiff(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.configB, "config-b", "", opts.localizer.MustLocalize("kafka.diff.flag.configB.description"))
+	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", "", opts.localizer.MustLocalize("kafka.diff.flag.output.description"))
+
+	flagutil.EnableOutputFlagCompletion(cmd)
+
+	return cmd
+}
+
+func runDiff(opts *options) error {
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	connA, err := opts.Connection(connection.DefaultConfigRequireMasAuth)
+	if err != nil {
+		return err
+	}
+
+	// the second instance is in the same environment, unless the config file of another environment is set
+	connB := connA
+	if opts.configB != "" {
+		if _, err = os.Stat(opts.configB); err != nil {
+			return clierr.New(clierr.CodeValidation, errors.New(opts.localizer.MustLocalize("kafka.diff.error.configBNotFound",
+				localize.NewEntry("Path", opts.configB),
+				localize.NewEntry("ErrorMessage", err),
+			)))
+		}
+		connB, err = opts.ConnectionFor(config.NewFileAt(opts.configB))(connection.DefaultConfigRequireMasAuth)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx := context.Background()
+
+	kafkaA, layoutA, err := fetchLayout(ctx, connA, opts.instanceA, opts.localizer)
+	if err != nil {
+		return err
+	}
+	kafkaB, layoutB, err := fetchLayout(ctx, connB, opts.instanceB, opts.localizer)
+	if err != nil {
+		return err
+	}
+
+	differences := kafkadiff.Compare(layoutA, layoutB)
+
+	r := report{
+		A:           instance{ID: kafkaA.GetId(), Name: kafkaA.GetName()},
+		B:           instance{ID: kafkaB.GetId(), Name: kafkaB.GetName()},
+		Equal:       len(differences) == 0,
+		Differences: differences,
+	}
+
+	switch opts.outputFormat {
+	case dump.JSONFormat:
+		data, _ := json.MarshalIndent(r, "", cmdutil.DefaultJSONIndent)
+		_ = dump.JSON(opts.IO.Out, data)
+	case dump.YAMLFormat, dump.YMLFormat:
+		data, _ := yaml.Marshal(r)
+		_ = dump.YAML(opts.IO.Out, data)
+	default:
+		if r.Equal {
+			logger.Info(opts.localizer.MustLocalize("kafka.diff.log.info.noDifferences",
+				localize.NewEntry("NameA", r.A.Name),
+				localize.NewEntry("NameB", r.B.Name),
+			))
+			return nil
+		}
+		if err = kafkadiff.WriteReport(opts.IO.Out, label(r.A), label(r.B), differences); err != nil {
+			return err
+		}
+	}
+
+	if r.Equal {
+		return nil
+	}
+
+	// like diff(1), the differences are reported through the exit code so scripts can detect the drift
+	return clierr.New(clierr.CodeDifferences, errors.New(opts.localizer.MustLocalize("kafka.diff.error.differences",
+		localize.NewEntry("NameA", r.A.Name),
+		localize.NewEntry("NameB", r.B.Name),
+		localize.NewEntry("Count", len(differences)),
+	)))
+}
+
+// fetchLayout resolves the Kafka instance by its ID or its name, and returns the layout of its topics and consumer groups
+func fetchLayout(ctx context.Context, conn connection.Connection, idOrName string, localizer localize.Localizer) (*kafkamgmtclient.KafkaRequest, *kafkadiff.Layout, error) {
+	kafkaInstance, err := resolveKafka(ctx, conn.API().Kafka(), idOrName, localizer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	api, _, err := conn.API().KafkaAdmin(kafkaInstance.GetId())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	layout, err := kafkadiff.Fetch(ctx, api)
+	if err != nil {
+		return nil, nil, err
+	}
+	return kafkaInstance, layout, nil
+}
+
+// resolveKafka returns the Kafka instance with the given ID, or else with the given name
+func resolveKafka(ctx context.Context, api kafkamgmtclient.DefaultApi, idOrName string, localizer localize.Localizer) (*kafkamgmtclient.KafkaRequest, error) {
+	kafkaInstance, _, err := kafka.GetKafkaByID(ctx, api, idOrName)
+	if err == nil {
+		return kafkaInstance, nil
+	}
+	if !errors.Is(err, kafkaerr.NotFoundByIDErr) {
+		return nil, err
+	}
+
+	kafkaInstance, _, err = kafka.GetKafkaByName(ctx, api, idOrName)
+	if err == nil {
+		return kafkaInstance, nil
+	}
+	if errors.Is(err, kafkaerr.NotFoundByNameErr) {
+		return nil, clierr.New(clierr.CodeNotFound, errors.New(localizer.MustLocalize("kafka.diff.error.notFound", localize.NewEntry("Instance", idOrName))))
+	}
+	return nil, err
+}
+
+// label returns the label of the instance in the report
+func label(i instance) string {
+	return fmt.Sprintf("%v (%v)", i.Name, i.ID)
+}
@@ -5,4 +5,6 @@ const (
 	FlagProvider = "provider"
 	// FlagRegion is a flag representing an OCM region ID
 	FlagRegion = "region"
+	// FlagCached is a flag to read the response from the cache when it was fetched recently
+	FlagCached = "cached"
 )
@@ -11,7 +11,11 @@ import (
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/kafka/create"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/kafka/delete"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/kafka/describe"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/kafka/diff"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/kafka/list"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/kafka/metrics"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/kafka/providers"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/kafka/regions"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/kafka/use"
 )
 
@@ -31,6 +35,10 @@ func NewKafkaCommand(f *factory.Factory) *cobra.Command {
 		use.NewUseCommand(f),
 		topic.NewTopicCommand(f),
 		consumergroup.NewConsumerGroupCommand(f),
+		providers.NewProvidersCommand(f),
+		regions.NewRegionsCommand(f),
+		metrics.NewMetricsCommand(f),
+		diff.NewDiffCommand(f),
 	)
 
 	return cmd
@@ -3,9 +3,15 @@ package list
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/redhat-developer/app-services-cli/pkg/cache"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/kafka/flags"
+	"github.com/redhat-developer/app-services-cli/pkg/cmdutil"
 	flagutil "github.com/redhat-developer/app-services-cli/pkg/cmdutil/flags"
 	"github.com/redhat-developer/app-services-cli/pkg/connection"
 	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
@@ -40,6 +46,14 @@ type options struct {
 	page         int
 	limit        int
 	search       string
+	filter       string
+	owner        string
+	status       string
+	createdAfter string
+	cached       bool
+
+	conditions       []kafka.Condition
+	createdAfterTime *time.Time
 
 	IO         *iostreams.IOStreams
 	Config     config.IConfig
@@ -62,10 +76,11 @@ func NewListCommand(f *factory.Factory) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:   opts.localizer.MustLocalize("kafka.list.cmd.use"),
-		Short: opts.localizer.MustLocalize("kafka.list.cmd.shortDescription"),
-		Long:  opts.localizer.MustLocalize("kafka.list.cmd.longDescription"),
-		Args:  cobra.NoArgs,
+		Use:     opts.localizer.MustLocalize("kafka.list.cmd.use"),
+		Short:   opts.localizer.MustLocalize("kafka.list.cmd.shortDescription"),
+		Long:    opts.localizer.MustLocalize("kafka.list.cmd.longDescription"),
+		Example: opts.localizer.MustLocalize("kafka.list.cmd.example"),
+		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if opts.outputFormat != "" && !flagutil.IsValidInput(opts.outputFormat, flagutil.ValidOutputFormats...) {
 				return flag.InvalidValueError("output", opts.outputFormat, flagutil.ValidOutputFormats...)
@@ -79,6 +94,10 @@ func NewListCommand(f *factory.Factory) *cobra.Command {
 				return err
 			}
 
+			if err := parseFilters(opts); err != nil {
+				return err
+			}
+
 			return runList(opts)
 		},
 	}
@@ -87,39 +106,158 @@ func NewListCommand(f *factory.Factory) *cobra.Command {
 	cmd.Flags().IntVarP(&opts.page, "page", "", 0, opts.localizer.MustLocalize("kafka.list.flag.page"))
 	cmd.Flags().IntVarP(&opts.limit, "limit", "", 100, opts.localizer.MustLocalize("kafka.list.flag.limit"))
 	cmd.Flags().StringVarP(&opts.search, "search", "", "", opts.localizer.MustLocalize("kafka.list.flag.search"))
+	cmd.Flags().StringVar(&opts.filter, "filter", "", opts.localizer.MustLocalize("kafka.list.flag.filter", localize.NewEntry("Fields", strings.Join(kafka.SearchFields, ", "))))
+	cmd.Flags().StringVar(&opts.owner, "owner", "", opts.localizer.MustLocalize("kafka.list.flag.owner"))
+	cmd.Flags().StringVar(&opts.status, "status", "", opts.localizer.MustLocalize("kafka.list.flag.status"))
+	cmd.Flags().StringVar(&opts.createdAfter, "created-after", "", opts.localizer.MustLocalize("kafka.list.flag.createdAfter"))
+	cmd.Flags().BoolVar(&opts.cached, flags.FlagCached, false, opts.localizer.MustLocalize("kafka.common.flag.cached.description"))
 
 	flagutil.EnableOutputFlagCompletion(cmd)
 
+	_ = cmd.RegisterFlagCompletionFunc("status", func(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return kafka.Statuses, cobra.ShellCompDirectiveNoSpace
+	})
+
 	return cmd
 }
 
+// parseFilters parses and validates the --filter, --owner, --status and --created-after flags
+func parseFilters(opts *options) (err error) {
+	if opts.conditions, err = kafka.ParseFilter(opts.filter); err != nil {
+		return err
+	}
+	for _, c := range []kafka.Condition{{Field: "owner", Value: opts.owner}, {Field: "status", Value: opts.status}} {
+		if c.Value == "" {
+			continue
+		}
+		if err = c.Validate(); err != nil {
+			return err
+		}
+		opts.conditions = append(opts.conditions, c)
+	}
+
+	// the structured filters are combined with "and", so they cannot be mixed with the "or" of --search
+	if opts.search != "" && len(opts.conditions) > 0 {
+		return errors.New(opts.localizer.MustLocalize("kafka.list.error.searchWithFilter"))
+	}
+
+	if opts.createdAfter != "" {
+		t, err := parseTime(opts.createdAfter)
+		if err != nil {
+			return errors.New(opts.localizer.MustLocalize("kafka.list.error.invalidCreatedAfter", localize.NewEntry("Value", opts.createdAfter)))
+		}
+		opts.createdAfterTime = &t
+	}
+
+	return nil
+}
+
+// parseTime parses a time in the RFC 3339 format, or a date in the YYYY-MM-DD format
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02", value, time.Local)
+}
+
 func runList(opts *options) error {
 	logger, err := opts.Logger()
 	if err != nil {
 		return err
 	}
 
-	connection, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
+	// the connection is only opened when it is needed, so that --cached can work offline
+	var conn connection.Connection
+	connect := func() (connection.Connection, error) {
+		if conn == nil {
+			conn, err = opts.Connection(connection.DefaultConfigSkipMasAuth)
+		}
+		return conn, err
+	}
+
+	if hasOwnerMe(opts.conditions) {
+		if _, err = connect(); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := opts.Config.Load()
 	if err != nil {
 		return err
 	}
 
-	api := connection.API()
-
-	a := api.Kafka().GetKafkas(context.Background())
-	a = a.Page(strconv.Itoa(opts.page))
-	a = a.Size(strconv.Itoa(opts.limit))
+	if err = resolveOwners(cfg, opts); err != nil {
+		return err
+	}
 
+	var query string
 	if opts.search != "" {
-		query := buildQuery(opts.search)
+		query = buildQuery(opts.search)
+	} else if len(opts.conditions) > 0 {
+		query = kafka.BuildSearch(opts.conditions)
+	}
+	if query != "" {
 		logger.Debug(opts.localizer.MustLocalize("kafka.list.log.debug.filteringKafkaList", localize.NewEntry("Search", query)))
-		a = a.Search(query)
 	}
 
-	response, _, err := a.Execute()
+	// the search of the management API does not support the creation time, so every page of the matching
+	// Kafka instances is fetched when --created-after is set, and they are filtered and paginated here
+	allPages := opts.createdAfterTime != nil
+
+	responseCache, err := cache.New(opts.Config)
 	if err != nil {
 		return err
 	}
+	cacheKey := cache.Key(cfg.APIUrl, "list", strconv.Itoa(opts.page), strconv.Itoa(opts.limit), query)
+	if allPages {
+		cacheKey = cache.Key(cfg.APIUrl, "list", "all", query)
+	}
+
+	var response kafkamgmtclient.KafkaRequestList
+	if opts.cached && responseCache.Get(cache.KafkasNamespace, cacheKey, &response) {
+		logger.Debug(opts.localizer.MustLocalize("kafka.common.log.debug.usingCachedResponse"))
+	} else {
+		conn, err := connect()
+		if err != nil {
+			return err
+		}
+
+		api := conn.API()
+
+		if allPages {
+			items, err := kafka.SearchKafkas(context.Background(), api.Kafka(), query)
+			if err != nil {
+				return err
+			}
+			response = kafkamgmtclient.KafkaRequestList{
+				Kind:  "KafkaRequestList",
+				Page:  1,
+				Size:  int32(len(items)),
+				Total: int32(len(items)),
+				Items: items,
+			}
+		} else {
+			a := api.Kafka().GetKafkas(context.Background())
+			a = a.Page(strconv.Itoa(opts.page))
+			a = a.Size(strconv.Itoa(opts.limit))
+
+			if query != "" {
+				a = a.Search(query)
+			}
+
+			response, _, err = a.Execute()
+			if err != nil {
+				return err
+			}
+		}
+
+		// the response is cached even when --cached is not set, so the next run can use it
+		_ = responseCache.Set(cache.KafkasNamespace, cacheKey, response, cache.KafkasTTL)
+	}
+
+	if allPages {
+		response = pageCreatedAfter(response.Items, *opts.createdAfterTime, opts.page, opts.limit)
+	}
 
 	if response.Size == 0 && opts.outputFormat == "" {
 		logger.Info(opts.localizer.MustLocalize("kafka.common.log.info.noKafkaInstances"))
@@ -142,6 +280,31 @@ func runList(opts *options) error {
 	return nil
 }
 
+// hasOwnerMe returns true if a condition is on the owner "me"
+func hasOwnerMe(conditions []kafka.Condition) bool {
+	for _, c := range conditions {
+		if c.Field == "owner" && c.Value == cmdutil.OwnerMe {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveOwners replaces the owner "me" in the conditions with the username of the current user
+func resolveOwners(cfg *config.Config, opts *options) error {
+	for i, c := range opts.conditions {
+		if c.Field != "owner" {
+			continue
+		}
+		owner, ok := cmdutil.ResolveOwner(cfg, c.Value)
+		if !ok {
+			return errors.New(opts.localizer.MustLocalize("kafka.list.error.unknownCurrentUser"))
+		}
+		opts.conditions[i].Value = owner
+	}
+	return nil
+}
+
 func mapResponseItemsToRows(kafkas []kafkamgmtclient.KafkaRequest) []kafkaRow {
 	rows := []kafkaRow{}
 
@@ -169,3 +332,35 @@ func buildQuery(search string) string {
 
 	return queryString
 }
+
+// pageCreatedAfter returns the requested page of the Kafka instances created after the time.
+// Pages start at 1, like in the management API
+func pageCreatedAfter(kafkas []kafkamgmtclient.KafkaRequest, t time.Time, page int, limit int) kafkamgmtclient.KafkaRequestList {
+	filtered := []kafkamgmtclient.KafkaRequest{}
+	for _, k := range kafkas {
+		if k.GetCreatedAt().After(t) {
+			filtered = append(filtered, k)
+		}
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	start := (page - 1) * limit
+	if start > len(filtered) {
+		start = len(filtered)
+	}
+	end := start + limit
+	if end > len(filtered) || limit < 1 {
+		end = len(filtered)
+	}
+	items := filtered[start:end]
+
+	return kafkamgmtclient.KafkaRequestList{
+		Kind:  "KafkaRequestList",
+		Page:  int32(page),
+		Size:  int32(len(items)),
+		Total: int32(len(filtered)),
+		Items: items,
+	}
+}
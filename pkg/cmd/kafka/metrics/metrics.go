@@ -0,0 +1,337 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/flag"
+	"github.com/redhat-developer/app-services-cli/pkg/cmdutil"
+	flagutil "github.com/redhat-developer/app-services-cli/pkg/cmdutil/flags"
+	"github.com/redhat-developer/app-services-cli/pkg/common/clierr"
+	"github.com/redhat-developer/app-services-cli/pkg/connection"
+	"github.com/redhat-developer/app-services-cli/pkg/dump"
+	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
+	"github.com/redhat-developer/app-services-cli/pkg/kafka"
+	kafkametrics "github.com/redhat-developer/app-services-cli/pkg/kafka/metrics"
+	"github.com/redhat-developer/app-services-cli/pkg/localize"
+	"github.com/redhat-developer/app-services-cli/pkg/logging"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// PrometheusFormat prints the metrics in the Prometheus text exposition format
+const PrometheusFormat = "prometheus"
+
+// outputFormats are the formats of the metrics, which are printed as a table by default
+var outputFormats = []string{dump.JSONFormat, dump.YAMLFormat, dump.YMLFormat, PrometheusFormat}
+
+type options struct {
+	id           string
+	name         string
+	outputFormat string
+	timeRange    time.Duration
+	step         time.Duration
+
+	IO         *iostreams.IOStreams
+	Config     config.IConfig
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	localizer  localize.Localizer
+}
+
+// summaryRow contains the properties used to populate the metrics of a Kafka instance into a table row
+type summaryRow struct {
+	Metric string `header:"Metric"`
+	Value  string `header:"Value"`
+}
+
+// rangeRow contains the properties used to populate the metrics at a point in time into a table row
+type rangeRow struct {
+	Time         string `header:"Time"`
+	BytesIn      string `header:"Bytes In"`
+	BytesOut     string `header:"Bytes Out"`
+	Partitions   string `header:"Partitions"`
+	StorageUsed  string `header:"Storage Used"`
+	StorageLimit string `header:"Storage Limit"`
+	Connections  string `header:"Connections"`
+}
+
+// topicRow contains the properties used to populate the messages of a topic into a table row
+type topicRow struct {
+	Topic    string `header:"Topic"`
+	Messages string `header:"Messages"`
+}
+
+// rangeTopicRow contains the properties used to populate the messages of a topic at a point in time into a table row
+type rangeTopicRow struct {
+	Time     string `header:"Time"`
+	Topic    string `header:"Topic"`
+	Messages string `header:"Messages"`
+}
+
+// NewMetricsCommand creates a new command to view the metrics of a Kafka instance
+func NewMetricsCommand(f *factory.Factory) *cobra.Command {
+	opts := &options{
+		IO:         f.IOStreams,
+		Config:     f.Config,
+		Connection: f.Connection,
+		Logger:     f.Logger,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.MustLocalize("kafka.metrics.cmd.use"),
+		Short:   opts.localizer.MustLocalize("kafka.metrics.cmd.shortDescription"),
+		Long:    opts.localizer.MustLocalize("kafka.metrics.cmd.longDescription"),
+		Example: opts.localizer.MustLocalize("kafka.metrics.cmd.example"),
+		Args:    cobra.RangeArgs(0, 1),
+		// Dynamic completion of the Kafka name
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return cmdutil.FilterValidKafkas(f, toComplete)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.outputFormat != "" && !flagutil.IsValidInput(opts.outputFormat, outputFormats...) {
+				return flag.InvalidValueError("output", opts.outputFormat, outputFormats...)
+			}
+
+			if len(args) > 0 {
+				opts.name = args[0]
+			}
+
+			if opts.name != "" && opts.id != "" {
+				return errors.New(opts.localizer.MustLocalize("service.error.idAndNameCannotBeUsed"))
+			}
+
+			if err := validateRange(opts, cmd.Flags().Changed("step")); err != nil {
+				return err
+			}
+
+			if opts.id != "" || opts.name != "" {
+				return runMetrics(opts)
+			}
+
+			cfg, err := opts.Config.Load()
+			if err != nil {
+				return err
+			}
+
+			var kafkaConfig *config.KafkaConfig
+			if cfg.Services.Kafka == kafkaConfig || cfg.Services.Kafka.ClusterID == "" {
+				return errors.New(opts.localizer.MustLocalize("kafka.common.error.noKafkaSelected"))
+			}
+
+			opts.id = cfg.Services.Kafka.ClusterID
+
+			return runMetrics(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.id, "id", "", opts.localizer.MustLocalize("kafka.metrics.flag.id"))
+	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", "", opts.localizer.MustLocalize("kafka.metrics.flag.output.description"))
+	cmd.Flags().DurationVar(&opts.timeRange, "range", 0, opts.localizer.MustLocalize("kafka.metrics.flag.range.description"))
+	cmd.Flags().DurationVar(&opts.step, "step", time.Minute, opts.localizer.MustLocalize("kafka.metrics.flag.step.description"))
+
+	_ = cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return outputFormats, cobra.ShellCompDirectiveNoSpace
+	})
+
+	return cmd
+}
+
+// validateRange checks that the range and the step can be sent to the API,
+// which takes the range in minutes and the step in seconds
+func validateRange(opts *options, stepChanged bool) error {
+	if opts.timeRange == 0 {
+		if stepChanged {
+			return clierr.New(clierr.CodeValidation, errors.New(opts.localizer.MustLocalize("kafka.metrics.error.stepWithoutRange")))
+		}
+		return nil
+	}
+
+	if opts.timeRange < kafkametrics.MinRange || opts.timeRange > kafkametrics.MaxRange || opts.timeRange%time.Minute != 0 {
+		return clierr.New(clierr.CodeValidation, errors.New(opts.localizer.MustLocalize("kafka.metrics.error.invalidRange",
+			localize.NewEntry("Range", opts.timeRange),
+			localize.NewEntry("Min", kafkametrics.MinRange),
+			localize.NewEntry("Max", kafkametrics.MaxRange),
+		)))
+	}
+	if opts.step < kafkametrics.MinStep || opts.step > kafkametrics.MaxStep || opts.step%time.Second != 0 {
+		return clierr.New(clierr.CodeValidation, errors.New(opts.localizer.MustLocalize("kafka.metrics.error.invalidStep",
+			localize.NewEntry("Step", opts.step),
+			localize.NewEntry("Min", kafkametrics.MinStep),
+			localize.NewEntry("Max", kafkametrics.MaxStep),
+		)))
+	}
+	if opts.step > opts.timeRange {
+		return clierr.New(clierr.CodeValidation, errors.New(opts.localizer.MustLocalize("kafka.metrics.error.stepLongerThanRange",
+			localize.NewEntry("Step", opts.step),
+			localize.NewEntry("Range", opts.timeRange),
+		)))
+	}
+	return nil
+}
+
+func runMetrics(opts *options) error {
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
+	if err != nil {
+		return err
+	}
+
+	api := conn.API()
+
+	ctx := context.Background()
+	if opts.name != "" {
+		kafkaInstance, _, err := kafka.GetKafkaByName(ctx, api.Kafka(), opts.name)
+		if err != nil {
+			return err
+		}
+		opts.id = kafkaInstance.GetId()
+	}
+
+	var samples []kafkametrics.Sample
+	if opts.timeRange == 0 {
+		samples, err = kafkametrics.Query(ctx, api.Kafka(), opts.id)
+	} else {
+		samples, err = kafkametrics.QueryRange(ctx, api.Kafka(), opts.id, opts.timeRange, opts.step)
+	}
+	if err != nil {
+		return err
+	}
+
+	if opts.outputFormat == PrometheusFormat {
+		return kafkametrics.WritePrometheus(opts.IO.Out, samples)
+	}
+
+	// the current values are a single summary, while the range is summarised at each step
+	var summaries interface{}
+	if opts.timeRange == 0 {
+		summaries = kafkametrics.Total(samples)
+	} else {
+		summaries = kafkametrics.Summarize(samples)
+	}
+
+	switch opts.outputFormat {
+	case dump.JSONFormat:
+		data, _ := json.MarshalIndent(summaries, "", cmdutil.DefaultJSONIndent)
+		_ = dump.JSON(opts.IO.Out, data)
+	case dump.YAMLFormat, dump.YMLFormat:
+		data, _ := yaml.Marshal(summaries)
+		_ = dump.YAML(opts.IO.Out, data)
+	default:
+		if len(samples) == 0 {
+			logger.Info(opts.localizer.MustLocalize("kafka.metrics.log.info.noMetrics"))
+			return nil
+		}
+		printTables(opts, summaries)
+	}
+
+	return nil
+}
+
+func printTables(opts *options, summaries interface{}) {
+	switch s := summaries.(type) {
+	case kafkametrics.Summary:
+		dump.Table(opts.IO.Out, mapSummaryToRows(s, opts.localizer))
+		if len(s.Topics) > 0 {
+			fmt.Fprintln(opts.IO.Out)
+			dump.Table(opts.IO.Out, mapTopicsToRows(s))
+		}
+	case []kafkametrics.Summary:
+		rows := make([]rangeRow, len(s))
+		for i, summary := range s {
+			rows[i] = rangeRow{
+				Time:         formatTime(summary.Timestamp),
+				BytesIn:      formatBytes(summary.BytesIn),
+				BytesOut:     formatBytes(summary.BytesOut),
+				Partitions:   formatNumber(summary.Partitions),
+				StorageUsed:  formatBytes(summary.StorageUsed),
+				StorageLimit: formatBytes(summary.StorageLimit),
+				Connections:  formatNumber(summary.Connections),
+			}
+		}
+		dump.Table(opts.IO.Out, rows)
+		if topics := mapRangeTopicsToRows(s); len(topics) > 0 {
+			fmt.Fprintln(opts.IO.Out)
+			dump.Table(opts.IO.Out, topics)
+		}
+	}
+}
+
+func mapSummaryToRows(s kafkametrics.Summary, localizer localize.Localizer) []summaryRow {
+	return []summaryRow{
+		{Metric: localizer.MustLocalize("kafka.metrics.metric.bytesIn"), Value: formatBytes(s.BytesIn)},
+		{Metric: localizer.MustLocalize("kafka.metrics.metric.bytesOut"), Value: formatBytes(s.BytesOut)},
+		{Metric: localizer.MustLocalize("kafka.metrics.metric.partitions"), Value: formatNumber(s.Partitions)},
+		{Metric: localizer.MustLocalize("kafka.metrics.metric.storageUsed"), Value: formatStorage(s.StorageUsed, s.StorageLimit)},
+		{Metric: localizer.MustLocalize("kafka.metrics.metric.storageLimit"), Value: formatBytes(s.StorageLimit)},
+		{Metric: localizer.MustLocalize("kafka.metrics.metric.connections"), Value: formatNumber(s.Connections)},
+	}
+}
+
+func mapTopicsToRows(s kafkametrics.Summary) []topicRow {
+	rows := make([]topicRow, len(s.Topics))
+	for i, t := range s.Topics {
+		rows[i] = topicRow{Topic: t.Topic, Messages: formatNumber(t.Messages)}
+	}
+	return rows
+}
+
+func mapRangeTopicsToRows(summaries []kafkametrics.Summary) []rangeTopicRow {
+	rows := []rangeTopicRow{}
+	for _, s := range summaries {
+		for _, t := range s.Topics {
+			rows = append(rows, rangeTopicRow{
+				Time:     formatTime(s.Timestamp),
+				Topic:    t.Topic,
+				Messages: formatNumber(t.Messages),
+			})
+		}
+	}
+	return rows
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// formatStorage returns the storage used along with the percentage of the limit, if any
+func formatStorage(used float64, limit float64) string {
+	if limit <= 0 {
+		return formatBytes(used)
+	}
+	return fmt.Sprintf("%v (%.1f%%)", formatBytes(used), used/limit*100)
+}
+
+// formatBytes returns the number of bytes in binary units, such as "1.5 GiB"
+func formatBytes(v float64) string {
+	const unit = 1024
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB"}
+
+	i := 0
+	for v >= unit && i < len(units)-1 {
+		v /= unit
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%v %v", formatNumber(v), units[i])
+	}
+	return fmt.Sprintf("%.1f %v", v, units[i])
+}
@@ -0,0 +1,117 @@
+package list
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/flag"
+	"github.com/redhat-developer/app-services-cli/pkg/cmdutil"
+	flagutil "github.com/redhat-developer/app-services-cli/pkg/cmdutil/flags"
+	"github.com/redhat-developer/app-services-cli/pkg/connection"
+	"github.com/redhat-developer/app-services-cli/pkg/dump"
+	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
+	"github.com/redhat-developer/app-services-cli/pkg/localize"
+	"github.com/redhat-developer/app-services-cli/pkg/logging"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type options struct {
+	outputFormat string
+
+	IO         *iostreams.IOStreams
+	Config     config.IConfig
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	localizer  localize.Localizer
+}
+
+// providerRow contains the properties used to populate the list of cloud providers into a table row
+type providerRow struct {
+	ID          string `header:"ID"`
+	Name        string `header:"Name"`
+	DisplayName string `header:"Display Name"`
+	Enabled     string `header:"Enabled"`
+}
+
+// NewListCommand creates a new command to list the cloud providers of Kafka instances
+func NewListCommand(f *factory.Factory) *cobra.Command {
+	opts := &options{
+		IO:         f.IOStreams,
+		Config:     f.Config,
+		Connection: f.Connection,
+		Logger:     f.Logger,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.MustLocalize("kafka.providers.list.cmd.use"),
+		Short:   opts.localizer.MustLocalize("kafka.providers.list.cmd.shortDescription"),
+		Long:    opts.localizer.MustLocalize("kafka.providers.list.cmd.longDescription"),
+		Example: opts.localizer.MustLocalize("kafka.providers.list.cmd.example"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if opts.outputFormat != "" && !flagutil.IsValidInput(opts.outputFormat, flagutil.ValidOutputFormats...) {
+				return flag.InvalidValueError("output", opts.outputFormat, flagutil.ValidOutputFormats...)
+			}
+
+			return runList(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", "", opts.localizer.MustLocalize("kafka.providers.list.flag.output.description"))
+
+	flagutil.EnableOutputFlagCompletion(cmd)
+
+	return cmd
+}
+
+func runList(opts *options) error {
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
+	if err != nil {
+		return err
+	}
+
+	cloudProviders, err := cmdutil.GetCloudProviders(opts.Config, conn)
+	if err != nil {
+		return err
+	}
+
+	switch opts.outputFormat {
+	case dump.JSONFormat:
+		data, _ := json.MarshalIndent(cloudProviders, "", cmdutil.DefaultJSONIndent)
+		_ = dump.JSON(opts.IO.Out, data)
+	case dump.YAMLFormat, dump.YMLFormat:
+		data, _ := yaml.Marshal(cloudProviders)
+		_ = dump.YAML(opts.IO.Out, data)
+	default:
+		if len(cloudProviders) == 0 {
+			logger.Info(opts.localizer.MustLocalize("kafka.providers.list.log.info.noProviders"))
+			return nil
+		}
+		dump.Table(opts.IO.Out, mapProvidersToRows(cloudProviders))
+	}
+
+	return nil
+}
+
+func mapProvidersToRows(cloudProviders []kafkamgmtclient.CloudProvider) []providerRow {
+	rows := make([]providerRow, len(cloudProviders))
+	for i, p := range cloudProviders {
+		rows[i] = providerRow{
+			ID:          p.GetId(),
+			Name:        p.GetName(),
+			DisplayName: p.GetDisplayName(),
+			Enabled:     strconv.FormatBool(p.GetEnabled()),
+		}
+	}
+	return rows
+}
@@ -0,0 +1,23 @@
+package providers
+
+import (
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/kafka/providers/list"
+	"github.com/spf13/cobra"
+)
+
+// NewProvidersCommand creates a new command sub-group for the cloud providers of Kafka instances
+func NewProvidersCommand(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   f.Localizer.MustLocalize("kafka.providers.cmd.use"),
+		Short: f.Localizer.MustLocalize("kafka.providers.cmd.shortDescription"),
+		Long:  f.Localizer.MustLocalize("kafka.providers.cmd.longDescription"),
+		Args:  cobra.ExactArgs(1),
+	}
+
+	cmd.AddCommand(
+		list.NewListCommand(f),
+	)
+
+	return cmd
+}
@@ -0,0 +1,156 @@
+package list
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/cloudprovider/cloudproviderutil"
+	"github.com/redhat-developer/app-services-cli/pkg/cloudregion/cloudregionutil"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/flag"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/kafka/flags"
+	"github.com/redhat-developer/app-services-cli/pkg/cmdutil"
+	flagutil "github.com/redhat-developer/app-services-cli/pkg/cmdutil/flags"
+	"github.com/redhat-developer/app-services-cli/pkg/connection"
+	"github.com/redhat-developer/app-services-cli/pkg/dump"
+	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
+	"github.com/redhat-developer/app-services-cli/pkg/localize"
+	"github.com/redhat-developer/app-services-cli/pkg/logging"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+const defaultProvider = "aws"
+
+type options struct {
+	provider     string
+	outputFormat string
+
+	IO         *iostreams.IOStreams
+	Config     config.IConfig
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	localizer  localize.Localizer
+}
+
+// region is a region along with whether Kafka instances are deployed across multiple availability zones in it
+type region struct {
+	cloudregionutil.Region `yaml:",inline"`
+	MultiAZ                bool `json:"multi_az" yaml:"multi_az"`
+}
+
+// regionRow contains the properties used to populate the list of regions into a table row
+type regionRow struct {
+	ID            string `header:"ID"`
+	DisplayName   string `header:"Name"`
+	Enabled       string `header:"Enabled"`
+	InstanceTypes string `header:"Instance Types"`
+	MultiAZ       string `header:"Multi-AZ"`
+}
+
+// NewListCommand creates a new command to list the regions of a cloud provider
+func NewListCommand(f *factory.Factory) *cobra.Command {
+	opts := &options{
+		IO:         f.IOStreams,
+		Config:     f.Config,
+		Connection: f.Connection,
+		Logger:     f.Logger,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.MustLocalize("kafka.regions.list.cmd.use"),
+		Short:   opts.localizer.MustLocalize("kafka.regions.list.cmd.shortDescription"),
+		Long:    opts.localizer.MustLocalize("kafka.regions.list.cmd.longDescription"),
+		Example: opts.localizer.MustLocalize("kafka.regions.list.cmd.example"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if opts.outputFormat != "" && !flagutil.IsValidInput(opts.outputFormat, flagutil.ValidOutputFormats...) {
+				return flag.InvalidValueError("output", opts.outputFormat, flagutil.ValidOutputFormats...)
+			}
+
+			return runList(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.provider, flags.FlagProvider, defaultProvider, opts.localizer.MustLocalize("kafka.regions.list.flag.provider.description"))
+	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", "", opts.localizer.MustLocalize("kafka.regions.list.flag.output.description"))
+
+	_ = cmd.RegisterFlagCompletionFunc(flags.FlagProvider, func(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return cmdutil.FetchCloudProviders(f)
+	})
+
+	flagutil.EnableOutputFlagCompletion(cmd)
+
+	return cmd
+}
+
+func runList(opts *options) error {
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
+	if err != nil {
+		return err
+	}
+
+	cloudProviders, err := cmdutil.GetCloudProviders(opts.Config, conn)
+	if err != nil {
+		return err
+	}
+
+	// the regions of disabled cloud providers are listed too, so that their status can be checked
+	cloudProvider := cloudproviderutil.FindByName(cloudProviders, opts.provider)
+	if cloudProvider == nil {
+		providerNames := make([]string, len(cloudProviders))
+		for i, p := range cloudProviders {
+			providerNames[i] = p.GetName()
+		}
+		return flag.InvalidValueWithSuggestionError(opts.localizer, flags.FlagProvider, opts.provider, providerNames...)
+	}
+
+	cloudRegions, err := cmdutil.GetCloudRegions(opts.Config, conn, cloudProvider.GetId())
+	if err != nil {
+		return err
+	}
+
+	regions := make([]region, len(cloudRegions))
+	for i, r := range cloudRegions {
+		regions[i] = region{Region: r, MultiAZ: r.MultiAZ()}
+	}
+
+	switch opts.outputFormat {
+	case dump.JSONFormat:
+		data, _ := json.MarshalIndent(regions, "", cmdutil.DefaultJSONIndent)
+		_ = dump.JSON(opts.IO.Out, data)
+	case dump.YAMLFormat, dump.YMLFormat:
+		data, _ := yaml.Marshal(regions)
+		_ = dump.YAML(opts.IO.Out, data)
+	default:
+		if len(regions) == 0 {
+			logger.Info(opts.localizer.MustLocalize("kafka.regions.list.log.info.noRegions", localize.NewEntry("Provider", opts.provider)))
+			return nil
+		}
+		dump.Table(opts.IO.Out, mapRegionsToRows(regions))
+	}
+
+	return nil
+}
+
+func mapRegionsToRows(regions []region) []regionRow {
+	rows := make([]regionRow, len(regions))
+	for i, r := range regions {
+		rows[i] = regionRow{
+			ID:            r.ID,
+			DisplayName:   r.DisplayName,
+			Enabled:       strconv.FormatBool(r.Enabled),
+			InstanceTypes: strings.Join(r.SupportedInstanceTypes, ", "),
+			MultiAZ:       strconv.FormatBool(r.MultiAZ),
+		}
+	}
+	return rows
+}
@@ -0,0 +1,23 @@
+package regions
+
+import (
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/kafka/regions/list"
+	"github.com/spf13/cobra"
+)
+
+// NewRegionsCommand creates a new command sub-group for the cloud regions of Kafka instances
+func NewRegionsCommand(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   f.Localizer.MustLocalize("kafka.regions.cmd.use"),
+		Short: f.Localizer.MustLocalize("kafka.regions.cmd.shortDescription"),
+		Long:  f.Localizer.MustLocalize("kafka.regions.cmd.longDescription"),
+		Args:  cobra.ExactArgs(1),
+	}
+
+	cmd.AddCommand(
+		list.NewListCommand(f),
+	)
+
+	return cmd
+}
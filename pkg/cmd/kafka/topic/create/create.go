@@ -10,6 +10,9 @@ import (
 
 	"github.com/AlecAivazis/survey/v2"
 
+	"github.com/redhat-developer/app-services-cli/pkg/cache"
+	"github.com/redhat-developer/app-services-cli/pkg/cmdutil"
+	"github.com/redhat-developer/app-services-cli/pkg/common/clierr"
 	"github.com/redhat-developer/app-services-cli/pkg/connection"
 	topicutil "github.com/redhat-developer/app-services-cli/pkg/kafka/topic"
 	"github.com/redhat-developer/app-services-cli/pkg/localize"
@@ -187,20 +190,22 @@ func runCmd(opts *Options) error {
 		operationTmplPair := localize.NewEntry("Operation", "create")
 		switch httpRes.StatusCode {
 		case 401:
-			return errors.New(opts.localizer.MustLocalize("kafka.topic.common.error.unauthorized", operationTmplPair))
+			return clierr.New(clierr.CodeNotLoggedIn, errors.New(opts.localizer.MustLocalize("kafka.topic.common.error.unauthorized", operationTmplPair)))
 		case 403:
-			return errors.New(opts.localizer.MustLocalize("kafka.topic.common.error.forbidden", operationTmplPair))
+			return clierr.New(clierr.CodeForbidden, errors.New(opts.localizer.MustLocalize("kafka.topic.common.error.forbidden", operationTmplPair)))
 		case 409:
-			return errors.New(opts.localizer.MustLocalize("kafka.topic.create.error.conflictError", localize.NewEntry("TopicName", opts.topicName), localize.NewEntry("InstanceName", kafkaInstance.GetName())))
+			return clierr.New(clierr.CodeConflict, errors.New(opts.localizer.MustLocalize("kafka.topic.create.error.conflictError", localize.NewEntry("TopicName", opts.topicName), localize.NewEntry("InstanceName", kafkaInstance.GetName()))))
 		case 500:
-			return errors.New(opts.localizer.MustLocalize("kafka.topic.common.error.internalServerError"))
+			return clierr.New(clierr.CodeServiceUnavailable, errors.New(opts.localizer.MustLocalize("kafka.topic.common.error.internalServerError")))
 		case 503:
-			return errors.New(opts.localizer.MustLocalize("kafka.topic.common.error.unableToConnectToKafka", localize.NewEntry("Name", kafkaInstance.GetName())))
+			return clierr.New(clierr.CodeServiceUnavailable, errors.New(opts.localizer.MustLocalize("kafka.topic.common.error.unableToConnectToKafka", localize.NewEntry("Name", kafkaInstance.GetName()))))
 		default:
 			return err
 		}
 	}
 
+	cmdutil.InvalidateCache(opts.Config, logger, cache.TopicsNamespace(opts.kafkaID))
+
 	logger.Info(opts.localizer.MustLocalize("kafka.topic.create.log.info.topicCreated", localize.NewEntry("TopicName", response.GetName()), localize.NewEntry("InstanceName", kafkaInstance.GetName())))
 
 	switch opts.outputFormat {
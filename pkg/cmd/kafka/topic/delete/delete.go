@@ -3,10 +3,15 @@ package delete
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/redhat-developer/app-services-cli/pkg/cache"
 	"github.com/redhat-developer/app-services-cli/pkg/cmdutil"
+	"github.com/redhat-developer/app-services-cli/pkg/cmdutil/bulk"
+	"github.com/redhat-developer/app-services-cli/pkg/common/clierr"
 	"github.com/redhat-developer/app-services-cli/pkg/connection"
+	topicutil "github.com/redhat-developer/app-services-cli/pkg/kafka/topic"
 	"github.com/redhat-developer/app-services-cli/pkg/localize"
 
 	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
@@ -14,7 +19,6 @@ import (
 	"github.com/redhat-developer/app-services-cli/internal/config"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
 	"github.com/redhat-developer/app-services-cli/pkg/logging"
-
 	"github.com/spf13/cobra"
 )
 
@@ -22,6 +26,7 @@ type Options struct {
 	topicName string
 	kafkaID   string
 	force     bool
+	bulk      bulk.Options
 
 	IO         *iostreams.IOStreams
 	Config     config.IConfig
@@ -45,7 +50,7 @@ func NewDeleteTopicCommand(f *factory.Factory) *cobra.Command {
 		Short:   opts.localizer.MustLocalize("kafka.topic.delete.cmd.shortDescription"),
 		Long:    opts.localizer.MustLocalize("kafka.topic.delete.cmd.longDescription"),
 		Example: opts.localizer.MustLocalize("kafka.topic.delete.cmd.example"),
-		Args:    cobra.ExactValidArgs(1),
+		Args:    cobra.MaximumNArgs(1),
 		// Dynamic completion of the topic name
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 			return cmdutil.FilterValidTopicNameArgs(f, toComplete)
@@ -55,30 +60,44 @@ func NewDeleteTopicCommand(f *factory.Factory) *cobra.Command {
 				return errors.New(opts.localizer.MustLocalize("flag.error.requiredWhenNonInteractive", localize.NewEntry("Flag", "yes")))
 			}
 
-			if len(args) > 0 {
+			if opts.bulk.Enabled() {
+				if len(args) > 0 {
+					return errors.New(opts.localizer.MustLocalize("bulk.error.nameWithSelection", localize.NewEntry("Field", opts.localizer.MustLocalize("kafka.topic.delete.bulk.field"))))
+				}
+				if err = opts.bulk.Validate(opts.localizer); err != nil {
+					return err
+				}
+			} else if len(args) == 0 {
+				return errors.New(opts.localizer.MustLocalize("bulk.error.nameRequired",
+					localize.NewEntry("Field", opts.localizer.MustLocalize("kafka.topic.delete.bulk.field")),
+					localize.NewEntry("Kind", opts.localizer.MustLocalize("kafka.topic.delete.bulk.kind")),
+				))
+			} else {
 				opts.topicName = args[0]
 			}
 
-			if opts.kafkaID != "" {
-				return runCmd(opts)
-			}
+			if opts.kafkaID == "" {
+				cfg, err := opts.Config.Load()
+				if err != nil {
+					return err
+				}
 
-			cfg, err := opts.Config.Load()
-			if err != nil {
-				return err
-			}
+				if !cfg.HasKafka() {
+					return opts.localizer.MustLocalizeError("kafka.topic.common.error.noKafkaSelected")
+				}
 
-			if !cfg.HasKafka() {
-				return opts.localizer.MustLocalizeError("kafka.topic.common.error.noKafkaSelected")
+				opts.kafkaID = cfg.Services.Kafka.ClusterID
 			}
 
-			opts.kafkaID = cfg.Services.Kafka.ClusterID
-
+			if opts.bulk.Enabled() {
+				return runBulkDelete(opts)
+			}
 			return runCmd(opts)
 		},
 	}
 
 	cmd.Flags().BoolVarP(&opts.force, "yes", "y", false, opts.localizer.MustLocalize("kafka.topic.delete.flag.yes.description"))
+	bulk.AddFlags(cmd, &opts.bulk, opts.localizer, opts.localizer.MustLocalize("kafka.topic.delete.bulk.kind"), false)
 
 	return cmd
 }
@@ -111,7 +130,7 @@ func runCmd(opts *Options) error {
 			return err
 		}
 		if httpRes.StatusCode == 404 {
-			return errors.New(opts.localizer.MustLocalize("kafka.topic.common.error.topicNotFoundError", topicNameTmplPair, kafkaNameTmplPair))
+			return clierr.New(clierr.CodeNotFound, errors.New(opts.localizer.MustLocalize("kafka.topic.common.error.topicNotFoundError", topicNameTmplPair, kafkaNameTmplPair)))
 		}
 	}
 
@@ -140,21 +159,70 @@ func runCmd(opts *Options) error {
 		operationTmplPair := localize.NewEntry("Operation", "delete")
 		switch httpRes.StatusCode {
 		case 404:
-			return errors.New(opts.localizer.MustLocalize("kafka.topic.common.error.notFoundError", topicNameTmplPair, kafkaNameTmplPair))
+			return clierr.New(clierr.CodeNotFound, errors.New(opts.localizer.MustLocalize("kafka.topic.common.error.notFoundError", topicNameTmplPair, kafkaNameTmplPair)))
 		case 401:
-			return errors.New(opts.localizer.MustLocalize("kafka.topic.common.error.unauthorized", operationTmplPair))
+			return clierr.New(clierr.CodeNotLoggedIn, errors.New(opts.localizer.MustLocalize("kafka.topic.common.error.unauthorized", operationTmplPair)))
 		case 403:
-			return errors.New(opts.localizer.MustLocalize("kafka.topic.common.error.forbidden", operationTmplPair))
+			return clierr.New(clierr.CodeForbidden, errors.New(opts.localizer.MustLocalize("kafka.topic.common.error.forbidden", operationTmplPair)))
 		case 500:
-			return errors.New(opts.localizer.MustLocalize("kafka.topic.common.error.internalServerError"))
+			return clierr.New(clierr.CodeServiceUnavailable, errors.New(opts.localizer.MustLocalize("kafka.topic.common.error.internalServerError")))
 		case 503:
-			return errors.New(opts.localizer.MustLocalize("kafka.topic.common.error.unableToConnectToKafka", localize.NewEntry("Name", kafkaInstance.GetName())))
+			return clierr.New(clierr.CodeServiceUnavailable, errors.New(opts.localizer.MustLocalize("kafka.topic.common.error.unableToConnectToKafka", localize.NewEntry("Name", kafkaInstance.GetName()))))
 		default:
 			return err
 		}
 	}
 
+	cmdutil.InvalidateCache(opts.Config, logger, cache.TopicsNamespace(opts.kafkaID))
+
 	logger.Info(opts.localizer.MustLocalize("kafka.topic.delete.log.info.topicDeleted", topicNameTmplPair, kafkaNameTmplPair))
 
 	return nil
 }
+
+// runBulkDelete deletes the topics selected by the bulk deletion flags
+func runBulkDelete(opts *Options) error {
+	conn, err := opts.Connection(connection.DefaultConfigRequireMasAuth)
+	if err != nil {
+		return err
+	}
+
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	api, _, err := conn.API().KafkaAdmin(opts.kafkaID)
+	if err != nil {
+		return err
+	}
+
+	topics, err := topicutil.ListTopics(context.Background(), api)
+	if err != nil {
+		return err
+	}
+
+	resources := make([]bulk.Resource, len(topics))
+	for i, t := range topics {
+		resources[i] = bulk.Resource{ID: t.GetName(), Name: t.GetName()}
+	}
+
+	deletion := &bulk.Deletion{
+		IO:        opts.IO,
+		Logger:    logger,
+		Localizer: opts.localizer,
+		Kind:      opts.localizer.MustLocalize("kafka.topic.delete.bulk.kind"),
+		Resources: opts.bulk.Match(resources, time.Now()),
+		Force:     opts.force,
+		Delete: func(ctx context.Context, r bulk.Resource) error {
+			_, err := api.TopicsApi.DeleteTopic(ctx, r.Name).Execute()
+			return err
+		},
+	}
+	deleted, err := deletion.Run()
+	if len(deleted) > 0 {
+		cmdutil.InvalidateCache(opts.Config, logger, cache.TopicsNamespace(opts.kafkaID))
+	}
+
+	return err
+}
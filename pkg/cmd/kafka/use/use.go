@@ -92,11 +92,6 @@ func runUse(opts *Options) error {
 		return err
 	}
 
-	cfg, err := opts.Config.Load()
-	if err != nil {
-		return err
-	}
-
 	connection, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
 	if err != nil {
 		return err
@@ -124,8 +119,11 @@ func runUse(opts *Options) error {
 	}
 
 	nameTmplEntry := localize.NewEntry("Name", res.GetName())
-	cfg.Services.Kafka = &kafkaConfig
-	if err := opts.Config.Save(cfg); err != nil {
+	err = opts.Config.Update(func(cfg *config.Config) error {
+		cfg.Services.Kafka = &kafkaConfig
+		return nil
+	})
+	if err != nil {
 		saveErrMsg := opts.localizer.MustLocalize("kafka.use.error.saveError", nameTmplEntry)
 		return fmt.Errorf("%v: %w", saveErrMsg, err)
 	}
@@ -0,0 +1,59 @@
+// This file contains functions used to implement the '--log-format' and '--log-file' command line options.
+
+package logflags
+
+import "github.com/spf13/pflag"
+
+// Log formats
+const (
+	TextFormat = "text"
+	JSONFormat = "json"
+)
+
+// Formats are the valid values of the '--log-format' flag
+var Formats = []string{TextFormat, JSONFormat}
+
+// AddFlags adds the log-format and log-file flags to the given set of command line flags.
+func AddFlags(flags *pflag.FlagSet, formatDescription string, fileDescription string) {
+	flags.StringVar(
+		&format,
+		"log-format",
+		TextFormat,
+		formatDescription,
+	)
+	flags.StringVar(
+		&file,
+		"log-file",
+		"",
+		fileDescription,
+	)
+}
+
+// Format returns the format of the logs
+func Format() string {
+	return format
+}
+
+// File returns the path of the file the logs are also written to, if any
+func File() string {
+	return file
+}
+
+// SetCommandPath sets the path of the command being run, such as "rhoas kafka list"
+func SetCommandPath(path string) {
+	commandPath = path
+}
+
+// CommandPath returns the path of the command being run, which is added to structured logs
+func CommandPath() string {
+	return commandPath
+}
+
+var (
+	// format is the value of the '--log-format' flag
+	format string
+	// file is the value of the '--log-file' flag
+	file string
+	// commandPath is the path of the command being run
+	commandPath string
+)
@@ -0,0 +1,58 @@
+// This file contains functions used to implement the '--log-http' command line option.
+
+package loghttp
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/spf13/pflag"
+)
+
+// EnvName is the environment variable which enables HTTP tracing when the flag is not used
+const EnvName = "RHOAS_LOG_HTTP"
+
+// AddFlag adds the log-http flag to the given set of command line flags.
+// The flag can be used without a value to trace requests to the logs,
+// or with the path of a HAR file to record the requests to
+func AddFlag(flags *pflag.FlagSet, description string) {
+	flags.StringVar(
+		&value,
+		"log-http",
+		"",
+		description,
+	)
+	flags.Lookup("log-http").NoOptDefVal = "true"
+}
+
+// Enabled returns a boolean flag that indicates if HTTP tracing to the logs is enabled.
+// It is false when the requests are only recorded to a HAR file
+func Enabled() bool {
+	enabled, _ := mode()
+	return enabled
+}
+
+// HARFile returns the path of the HAR file to record the requests to, if any
+func HARFile() string {
+	_, harFile := mode()
+	return harFile
+}
+
+// mode parses the value of the flag, or of the environment variable when the flag is not used.
+// Boolean values enable or disable tracing to the logs, other values are HAR file paths
+func mode() (logs bool, harFile string) {
+	v := value
+	if v == "" {
+		v = os.Getenv(EnvName)
+	}
+	if v == "" {
+		return false, ""
+	}
+	if enabled, err := strconv.ParseBool(v); err == nil {
+		return enabled, ""
+	}
+	return false, v
+}
+
+// value is the value of the flag
+var value string
@@ -5,15 +5,18 @@ package login
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"net/http"
 	"net/url"
+	"path/filepath"
 
 	"github.com/redhat-developer/app-services-cli/internal/build"
 	"golang.org/x/oauth2"
 
 	"github.com/redhat-developer/app-services-cli/pkg/auth/login"
 	"github.com/redhat-developer/app-services-cli/pkg/auth/token"
+	"github.com/redhat-developer/app-services-cli/pkg/cache"
 	"github.com/redhat-developer/app-services-cli/pkg/localize"
 
 	"github.com/redhat-developer/app-services-cli/internal/config"
@@ -73,6 +76,9 @@ type Options struct {
 	clientID              string
 	scopes                []string
 	insecureSkipTLSVerify bool
+	caFile                string
+	certFile              string
+	keyFile               string
 	printURL              bool
 	offlineToken          string
 }
@@ -103,6 +109,10 @@ func NewLoginCmd(f *factory.Factory) *cobra.Command {
 				return err
 			}
 
+			if (opts.certFile == "") != (opts.keyFile == "") {
+				return errors.New(opts.localizer.MustLocalize("login.error.certAndKeyRequired"))
+			}
+
 			if opts.IO.IsSSHSession() && opts.offlineToken == "" {
 				logger.Info(opts.localizer.MustLocalize("login.log.info.sshLoginDetected", localize.NewEntry("OfflineTokenURL", build.OfflineTokenURL)))
 			}
@@ -113,6 +123,9 @@ func NewLoginCmd(f *factory.Factory) *cobra.Command {
 
 	cmd.Flags().StringVar(&opts.url, "api-gateway", build.ProductionAPIURL, opts.localizer.MustLocalize("login.flag.apiGateway"))
 	cmd.Flags().BoolVar(&opts.insecureSkipTLSVerify, "insecure", false, opts.localizer.MustLocalize("login.flag.insecure"))
+	cmd.Flags().StringVar(&opts.caFile, "ca-file", "", opts.localizer.MustLocalize("login.flag.caFile"))
+	cmd.Flags().StringVar(&opts.certFile, "cert", "", opts.localizer.MustLocalize("login.flag.cert"))
+	cmd.Flags().StringVar(&opts.keyFile, "key", "", opts.localizer.MustLocalize("login.flag.key"))
 	cmd.Flags().StringVar(&opts.clientID, "client-id", build.DefaultClientID, opts.localizer.MustLocalize("login.flag.clientId"))
 	cmd.Flags().StringVar(&opts.authURL, "auth-url", build.ProductionAuthURL, opts.localizer.MustLocalize("login.flag.authUrl"))
 	cmd.Flags().StringVar(&opts.masAuthURL, "mas-auth-url", build.ProductionMasAuthURL, opts.localizer.MustLocalize("login.flag.masAuthUrl"))
@@ -147,8 +160,13 @@ func runLogin(opts *Options) (err error) {
 	}
 	opts.masAuthURL = masAuthURL.String()
 
+	tlsConfig, err := loadTLSConfig(opts)
+	if err != nil {
+		return err
+	}
+
 	if opts.offlineToken == "" {
-		tr := createTransport(opts.insecureSkipTLSVerify)
+		tr := createTransport(tlsConfig)
 		httpClient := oauth2.NewClient(context.Background(), nil)
 		httpClient.Transport = tr
 
@@ -184,23 +202,31 @@ func runLogin(opts *Options) (err error) {
 		}
 	}
 
-	cfg, err := opts.Config.Load()
+	var accessToken string
+	err = opts.Config.Update(func(cfg *config.Config) error {
+		cfg.UseCloudProfile()
+		cfg.APIUrl = gatewayURL.String()
+		cfg.Insecure = opts.insecureSkipTLSVerify
+		cfg.CAFile = opts.caFile
+		cfg.CertFile = opts.certFile
+		cfg.KeyFile = opts.keyFile
+		cfg.ClientID = opts.clientID
+		cfg.AuthURL = opts.authURL
+		cfg.MasAuthURL = opts.masAuthURL
+		cfg.Scopes = opts.scopes
+		accessToken = cfg.AccessToken
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	cfg.APIUrl = gatewayURL.String()
-	cfg.Insecure = opts.insecureSkipTLSVerify
-	cfg.ClientID = opts.clientID
-	cfg.AuthURL = opts.authURL
-	cfg.MasAuthURL = opts.masAuthURL
-	cfg.Scopes = opts.scopes
-
-	if err = opts.Config.Save(cfg); err != nil {
-		return err
+	// the cached responses belong to the previous session
+	if err = cache.Clear(opts.Config); err != nil {
+		logger.Debug("Unable to clear the cache:", err)
 	}
 
-	username, ok := token.GetUsername(cfg.AccessToken)
+	username, ok := token.GetUsername(accessToken)
 	logger.Info("")
 
 	if !ok {
@@ -219,33 +245,64 @@ func runLogin(opts *Options) (err error) {
 }
 
 func loginWithOfflineToken(opts *Options) (err error) {
-	cfg, err := opts.Config.Load()
+	err = opts.Config.Update(func(cfg *config.Config) error {
+		cfg.UseCloudProfile()
+		cfg.Insecure = opts.insecureSkipTLSVerify
+		cfg.CAFile = opts.caFile
+		cfg.CertFile = opts.certFile
+		cfg.KeyFile = opts.keyFile
+		cfg.ClientID = opts.clientID
+		cfg.AuthURL = opts.authURL
+		cfg.MasAuthURL = opts.masAuthURL
+		cfg.Scopes = opts.scopes
+		cfg.RefreshToken = opts.offlineToken
+		// remove MAS-SSO tokens, as this does not support token login
+		cfg.MasAccessToken = ""
+		cfg.MasRefreshToken = ""
+		return nil
+	})
 	if err != nil {
 		return err
 	}
-	cfg.Insecure = opts.insecureSkipTLSVerify
-	cfg.ClientID = opts.clientID
-	cfg.AuthURL = opts.authURL
-	cfg.MasAuthURL = opts.masAuthURL
-	cfg.Scopes = opts.scopes
-	cfg.RefreshToken = opts.offlineToken
-	// remove MAS-SSO tokens, as this does not support token login
-	cfg.MasAccessToken = ""
-	cfg.MasRefreshToken = ""
-
-	if err = opts.Config.Save(cfg); err != nil {
-		return err
-	}
 
 	_, err = opts.Connection(connection.DefaultConfigSkipMasAuth)
 	return err
 }
 
-func createTransport(insecure bool) *http.Transport {
-	// #nosec 402
+func createTransport(tlsConfig *tls.Config) *http.Transport {
 	return &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure},
+		TLSClientConfig: tlsConfig,
+		Proxy:           http.ProxyFromEnvironment,
+	}
+}
+
+// loadTLSConfig loads the CA file and client certificate set by the flags.
+// Their paths are made absolute, as they are stored in the config and used from any directory
+func loadTLSConfig(opts *Options) (tlsConfig *tls.Config, err error) {
+	for _, path := range []*string{&opts.caFile, &opts.certFile, &opts.keyFile} {
+		if *path == "" {
+			continue
+		}
+		if *path, err = filepath.Abs(*path); err != nil {
+			return nil, err
+		}
 	}
+
+	var trustedCAs *x509.CertPool
+	if opts.caFile != "" {
+		if trustedCAs, err = connection.LoadTrustedCAs(opts.caFile); err != nil {
+			return nil, err
+		}
+	}
+
+	var clientCert *tls.Certificate
+	if opts.certFile != "" {
+		if clientCert, err = connection.LoadClientCertificate(opts.certFile, opts.keyFile); err != nil {
+			return nil, err
+		}
+	}
+
+	return connection.NewTLSConfig(opts.insecureSkipTLSVerify, trustedCAs, clientCert), nil
 }
 
 func getURLFromAlias(urlOrAlias string, urlAliasMap map[string]string, localizer localize.Localizer) (u *url.URL, err error) {
@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/cache"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
 	"github.com/redhat-developer/app-services-cli/pkg/connection"
 	"github.com/redhat-developer/app-services-cli/pkg/localize"
@@ -60,6 +61,11 @@ func runLogout(opts *Options) error {
 		return fmt.Errorf("%v: %w", opts.localizer.MustLocalize("logout.error.unableToLogout"), err)
 	}
 
+	// the cached responses belong to the user who logged out
+	if err = cache.Clear(opts.Config); err != nil {
+		logger.Debug("Unable to clear the cache:", err)
+	}
+
 	logger.Info(opts.localizer.MustLocalize("logout.log.info.logoutSuccess"))
 
 	return nil
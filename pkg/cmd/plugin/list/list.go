@@ -0,0 +1,100 @@
+package list
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/flag"
+	"github.com/redhat-developer/app-services-cli/pkg/cmdutil"
+	flagutil "github.com/redhat-developer/app-services-cli/pkg/cmdutil/flags"
+	"github.com/redhat-developer/app-services-cli/pkg/dump"
+	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
+	"github.com/redhat-developer/app-services-cli/pkg/localize"
+	"github.com/redhat-developer/app-services-cli/pkg/logging"
+	"github.com/redhat-developer/app-services-cli/pkg/plugin"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type Options struct {
+	IO        *iostreams.IOStreams
+	Logger    func() (logging.Logger, error)
+	localizer localize.Localizer
+
+	output string
+}
+
+// pluginRow contains the properties used to populate the list of plugins into a table row
+type pluginRow struct {
+	Name string `json:"name" header:"Name"`
+	Path string `json:"path" header:"Path"`
+}
+
+// NewListCommand creates a new command to list the plugins
+func NewListCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		IO:        f.IOStreams,
+		Logger:    f.Logger,
+		localizer: f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.MustLocalize("plugin.list.cmd.use"),
+		Short:   opts.localizer.MustLocalize("plugin.list.cmd.shortDescription"),
+		Long:    opts.localizer.MustLocalize("plugin.list.cmd.longDescription"),
+		Example: opts.localizer.MustLocalize("plugin.list.cmd.example"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if opts.output != "" && !flagutil.IsValidInput(opts.output, flagutil.ValidOutputFormats...) {
+				return flag.InvalidValueError("output", opts.output, flagutil.ValidOutputFormats...)
+			}
+
+			return runList(opts, cmd.Root())
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", opts.localizer.MustLocalize("plugin.list.flag.output.description"))
+
+	flagutil.EnableOutputFlagCompletion(cmd)
+
+	return cmd
+}
+
+func runList(opts *Options, root *cobra.Command) error {
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	plugins := []plugin.Plugin{}
+	for _, p := range plugin.Find(os.Getenv("PATH")) {
+		if cmdutil.IsBuiltinCommand(root, p.Name) {
+			logger.Info(opts.localizer.MustLocalize("plugin.list.log.info.overridden", localize.NewEntry("Name", p.Name), localize.NewEntry("Path", p.Path)))
+			continue
+		}
+		plugins = append(plugins, p)
+	}
+
+	if len(plugins) == 0 && opts.output == "" {
+		logger.Info(opts.localizer.MustLocalize("plugin.list.log.info.noneFound", localize.NewEntry("Prefix", plugin.Prefix)))
+		return nil
+	}
+
+	switch opts.output {
+	case dump.JSONFormat:
+		data, _ := json.MarshalIndent(plugins, "", cmdutil.DefaultJSONIndent)
+		_ = dump.JSON(opts.IO.Out, data)
+	case dump.YAMLFormat, dump.YMLFormat:
+		data, _ := yaml.Marshal(plugins)
+		_ = dump.YAML(opts.IO.Out, data)
+	default:
+		rows := make([]pluginRow, len(plugins))
+		for i, p := range plugins {
+			rows[i] = pluginRow(p)
+		}
+		dump.Table(opts.IO.Out, rows)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package plugin
+
+import (
+	"os"
+
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/plugin/list"
+	"github.com/redhat-developer/app-services-cli/pkg/cmdutil"
+	"github.com/redhat-developer/app-services-cli/pkg/connection"
+	"github.com/redhat-developer/app-services-cli/pkg/localize"
+	"github.com/redhat-developer/app-services-cli/pkg/plugin"
+	"github.com/spf13/cobra"
+)
+
+// NewPluginCommand creates a new command sub-group to manage plugins
+func NewPluginCommand(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     f.Localizer.MustLocalize("plugin.cmd.use"),
+		Short:   f.Localizer.MustLocalize("plugin.cmd.shortDescription"),
+		Long:    f.Localizer.MustLocalize("plugin.cmd.longDescription"),
+		Example: f.Localizer.MustLocalize("plugin.cmd.example"),
+		Args:    cobra.ExactArgs(1),
+	}
+
+	cmd.AddCommand(
+		list.NewListCommand(f),
+	)
+
+	return cmd
+}
+
+// AddPluginCommands adds a command to the root command for every plugin found on the PATH.
+// Plugins cannot override the built-in commands
+func AddPluginCommands(root *cobra.Command, f *factory.Factory) {
+	for _, p := range plugin.Find(os.Getenv("PATH")) {
+		if cmdutil.IsBuiltinCommand(root, p.Name) {
+			continue
+		}
+		root.AddCommand(newPluginRunCommand(f, p))
+	}
+}
+
+// newPluginRunCommand creates a command which runs the plugin, passing it all the arguments and flags
+func newPluginRunCommand(f *factory.Factory, p plugin.Plugin) *cobra.Command {
+	return &cobra.Command{
+		Use:                p.Name,
+		Short:              f.Localizer.MustLocalize("plugin.run.cmd.shortDescription", localize.NewEntry("Path", p.Path)),
+		Annotations:        map[string]string{cmdutil.PluginAnnotation: p.Path},
+		DisableFlagParsing: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPlugin(f, p, args)
+		},
+	}
+}
+
+func runPlugin(f *factory.Factory, p plugin.Plugin, args []string) error {
+	logger, err := f.Logger()
+	if err != nil {
+		return err
+	}
+
+	cfgLocation, err := f.Config.Location()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := f.Config.Load()
+	if err != nil {
+		return err
+	}
+
+	// the tokens are refreshed so that the plugin gets a valid access token,
+	// but plugins which do not call the API can run without a session
+	if !cfg.IsLocalProfile() && (cfg.AccessToken != "" || cfg.RefreshToken != "") {
+		if _, err = f.Connection(connection.DefaultConfigSkipMasAuth); err != nil {
+			logger.Debug(f.Localizer.MustLocalize("plugin.run.log.debug.noAccessToken"), err)
+		} else if cfg, err = f.Config.Load(); err != nil {
+			return err
+		}
+	}
+
+	logger.Debug(f.Localizer.MustLocalize("plugin.run.log.debug.running", localize.NewEntry("Path", p.Path)))
+
+	return plugin.Run(p, args, plugin.Environment(cfgLocation, cfg), f.IOStreams)
+}
@@ -0,0 +1,132 @@
+package quota
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/ams"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/flag"
+	"github.com/redhat-developer/app-services-cli/pkg/cmdutil"
+	flagutil "github.com/redhat-developer/app-services-cli/pkg/cmdutil/flags"
+	"github.com/redhat-developer/app-services-cli/pkg/connection"
+	"github.com/redhat-developer/app-services-cli/pkg/dump"
+	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
+	"github.com/redhat-developer/app-services-cli/pkg/localize"
+	"github.com/redhat-developer/app-services-cli/pkg/logging"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type Options struct {
+	IO         *iostreams.IOStreams
+	Config     config.IConfig
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	localizer  localize.Localizer
+
+	outputFormat string
+}
+
+// Status is the quota of the organization, along with the type of Kafka instance it allows to provision
+type Status struct {
+	Quotas            []ams.Quota `json:"quotas" yaml:"quotas"`
+	KafkaInstanceType string      `json:"kafka_instance_type" yaml:"kafka_instance_type"`
+}
+
+// quotaRow contains the properties used to populate the list of quota into a table row
+type quotaRow struct {
+	Service      string `header:"Service"`
+	InstanceType string `header:"Instance Type"`
+	Allowed      string `header:"Allowed"`
+	Consumed     string `header:"Consumed"`
+	Remaining    string `header:"Remaining"`
+}
+
+// NewQuotaCommand creates a new command to view the quota of the organization
+func NewQuotaCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		IO:         f.IOStreams,
+		Config:     f.Config,
+		Connection: f.Connection,
+		Logger:     f.Logger,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.MustLocalize("quota.cmd.use"),
+		Short:   opts.localizer.MustLocalize("quota.cmd.shortDescription"),
+		Long:    opts.localizer.MustLocalize("quota.cmd.longDescription"),
+		Example: opts.localizer.MustLocalize("quota.cmd.example"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if opts.outputFormat != "" && !flagutil.IsValidInput(opts.outputFormat, flagutil.ValidOutputFormats...) {
+				return flag.InvalidValueError("output", opts.outputFormat, flagutil.ValidOutputFormats...)
+			}
+
+			return runQuota(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", "", opts.localizer.MustLocalize("quota.flag.output.description"))
+
+	flagutil.EnableOutputFlagCompletion(cmd)
+
+	return cmd
+}
+
+func runQuota(opts *Options) error {
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
+	if err != nil {
+		return err
+	}
+
+	quotas, err := ams.GetQuotas(conn)
+	if err != nil {
+		return err
+	}
+
+	status := Status{
+		Quotas:            quotas,
+		KafkaInstanceType: ams.KafkaInstanceType(quotas),
+	}
+
+	switch opts.outputFormat {
+	case dump.JSONFormat:
+		data, _ := json.MarshalIndent(status, "", cmdutil.DefaultJSONIndent)
+		_ = dump.JSON(opts.IO.Out, data)
+	case dump.YAMLFormat, dump.YMLFormat:
+		data, _ := yaml.Marshal(status)
+		_ = dump.YAML(opts.IO.Out, data)
+	default:
+		if len(quotas) == 0 {
+			logger.Info(opts.localizer.MustLocalize("quota.log.info.noQuota"))
+		} else {
+			dump.Table(opts.IO.Out, mapQuotasToRows(quotas))
+			logger.Info("")
+		}
+		logger.Info(ams.DescribeKafkaInstanceType(opts.localizer, quotas))
+	}
+
+	return nil
+}
+
+func mapQuotasToRows(quotas []ams.Quota) []quotaRow {
+	rows := make([]quotaRow, len(quotas))
+	for i, q := range quotas {
+		rows[i] = quotaRow{
+			Service:      q.Service,
+			InstanceType: q.InstanceType,
+			Allowed:      strconv.Itoa(q.Allowed),
+			Consumed:     strconv.Itoa(q.Consumed),
+			Remaining:    strconv.Itoa(q.Remaining()),
+		}
+	}
+	return rows
+}
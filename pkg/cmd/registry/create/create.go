@@ -100,11 +100,6 @@ func runCreate(opts *Options) error {
 		return err
 	}
 
-	cfg, err := opts.Config.Load()
-	if err != nil {
-		return err
-	}
-
 	var payload *srsmgmtv1.RegistryCreateRest
 	if opts.interactive {
 		logger.Debug()
@@ -164,8 +159,11 @@ func runCreate(opts *Options) error {
 
 	if opts.autoUse {
 		logger.Debug("Auto-use is set, updating the current instance")
-		cfg.Services.ServiceRegistry = registryConfig
-		if err := opts.Config.Save(cfg); err != nil {
+		err = opts.Config.Update(func(cfg *config.Config) error {
+			cfg.Services.ServiceRegistry = registryConfig
+			return nil
+		})
+		if err != nil {
 			return fmt.Errorf("%v: %w", opts.localizer.MustLocalize("registry.cmd.create.error.couldNotUse"), err)
 		}
 	} else {
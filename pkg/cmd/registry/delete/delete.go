@@ -94,11 +94,6 @@ func runDelete(opts *options) error {
 		return err
 	}
 
-	cfg, err := opts.Config.Load()
-	if err != nil {
-		return err
-	}
-
 	connection, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
 	if err != nil {
 		return err
@@ -152,19 +147,16 @@ func runDelete(opts *options) error {
 
 	logger.Info(opts.localizer.MustLocalize("registry.delete.log.info.deleteSuccess", localize.NewEntry("Name", registryName)))
 
-	currentContextRegistry := cfg.Services.ServiceRegistry
-	// this is not the current cluster, our work here is done
-	if currentContextRegistry == nil || currentContextRegistry.InstanceID != opts.id {
-		return nil
-	}
-
-	// the service that was deleted is set as the user's current cluster
-	// since it was deleted it should be removed from the config
-	cfg.Services.ServiceRegistry = nil
-	err = opts.Config.Save(cfg)
-	if err != nil {
-		return err
-	}
+	return opts.Config.Update(func(cfg *config.Config) error {
+		currentContextRegistry := cfg.Services.ServiceRegistry
+		// this is not the current cluster, our work here is done
+		if currentContextRegistry == nil || currentContextRegistry.InstanceID != opts.id {
+			return nil
+		}
 
-	return nil
+		// the service that was deleted is set as the user's current cluster
+		// since it was deleted it should be removed from the config
+		cfg.Services.ServiceRegistry = nil
+		return nil
+	})
 }
@@ -85,11 +85,6 @@ func runUse(opts *Options) error {
 		return err
 	}
 
-	cfg, err := opts.Config.Load()
-	if err != nil {
-		return err
-	}
-
 	connection, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
 	if err != nil {
 		return err
@@ -117,8 +112,11 @@ func runUse(opts *Options) error {
 	}
 
 	nameTmplEntry := localize.NewEntry("Name", registry.GetName())
-	cfg.Services.ServiceRegistry = registryConfig
-	if err := opts.Config.Save(cfg); err != nil {
+	err = opts.Config.Update(func(cfg *config.Config) error {
+		cfg.Services.ServiceRegistry = registryConfig
+		return nil
+	})
+	if err != nil {
 		saveErrMsg := opts.localizer.MustLocalize("registry.use.error.saveError", nameTmplEntry)
 		return fmt.Errorf("%v: %w", saveErrMsg, err)
 	}
@@ -0,0 +1,40 @@
+// This file contains functions used to implement the '--max-retries' command line option.
+
+package retry
+
+import (
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/httputil"
+	"github.com/spf13/pflag"
+)
+
+// AddFlag adds the max-retries flag to the given set of command line flags.
+func AddFlag(flags *pflag.FlagSet, description string) {
+	flags.IntVar(
+		&maxRetries,
+		flagName,
+		httputil.DefaultMaxRetries,
+		description,
+	)
+	flag = flags.Lookup(flagName)
+}
+
+// MaxRetries returns the number of times failed API requests are retried.
+// The flag takes precedence over the value of the config
+func MaxRetries(cfg *config.Config) int {
+	if flag != nil && flag.Changed {
+		return maxRetries
+	}
+	if cfg != nil && cfg.MaxRetries != nil {
+		return *cfg.MaxRetries
+	}
+	return httputil.DefaultMaxRetries
+}
+
+const flagName = "max-retries"
+
+// maxRetries is the value of the flag
+var maxRetries int
+
+// flag is the flag added by AddFlag, used to check if it was set
+var flag *pflag.Flag
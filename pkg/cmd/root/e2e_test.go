@@ -0,0 +1,815 @@
+package root
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/api/fake"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/loghttp"
+	"github.com/redhat-developer/app-services-cli/pkg/common/clierr"
+	"github.com/redhat-developer/app-services-cli/pkg/localize/goi18n"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+	"gopkg.in/yaml.v2"
+)
+
+// newFakeSession starts a fake control plane and logs in to it with a configuration file
+// in a temporary directory
+func newFakeSession(t *testing.T) *fake.Server {
+	server := fake.NewServer()
+	t.Cleanup(server.Close)
+
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+	t.Setenv(config.EnvName, cfgPath)
+
+	cfg := &config.Config{}
+	server.Login(cfg)
+	if err := config.NewFile().Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	return server
+}
+
+// execute runs the CLI with the arguments, returning what it writes to the output stream
+func execute(t *testing.T, args ...string) (string, error) {
+	out, _, err := executeWithErrOut(t, args...)
+	return out, err
+}
+
+// executeWithErrOut runs the CLI with the arguments, returning what it writes to the output and error streams
+func executeWithErrOut(t *testing.T, args ...string) (string, string, error) {
+	localizer, err := goi18n.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out, errOut bytes.Buffer
+	f := factory.New("dev", localizer)
+	f.IOStreams.Out = &out
+	f.IOStreams.ErrOut = &errOut
+	f.IOStreams.In = os.Stdin
+
+	cmd := NewRootCommand(f, "dev")
+	cmd.SetArgs(args)
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	err = cmd.Execute()
+	t.Logf("rhoas %v\n%v%v%v", strings.Join(args, " "), out.String(), errOut.String(), err)
+	return out.String(), errOut.String(), err
+}
+
+func mustExecute(t *testing.T, args ...string) string {
+	t.Helper()
+	out, err := execute(t, args...)
+	if err != nil {
+		t.Fatalf("rhoas %v: %v", strings.Join(args, " "), err)
+	}
+	return out
+}
+
+func TestKafkaCommandsAgainstFake(t *testing.T) {
+	newFakeSession(t)
+
+	var created struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	out := mustExecute(t, "kafka", "create", "my-kafka", "-o", "json")
+	if err := json.Unmarshal([]byte(out), &created); err != nil {
+		t.Fatalf("could not parse kafka create output %q: %v", out, err)
+	}
+	if created.ID == "" || created.Name != "my-kafka" {
+		t.Fatalf("unexpected Kafka instance created: %+v", created)
+	}
+
+	if _, err := execute(t, "kafka", "create", "my-kafka"); err == nil {
+		t.Error("expected an error when creating a Kafka instance with a name which is already used")
+	}
+
+	out = mustExecute(t, "kafka", "list", "-o", "json")
+	if !strings.Contains(out, created.ID) {
+		t.Errorf("kafka list output does not contain the instance %v: %v", created.ID, out)
+	}
+
+	out = mustExecute(t, "kafka", "describe", "--id", created.ID, "-o", "json")
+	if !strings.Contains(out, `"status": "ready"`) {
+		t.Errorf("kafka describe output does not contain the ready status: %v", out)
+	}
+
+	// the instance was set as the current instance when it was created
+	mustExecute(t, "kafka", "topic", "create", "my-topic", "--partitions", "3")
+	out = mustExecute(t, "kafka", "topic", "list", "-o", "json")
+	if !strings.Contains(out, "my-topic") {
+		t.Errorf("kafka topic list output does not contain the topic: %v", out)
+	}
+	mustExecute(t, "kafka", "topic", "delete", "my-topic", "-y")
+
+	mustExecute(t, "kafka", "delete", "--id", created.ID, "-y")
+	if _, err := execute(t, "kafka", "describe", "--id", created.ID); err == nil {
+		t.Error("expected an error when describing a deleted Kafka instance")
+	}
+}
+
+func TestKafkaCreateRequiresTermsAgainstFake(t *testing.T) {
+	server := newFakeSession(t)
+	server.SetTermsRequired(true)
+
+	// the command points the user at the terms and conditions instead of creating the instance
+	mustExecute(t, "kafka", "create", "my-kafka")
+	out := mustExecute(t, "kafka", "list", "-o", "json")
+	if strings.Contains(out, "my-kafka") {
+		t.Errorf("Kafka instance created although the terms and conditions were not accepted: %v", out)
+	}
+
+	server.SetTermsRequired(false)
+	mustExecute(t, "kafka", "create", "my-kafka")
+	out = mustExecute(t, "kafka", "list", "-o", "json")
+	if !strings.Contains(out, "my-kafka") {
+		t.Errorf("kafka list output does not contain the instance: %v", out)
+	}
+}
+
+func TestServiceAccountAndRegistryCommandsAgainstFake(t *testing.T) {
+	newFakeSession(t)
+
+	credentialsFile := filepath.Join(t.TempDir(), "credentials.json")
+	mustExecute(t, "service-account", "create", "--name", "my-sa", "--file-format", "json", "--file-location", credentialsFile)
+	if _, err := os.Stat(credentialsFile); err != nil {
+		t.Errorf("credentials file not written: %v", err)
+	}
+
+	out := mustExecute(t, "service-account", "list", "-o", "json")
+	if !strings.Contains(out, "my-sa") {
+		t.Errorf("service-account list output does not contain the service account: %v", out)
+	}
+
+	mustExecute(t, "service-registry", "create", "my-registry")
+	out = mustExecute(t, "service-registry", "list", "-o", "json")
+	if !strings.Contains(out, "my-registry") {
+		t.Errorf("service-registry list output does not contain the registry: %v", out)
+	}
+}
+
+func TestKafkaListFiltersAgainstFake(t *testing.T) {
+	newFakeSession(t)
+
+	mustExecute(t, "kafka", "create", "dev-kafka")
+	mustExecute(t, "kafka", "create", "prod-kafka")
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{args: []string{"--filter", "name=dev-*"}, want: []string{"dev-kafka"}},
+		{args: []string{"--filter", "name!=dev-kafka,status=ready"}, want: []string{"prod-kafka"}},
+		{args: []string{"--owner", fake.Username, "--status", "ready"}, want: []string{"dev-kafka", "prod-kafka"}},
+		{args: []string{"--owner", "me"}, want: []string{"dev-kafka", "prod-kafka"}},
+		{args: []string{"--filter", "owner=me,name=dev-*"}, want: []string{"dev-kafka"}},
+		{args: []string{"--filter", "owner!=me"}, want: []string{}},
+		{args: []string{"--filter", "name=x' or name like '%"}, want: []string{}},
+		{args: []string{"--created-after", "2000-01-01"}, want: []string{"dev-kafka", "prod-kafka"}},
+		{args: []string{"--created-after", "2999-01-01T00:00:00Z"}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			out := mustExecute(t, append([]string{"kafka", "list", "-o", "json"}, tt.args...)...)
+
+			var list struct {
+				Items []struct {
+					Name string `json:"name"`
+				} `json:"items"`
+			}
+			if err := json.Unmarshal([]byte(out), &list); err != nil {
+				t.Fatalf("could not parse kafka list output %q: %v", out, err)
+			}
+			got := []string{}
+			for _, item := range list.Items {
+				got = append(got, item.Name)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("kafka list %v = %v, want %v", tt.args, got, tt.want)
+			}
+		})
+	}
+
+	// every page is filtered by creation time, and the filtered instances are paginated
+	mustExecute(t, "kafka", "create", "test-kafka")
+	for _, tt := range []struct {
+		page  string
+		want  []string
+		total int
+	}{
+		{page: "1", want: []string{"dev-kafka", "prod-kafka"}, total: 3},
+		{page: "2", want: []string{"test-kafka"}, total: 3},
+	} {
+		out := mustExecute(t, "kafka", "list", "-o", "json", "--created-after", "2000-01-01", "--limit", "2", "--page", tt.page)
+
+		var list struct {
+			Total int `json:"total"`
+			Items []struct {
+				Name string `json:"name"`
+			} `json:"items"`
+		}
+		if err := json.Unmarshal([]byte(out), &list); err != nil {
+			t.Fatalf("could not parse kafka list output %q: %v", out, err)
+		}
+		got := []string{}
+		for _, item := range list.Items {
+			got = append(got, item.Name)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") || list.Total != tt.total {
+			t.Errorf("kafka list --page %v = %v of %v, want %v of %v", tt.page, got, list.Total, tt.want, tt.total)
+		}
+	}
+
+	if _, err := execute(t, "kafka", "list", "--filter", "created_at=2021-01-01"); err == nil {
+		t.Error("expected an error for an unsupported filter field")
+	}
+	if _, err := execute(t, "kafka", "list", "--search", "dev", "--status", "ready"); err == nil {
+		t.Error("expected an error when --search is used with a filter")
+	}
+}
+
+func TestCompletionBeyondFirstPageAgainstFake(t *testing.T) {
+	server := newFakeSession(t)
+
+	var kafka struct {
+		ID string `json:"id"`
+	}
+	out := mustExecute(t, "kafka", "create", "my-kafka", "-o", "json")
+	if err := json.Unmarshal([]byte(out), &kafka); err != nil {
+		t.Fatalf("could not parse kafka create output %q: %v", out, err)
+	}
+
+	// the fake lists 100 items per page by default
+	for i := 0; i < 150; i++ {
+		server.AddConsumerGroup(kafka.ID, kafkainstanceclient.ConsumerGroup{GroupId: fmt.Sprintf("group-%03d", i)})
+	}
+
+	// the first completion fills the cache, the second one is served from it
+	for i := 0; i < 2; i++ {
+		out = mustExecute(t, "__complete", "kafka", "consumer-group", "describe", "--id", "group-14")
+		for _, id := range []string{"group-140", "group-149"} {
+			if !strings.Contains(out, id) {
+				t.Errorf("completion %v does not contain the consumer group %v: %v", i, id, out)
+			}
+		}
+		if strings.Contains(out, "group-013") {
+			t.Errorf("completion %v contains a consumer group which does not match the prefix: %v", i, out)
+		}
+	}
+}
+
+func TestGenerateConfigOverwriteAgainstFake(t *testing.T) {
+	newFakeSession(t)
+	mustExecute(t, "kafka", "create", "my-kafka")
+
+	dir := t.TempDir()
+	t.Setenv("RHOAS_TEST_CONFIG_DIR", dir)
+	path := filepath.Join(dir, "app.env")
+	if err := os.WriteFile(path, []byte("existing"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// the variables in the path are expanded before checking if the file exists
+	if _, err := execute(t, "generate-config", "--type", "env", "--file-location", "$RHOAS_TEST_CONFIG_DIR/app.env"); err == nil {
+		t.Error("expected an error when the file exists and --overwrite is not set")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "existing" {
+		t.Errorf("the existing file was overwritten: %q", data)
+	}
+
+	mustExecute(t, "generate-config", "--type", "env", "--file-location", "$RHOAS_TEST_CONFIG_DIR/app.env", "--overwrite")
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "KAFKA_HOST") {
+		t.Errorf("the file was not overwritten: %q", data)
+	}
+}
+
+func TestLogHTTPToHARFileAgainstFake(t *testing.T) {
+	newFakeSession(t)
+
+	harFile := filepath.Join(t.TempDir(), "requests.har")
+	t.Setenv(loghttp.EnvName, harFile)
+
+	_, errOut, err := executeWithErrOut(t, "kafka", "list", "-o", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the requests are only recorded to the HAR file, they are not traced to the logs
+	if strings.Contains(errOut, "-->") || strings.Contains(errOut, "<--") {
+		t.Errorf("unexpected traces in the logs: %v", errOut)
+	}
+
+	data, err := os.ReadFile(harFile)
+	if err != nil {
+		t.Fatalf("HAR file not written: %v", err)
+	}
+	var har struct {
+		Log struct {
+			Entries []struct {
+				Request struct {
+					URL string `json:"url"`
+				} `json:"request"`
+			} `json:"entries"`
+		} `json:"log"`
+	}
+	if err = json.Unmarshal(data, &har); err != nil {
+		t.Fatalf("could not parse HAR file %q: %v", data, err)
+	}
+	recorded := false
+	for _, entry := range har.Log.Entries {
+		recorded = recorded || strings.Contains(entry.Request.URL, "/api/kafkas_mgmt/v1/kafkas")
+	}
+	if !recorded {
+		t.Errorf("the kafka list request is not recorded in the HAR file: %s", data)
+	}
+}
+
+func TestQuotaAgainstFake(t *testing.T) {
+	server := newFakeSession(t)
+
+	var status struct {
+		Quotas            []map[string]interface{} `json:"quotas"`
+		KafkaInstanceType string                   `json:"kafka_instance_type"`
+	}
+	out := mustExecute(t, "quota", "-o", "json")
+	if err := json.Unmarshal([]byte(out), &status); err != nil {
+		t.Fatalf("could not parse quota output %q: %v", out, err)
+	}
+	if len(status.Quotas) != 0 || status.KafkaInstanceType != "evaluation" {
+		t.Errorf("unexpected quota without quota: %v", out)
+	}
+
+	server.SetQuota(1, 1)
+	mustExecute(t, "kafka", "create", "my-kafka")
+
+	out = mustExecute(t, "quota", "-o", "json")
+	if err := json.Unmarshal([]byte(out), &status); err != nil {
+		t.Fatalf("could not parse quota output %q: %v", out, err)
+	}
+	if len(status.Quotas) != 2 || status.KafkaInstanceType != "evaluation" {
+		t.Errorf("unexpected quota once the Kafka quota is consumed: %v", out)
+	}
+	if status.Quotas[0]["service"] != "kafka" || status.Quotas[0]["consumed"] != float64(1) {
+		t.Errorf("unexpected Kafka quota: %v", status.Quotas[0])
+	}
+}
+
+func TestKafkaProvidersAndRegionsAgainstFake(t *testing.T) {
+	newFakeSession(t)
+
+	out := mustExecute(t, "kafka", "providers", "list")
+	if !strings.Contains(out, "Amazon Web Services") || !strings.Contains(out, "gcp") {
+		t.Errorf("unexpected cloud providers: %v", out)
+	}
+
+	var regions []struct {
+		ID                     string   `json:"id"`
+		Enabled                bool     `json:"enabled"`
+		SupportedInstanceTypes []string `json:"supported_instance_types"`
+		MultiAZ                bool     `json:"multi_az"`
+	}
+	out = mustExecute(t, "kafka", "regions", "list", "--provider", "aws", "-o", "json")
+	if err := json.Unmarshal([]byte(out), &regions); err != nil {
+		t.Fatalf("could not parse regions output %q: %v", out, err)
+	}
+	if len(regions) != 3 {
+		t.Fatalf("expected 3 regions, got %v", out)
+	}
+	if !regions[0].Enabled || !regions[0].MultiAZ {
+		t.Errorf("expected us-east-1 to be enabled and multi-AZ, got %+v", regions[0])
+	}
+	if !regions[1].Enabled || regions[1].MultiAZ || len(regions[1].SupportedInstanceTypes) != 1 {
+		t.Errorf("expected eu-west-1 to only support evaluation instances, got %+v", regions[1])
+	}
+	if regions[2].Enabled {
+		t.Errorf("expected ap-south-1 to be disabled, got %+v", regions[2])
+	}
+
+	if _, err := execute(t, "kafka", "regions", "list", "--provider", "asw"); err == nil || !strings.Contains(err.Error(), `Did you mean "aws"?`) {
+		t.Errorf("expected a suggestion for a mistyped provider, got %v", err)
+	}
+
+	_, err := execute(t, "kafka", "create", "my-kafka", "--provider", "aws", "--region", "eu-wst-1")
+	if err == nil || !strings.Contains(err.Error(), `Did you mean "eu-west-1"?`) {
+		t.Errorf("expected a suggestion for a mistyped region, got %v", err)
+	}
+	if _, err = execute(t, "kafka", "create", "my-kafka", "--provider", "gcp"); err == nil || !strings.Contains(err.Error(), "--provider") {
+		t.Errorf("expected an error for a disabled provider, got %v", err)
+	}
+
+	var kafka struct {
+		Region  string `json:"region"`
+		MultiAZ bool   `json:"multi_az"`
+	}
+	out = mustExecute(t, "kafka", "create", "my-kafka", "--region", "eu-west-1")
+	if err = json.Unmarshal([]byte(out), &kafka); err != nil {
+		t.Fatalf("could not parse create output %q: %v", out, err)
+	}
+	if kafka.Region != "eu-west-1" || kafka.MultiAZ {
+		t.Errorf("expected a single-AZ instance in eu-west-1, got %v", out)
+	}
+}
+
+func TestBulkDeleteAgainstFake(t *testing.T) {
+	server := newFakeSession(t)
+
+	for _, name := range []string{"ci-kafka-1", "ci-kafka-2", "prod-kafka"} {
+		mustExecute(t, "kafka", "create", name)
+	}
+
+	if _, err := execute(t, "kafka", "delete", "prod-kafka", "--all", "--yes"); err == nil {
+		t.Error("expected an error when a Kafka instance is named along with --all")
+	}
+	if _, err := execute(t, "kafka", "delete", "--selector", "owner=me", "--yes"); err == nil {
+		t.Error("expected an error for an unsupported selector")
+	}
+
+	// the instances were just created, so none of them is old enough
+	out := mustExecute(t, "kafka", "delete", "--all", "--older-than", "24h", "--yes")
+	if strings.Contains(out, "ci-kafka-1") {
+		t.Errorf("expected no Kafka instance to match, got %v", out)
+	}
+
+	out = mustExecute(t, "kafka", "delete", "--selector", "name-prefix=ci-", "--owner", "me", "--yes")
+	if !strings.Contains(out, "ci-kafka-1") || !strings.Contains(out, "ci-kafka-2") || strings.Contains(out, "prod-kafka") {
+		t.Errorf("unexpected Kafka instances matched: %v", out)
+	}
+	out = mustExecute(t, "kafka", "list", "-o", "json")
+	if strings.Contains(out, "ci-kafka") || !strings.Contains(out, "prod-kafka") {
+		t.Errorf("unexpected Kafka instances after the bulk deletion: %v", out)
+	}
+
+	// prod-kafka is the current instance, as it was created last
+	for _, name := range []string{"ci-topic-1", "ci-topic-2", "prod-topic"} {
+		mustExecute(t, "kafka", "topic", "create", name)
+	}
+	if _, err := execute(t, "kafka", "topic", "delete"); err == nil {
+		t.Error("expected an error when neither a topic nor a selection is given")
+	}
+	mustExecute(t, "kafka", "topic", "delete", "--selector", "name-prefix=ci-", "--yes")
+	out = mustExecute(t, "kafka", "topic", "list", "-o", "json")
+	if strings.Contains(out, "ci-topic") || !strings.Contains(out, "prod-topic") {
+		t.Errorf("unexpected topics after the bulk deletion: %v", out)
+	}
+
+	var kafka struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(mustExecute(t, "kafka", "describe", "-o", "json")), &kafka); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"ci-group-1", "ci-group-2"} {
+		server.AddConsumerGroup(kafka.ID, kafkainstanceclient.ConsumerGroup{GroupId: id})
+	}
+	mustExecute(t, "kafka", "consumer-group", "delete", "--all", "--yes")
+	out = mustExecute(t, "kafka", "consumer-group", "list", "-o", "json")
+	if strings.Contains(out, "ci-group") {
+		t.Errorf("unexpected consumer groups after the bulk deletion: %v", out)
+	}
+
+	for _, name := range []string{"ci-sa-1", "ci-sa-2"} {
+		mustExecute(t, "service-account", "create", "--name", name, "--file-format", "env", "--file-location", filepath.Join(t.TempDir(), name+".env"))
+	}
+	mustExecute(t, "service-account", "delete", "--all", "--owner", "me", "--yes")
+	out = mustExecute(t, "service-account", "list", "-o", "json")
+	if strings.Contains(out, "ci-sa") {
+		t.Errorf("unexpected service accounts after the bulk deletion: %v", out)
+	}
+
+	if _, err := execute(t, "service-account", "delete", "--all"); err == nil {
+		t.Error("expected an error when --yes is not set and the CLI cannot prompt")
+	}
+}
+
+func TestServiceAccountListAndPruneAgainstFake(t *testing.T) {
+	newFakeSession(t)
+
+	credentialsDir := t.TempDir()
+	for _, name := range []string{"alpha", "beta", "rhoascli-1", "rhoascli-2"} {
+		// only the credentials of rhoascli-1 are kept in the credentials directory
+		dir := t.TempDir()
+		if name == "rhoascli-1" {
+			dir = credentialsDir
+		}
+		mustExecute(t, "service-account", "create", "--name", name, "--file-format", "env", "--file-location", filepath.Join(dir, name+".env"))
+	}
+
+	listNames := func(args ...string) []string {
+		var list struct {
+			Items []struct {
+				Name string `json:"name"`
+			} `json:"items"`
+		}
+		out := mustExecute(t, append([]string{"service-account", "list", "-o", "json"}, args...)...)
+		if err := json.Unmarshal([]byte(out), &list); err != nil {
+			t.Fatalf("could not parse service account list output %q: %v", out, err)
+		}
+		names := []string{}
+		for _, sa := range list.Items {
+			names = append(names, sa.Name)
+		}
+		return names
+	}
+
+	if names := listNames("--search", "ALP"); strings.Join(names, ",") != "alpha" {
+		t.Errorf("unexpected service accounts matching the search: %v", names)
+	}
+	if names := listNames("--owner", "me", "--sort-by", "name", "--desc"); strings.Join(names, ",") != "rhoascli-2,rhoascli-1,beta,alpha" {
+		t.Errorf("unexpected service accounts sorted by name in descending order: %v", names)
+	}
+	if names := listNames("--sort-by", "name", "--limit", "1", "--page", "2"); strings.Join(names, ",") != "beta" {
+		t.Errorf("unexpected second page of service accounts: %v", names)
+	}
+	if names := listNames("--created-before", "2000-01-01"); len(names) != 0 {
+		t.Errorf("expected no service account created before 2000, got %v", names)
+	}
+	if names := listNames("--owner", "someone-else"); len(names) != 0 {
+		t.Errorf("expected no service account owned by another user, got %v", names)
+	}
+	if _, err := execute(t, "service-account", "list", "--sort-by", "client-id"); err == nil {
+		t.Error("expected an error for an unsupported sort field")
+	}
+
+	if _, err := execute(t, "service-account", "prune", "--kubeconfig", filepath.Join(t.TempDir(), "missing"), "--credentials-path", credentialsDir, "--yes"); err == nil {
+		t.Error("expected an error when the Kubernetes cluster cannot be checked")
+	}
+
+	out := mustExecute(t, "service-account", "prune", "--skip-cluster", "--credentials-path", credentialsDir, "--dry-run")
+	if !strings.Contains(out, "rhoascli-2") || strings.Contains(out, "rhoascli-1") || strings.Contains(out, "alpha") {
+		t.Errorf("unexpected service accounts to prune: %v", out)
+	}
+	if names := listNames(); len(names) != 4 {
+		t.Errorf("expected the dry run not to delete any service account, got %v", names)
+	}
+
+	mustExecute(t, "service-account", "prune", "--skip-cluster", "--credentials-path", credentialsDir, "--yes")
+	if names := listNames("--sort-by", "name"); strings.Join(names, ",") != "alpha,beta,rhoascli-1" {
+		t.Errorf("unexpected service accounts after pruning: %v", names)
+	}
+}
+
+func TestServiceAccountCredentialsTargetsAgainstFake(t *testing.T) {
+	newFakeSession(t)
+
+	out := mustExecute(t, "service-account", "create", "--name", "piped", "--file-format", "json", "--target", "stdout")
+	var creds struct {
+		ClientID string `json:"clientID"`
+	}
+	if err := json.Unmarshal([]byte(out), &creds); err != nil || creds.ClientID == "" {
+		t.Fatalf("expected the credentials to be printed as JSON, got %q: %v", out, err)
+	}
+
+	if _, err := execute(t, "service-account", "create", "--name", "unknown-target", "--file-format", "json", "--target", "ftp"); err == nil {
+		t.Error("expected an error for an unsupported target")
+	}
+	if _, err := execute(t, "service-account", "create", "--name", "no-vault-path", "--target", "vault"); err == nil {
+		t.Error("expected an error when the Vault path is not set")
+	}
+	if _, err := execute(t, "service-account", "create", "--name", "no-cluster", "--target", "kubernetes", "--kubeconfig", filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected an error when the Kubernetes cluster cannot be reached")
+	}
+
+	// the service account is not created when the credentials cannot be saved
+	var list struct {
+		Items []struct {
+			Name string `json:"name"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(mustExecute(t, "service-account", "list", "-o", "json")), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 1 || list.Items[0].Name != "piped" {
+		t.Errorf("unexpected service accounts %v", list.Items)
+	}
+}
+
+func TestServiceAccountCredentialFormatsAgainstFake(t *testing.T) {
+	newFakeSession(t)
+
+	mustExecute(t, "kafka", "create", "my-kafka")
+
+	out := mustExecute(t, "service-account", "create", "--name", "yaml-sa", "--file-format", "yaml", "--target", "stdout")
+	var doc map[string]string
+	if err := yaml.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("could not parse the credentials %q: %v", out, err)
+	}
+	if doc["clientID"] == "" || doc["oauthTokenEndpointURI"] == "" {
+		t.Errorf("expected the credentials along with the token endpoint of the selected Kafka instance, got %v", doc)
+	}
+
+	template := filepath.Join(t.TempDir(), "app.tmpl")
+	if err := os.WriteFile(template, []byte("id={{.ClientID}}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	out = mustExecute(t, "service-account", "create", "--name", "template-sa", "--file-format", "template", "--template", template, "--target", "stdout")
+	if !strings.HasPrefix(out, "id=") {
+		t.Errorf("expected the credentials rendered by the template, got %q", out)
+	}
+
+	if _, err := execute(t, "service-account", "create", "--name", "no-template", "--file-format", "template"); err == nil {
+		t.Error("expected an error when the template is not set")
+	}
+}
+
+func TestErrorCodesAgainstFake(t *testing.T) {
+	newFakeSession(t)
+
+	tests := []struct {
+		name string
+		args []string
+		want clierr.Code
+	}{
+		{name: "unknown flag", args: []string{"kafka", "list", "--unknown"}, want: clierr.CodeValidation},
+		{name: "invalid flag value", args: []string{"kafka", "list", "-o", "xml"}, want: clierr.CodeValidation},
+		{name: "too many arguments", args: []string{"kafka", "describe", "a", "b"}, want: clierr.CodeValidation},
+		{name: "unexpected argument", args: []string{"kafka", "list", "a"}, want: clierr.CodeValidation},
+		{name: "missing arguments", args: []string{"kafka", "diff", "a"}, want: clierr.CodeValidation},
+		{name: "unknown Kafka instance ID", args: []string{"kafka", "describe", "--id", "unknown"}, want: clierr.CodeNotFound},
+		{name: "unknown Kafka instance name", args: []string{"kafka", "describe", "unknown"}, want: clierr.CodeNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := execute(t, tt.args...)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if got := clierr.Describe(err).Code; got != tt.want {
+				t.Errorf("code = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKafkaMetricsAgainstFake(t *testing.T) {
+	newFakeSession(t)
+
+	mustExecute(t, "kafka", "create", "my-kafka")
+	mustExecute(t, "kafka", "topic", "create", "orders", "--partitions", "3")
+	mustExecute(t, "kafka", "topic", "create", "payments", "--partitions", "2")
+
+	var summary struct {
+		BytesIn      float64 `json:"bytes_in"`
+		Partitions   float64 `json:"partitions"`
+		StorageUsed  float64 `json:"storage_used_bytes"`
+		StorageLimit float64 `json:"storage_limit_bytes"`
+		Connections  float64 `json:"connections"`
+		Topics       []struct {
+			Topic    string  `json:"topic"`
+			Messages float64 `json:"messages"`
+		} `json:"topics"`
+	}
+	out := mustExecute(t, "kafka", "metrics", "my-kafka", "-o", "json")
+	if err := json.Unmarshal([]byte(out), &summary); err != nil {
+		t.Fatalf("could not parse kafka metrics output %q: %v", out, err)
+	}
+	if summary.BytesIn != 2*fake.BrokerBytesIn || summary.Partitions != 5 || summary.StorageUsed != 2*fake.BrokerStorageUsed ||
+		summary.StorageLimit != 2*fake.BrokerStorageLimit || summary.Connections != fake.InstanceConnections {
+		t.Errorf("unexpected metrics: %+v", summary)
+	}
+	if len(summary.Topics) != 2 || summary.Topics[0].Topic != "orders" || summary.Topics[0].Messages != 2*fake.BrokerTopicMessages {
+		t.Errorf("unexpected messages per topic: %+v", summary.Topics)
+	}
+
+	out = mustExecute(t, "kafka", "metrics")
+	if !strings.Contains(out, "200.0 MiB (9.8%)") || !strings.Contains(out, "payments") {
+		t.Errorf("kafka metrics table does not contain the storage used and the topics: %v", out)
+	}
+
+	var summaries []struct {
+		Timestamp string  `json:"timestamp"`
+		BytesOut  float64 `json:"bytes_out"`
+	}
+	out = mustExecute(t, "kafka", "metrics", "--range", "1h", "--step", "10m", "-o", "json")
+	if err := json.Unmarshal([]byte(out), &summaries); err != nil {
+		t.Fatalf("could not parse kafka metrics output %q: %v", out, err)
+	}
+	if len(summaries) != 7 || summaries[0].Timestamp == "" || summaries[6].BytesOut != 2*fake.BrokerBytesOut {
+		t.Errorf("unexpected metrics over the range: %+v", summaries)
+	}
+
+	out = mustExecute(t, "kafka", "metrics", "-o", "prometheus")
+	if !strings.Contains(out, "# TYPE kafka_server_brokertopicmetrics_bytes_in_total counter\n") ||
+		!strings.Contains(out, `kafka_server_brokertopicmetrics_messages_in_total{broker="1",topic="payments"} 10 `) {
+		t.Errorf("unexpected Prometheus output: %v", out)
+	}
+
+	for _, args := range [][]string{
+		{"kafka", "metrics", "--step", "1m"},
+		{"kafka", "metrics", "--range", "90s"},
+		{"kafka", "metrics", "--range", "100h"},
+		{"kafka", "metrics", "--range", "1h", "--step", "2h"},
+	} {
+		if _, err := execute(t, args...); clierr.Describe(err).Code != clierr.CodeValidation {
+			t.Errorf("rhoas %v: expected a validation error, got %v", strings.Join(args, " "), err)
+		}
+	}
+}
+
+func TestKafkaDiffAgainstFake(t *testing.T) {
+	server := newFakeSession(t)
+
+	var staging, production struct {
+		ID string `json:"id"`
+	}
+	out := mustExecute(t, "kafka", "create", "staging", "-o", "json")
+	if err := json.Unmarshal([]byte(out), &staging); err != nil {
+		t.Fatalf("could not parse kafka create output %q: %v", out, err)
+	}
+	mustExecute(t, "kafka", "topic", "create", "orders", "--partitions", "3")
+	mustExecute(t, "kafka", "topic", "create", "payments", "--retention-ms", "3600000")
+	server.AddConsumerGroup(staging.ID, kafkainstanceclient.ConsumerGroup{GroupId: "billing"})
+
+	out = mustExecute(t, "kafka", "create", "production", "-o", "json")
+	if err := json.Unmarshal([]byte(out), &production); err != nil {
+		t.Fatalf("could not parse kafka create output %q: %v", out, err)
+	}
+	mustExecute(t, "kafka", "topic", "create", "orders", "--partitions", "3")
+	mustExecute(t, "kafka", "topic", "create", "payments")
+	mustExecute(t, "kafka", "topic", "create", "analytics")
+
+	// the instances are resolved by name or by ID
+	out, err := execute(t, "kafka", "diff", "staging", production.ID)
+	if got := clierr.Describe(err).ExitCode; got != 10 {
+		t.Fatalf("expected the exit code 10 when the instances are different, got %v (%v)", got, err)
+	}
+	want := `--- staging (` + staging.ID + `)
++++ production (` + production.ID + `)
+@@ topics @@
++analytics
+@@ topic payments @@
+-retention.ms=3600000
++retention.ms=604800000
+@@ consumer groups @@
+-billing
+`
+	if out != want {
+		t.Errorf("unexpected kafka diff output:\n%v\nwant\n%v", out, want)
+	}
+
+	var r struct {
+		Equal       bool `json:"equal"`
+		Differences []struct {
+			Resource string  `json:"resource"`
+			Name     string  `json:"name"`
+			A        *string `json:"a"`
+		} `json:"differences"`
+	}
+	out, _ = execute(t, "kafka", "diff", staging.ID, "production", "-o", "json")
+	if err = json.Unmarshal([]byte(out), &r); err != nil {
+		t.Fatalf("could not parse kafka diff output %q: %v", out, err)
+	}
+	if r.Equal || len(r.Differences) != 3 || r.Differences[0].Name != "analytics" || r.Differences[0].A != nil {
+		t.Errorf("unexpected differences: %+v", r)
+	}
+
+	// the YAML output uses the same keys as the JSON output
+	out, _ = execute(t, "kafka", "diff", staging.ID, "production", "-o", "yaml")
+	if !strings.Contains(out, "resource: topic") || !strings.Contains(out, "key: retention.ms") || strings.Contains(out, "key: \"\"") {
+		t.Errorf("unexpected kafka diff YAML output:\n%v", out)
+	}
+
+	mustExecute(t, "kafka", "diff", "production", "production")
+
+	if _, err = execute(t, "kafka", "diff", "staging", "unknown"); clierr.Describe(err).Code != clierr.CodeNotFound {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+
+	// the second instance is in another environment, which has its own config file
+	other := fake.NewServer()
+	t.Cleanup(other.Close)
+	otherCfg := &config.Config{}
+	other.Login(otherCfg)
+	otherPath := filepath.Join(t.TempDir(), "other.json")
+	if err = config.NewFileAt(otherPath).Save(otherCfg); err != nil {
+		t.Fatal(err)
+	}
+	currentPath := os.Getenv(config.EnvName)
+	t.Setenv(config.EnvName, otherPath)
+	mustExecute(t, "kafka", "create", "production")
+	mustExecute(t, "kafka", "topic", "create", "orders", "--partitions", "3")
+	mustExecute(t, "kafka", "topic", "create", "payments", "--retention-ms", "3600000")
+	t.Setenv(config.EnvName, currentPath)
+
+	if _, err = execute(t, "kafka", "diff", "staging", "production", "--config-b", otherPath); clierr.Describe(err).Code != clierr.CodeDifferences {
+		t.Fatalf("expected differences, got %v", err)
+	}
+	other.AddConsumerGroup(otherKafkaID(t, otherPath), kafkainstanceclient.ConsumerGroup{GroupId: "billing"})
+	mustExecute(t, "kafka", "diff", "staging", "production", "--config-b", otherPath)
+}
+
+// otherKafkaID returns the ID of the Kafka instance selected in the config file
+func otherKafkaID(t *testing.T, path string) string {
+	cfg, err := config.NewFileAt(path).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfg.Services.Kafka.ClusterID
+}
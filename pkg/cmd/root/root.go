@@ -12,13 +12,22 @@ import (
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/whoami"
 
 	"github.com/redhat-developer/app-services-cli/pkg/arguments"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/auth"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/cluster"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/completion"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/dev"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
+	cmdflag "github.com/redhat-developer/app-services-cli/pkg/cmd/flag"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/generateconfig"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/kafka"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/logflags"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/logout"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/plugin"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/quota"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/serviceaccount"
 	cliversion "github.com/redhat-developer/app-services-cli/pkg/cmd/version"
+	flagutil "github.com/redhat-developer/app-services-cli/pkg/cmdutil/flags"
+	"github.com/redhat-developer/app-services-cli/pkg/common/clierr"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -31,9 +40,24 @@ func NewRootCommand(f *factory.Factory, version string) *cobra.Command {
 		Short:         f.Localizer.MustLocalize("root.cmd.shortDescription"),
 		Long:          f.Localizer.MustLocalize("root.cmd.longDescription"),
 		Example:       f.Localizer.MustLocalize("root.cmd.example"),
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			if !flagutil.IsValidInput(logflags.Format(), logflags.Formats...) {
+				return cmdflag.InvalidValueError("log-format", logflags.Format(), logflags.Formats...)
+			}
+			logflags.SetCommandPath(cmd.CommandPath())
+			return nil
+		},
 	}
+	// unknown flags and invalid flag values are validation errors
+	cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return clierr.New(clierr.CodeValidation, err)
+	})
+
 	fs := cmd.PersistentFlags()
 	arguments.AddDebugFlag(fs)
+	arguments.AddLogFlags(fs, f.Localizer.MustLocalize("root.cmd.flag.logFormat.description"), f.Localizer.MustLocalize("root.cmd.flag.logFile.description"))
+	arguments.AddLogHTTPFlag(fs, f.Localizer.MustLocalize("root.cmd.flag.logHTTP.description"))
+	arguments.AddRetryFlag(fs, f.Localizer.MustLocalize("root.cmd.flag.maxRetries.description"))
 	// this flag comes out of the box, but has its own basic usage text, so this overrides that
 	var help bool
 
@@ -52,13 +76,39 @@ func NewRootCommand(f *factory.Factory, version string) *cobra.Command {
 	cmd.AddCommand(serviceaccount.NewServiceAccountCommand(f))
 	cmd.AddCommand(cluster.NewClusterCommand(f))
 	cmd.AddCommand(status.NewStatusCommand(f))
+	cmd.AddCommand(quota.NewQuotaCommand(f))
 	cmd.AddCommand(completion.NewCompletionCommand(f))
 	cmd.AddCommand(whoami.NewWhoAmICmd(f))
+	cmd.AddCommand(auth.NewAuthCommand(f))
 	cmd.AddCommand(cliversion.NewVersionCmd(f))
 	cmd.AddCommand(config.NewConfigCommand(f))
+	cmd.AddCommand(generateconfig.NewGenerateConfigCommand(f))
+	cmd.AddCommand(dev.NewDevCommand(f))
+	cmd.AddCommand(plugin.NewPluginCommand(f))
 
 	// Early stage/dev preview commands
 	cmd.AddCommand(registry.NewServiceRegistryCommand(f))
 
+	// Plugins are added last, as they cannot override the built-in commands
+	plugin.AddPluginCommands(cmd, f)
+
+	// invalid positional arguments are validation errors, like the invalid flags
+	wrapArgsErrors(cmd)
+
 	return cmd
 }
+
+// wrapArgsErrors tags the errors of the positional arguments validation of the command and its children
+func wrapArgsErrors(cmd *cobra.Command) {
+	if validateArgs := cmd.Args; validateArgs != nil {
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+			if err := validateArgs(cmd, args); err != nil {
+				return clierr.New(clierr.CodeValidation, err)
+			}
+			return nil
+		}
+	}
+	for _, child := range cmd.Commands() {
+		wrapArgsErrors(child)
+	}
+}
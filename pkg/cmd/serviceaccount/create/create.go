@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
 
 	"github.com/redhat-developer/app-services-cli/pkg/localize"
 	"github.com/redhat-developer/app-services-cli/pkg/serviceaccount/validation"
@@ -16,10 +15,12 @@ import (
 	flagutil "github.com/redhat-developer/app-services-cli/pkg/cmdutil/flags"
 	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
 	"github.com/redhat-developer/app-services-cli/pkg/serviceaccount/credentials"
+	"github.com/redhat-developer/app-services-cli/pkg/serviceaccount/serviceaccountutil"
 
 	"github.com/redhat-developer/app-services-cli/internal/config"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/flag"
+	saflags "github.com/redhat-developer/app-services-cli/pkg/cmd/serviceaccount/flags"
 	"github.com/redhat-developer/app-services-cli/pkg/logging"
 	"github.com/spf13/cobra"
 )
@@ -31,11 +32,13 @@ type Options struct {
 	Logger     func() (logging.Logger, error)
 	localizer  localize.Localizer
 
-	fileFormat  string
-	overwrite   bool
-	name        string
-	description string
-	filename    string
+	fileFormat   string
+	templatePath string
+	overwrite    bool
+	name         string
+	description  string
+	filename     string
+	target       saflags.TargetOptions
 
 	interactive bool
 }
@@ -69,7 +72,7 @@ func NewCreateCommand(f *factory.Factory) *cobra.Command {
 					Localizer: opts.localizer,
 				}
 
-				if opts.fileFormat == "" {
+				if opts.fileFormat == "" && opts.target.UsesFileFormat() {
 					return errors.New(opts.localizer.MustLocalize("flag.error.requiredWhenNonInteractive", localize.NewEntry("Flag", "file-format")))
 				}
 
@@ -81,11 +84,18 @@ func NewCreateCommand(f *factory.Factory) *cobra.Command {
 				}
 			}
 
+			if err = opts.target.Validate(opts.localizer); err != nil {
+				return err
+			}
+
 			// check that a valid --file-format flag value is used
 			validOutput := flagutil.IsValidInput(opts.fileFormat, flagutil.CredentialsOutputFormats...)
 			if !validOutput && opts.fileFormat != "" {
 				return flag.InvalidValueError("file-format", opts.fileFormat, flagutil.CredentialsOutputFormats...)
 			}
+			if !opts.interactive && opts.fileFormat == credentials.TemplateFormat && opts.templatePath == "" {
+				return errors.New(opts.localizer.MustLocalize("serviceAccount.common.error.templateRequired"))
+			}
 
 			return runCreate(opts)
 		},
@@ -96,6 +106,9 @@ func NewCreateCommand(f *factory.Factory) *cobra.Command {
 	cmd.Flags().BoolVar(&opts.overwrite, "overwrite", false, opts.localizer.MustLocalize("serviceAccount.common.flag.overwrite.description"))
 	cmd.Flags().StringVar(&opts.filename, "file-location", "", opts.localizer.MustLocalize("serviceAccount.common.flag.fileLocation.description"))
 	cmd.Flags().StringVar(&opts.fileFormat, "file-format", "", opts.localizer.MustLocalize("serviceAccount.common.flag.fileFormat.description"))
+	cmd.Flags().StringVar(&opts.templatePath, "template", "", opts.localizer.MustLocalize("serviceAccount.common.flag.template.description"))
+
+	saflags.AddTargetFlags(cmd, &opts.target, opts.localizer)
 
 	flagutil.EnableStaticFlagCompletion(cmd, "file-format", flagutil.CredentialsOutputFormats)
 
@@ -120,16 +133,35 @@ func runCreate(opts *Options) error {
 		if err != nil {
 			return err
 		}
-	} else if opts.filename == "" {
+	} else if opts.filename == "" && opts.target.Target == saflags.TargetFile {
 		// obtain the absolute path to where credentials will be saved
 		opts.filename = credentials.GetDefaultPath(opts.fileFormat)
 	}
 
-	// If the credentials file already exists, and the --overwrite flag is not set then return an error
-	// indicating that the user should explicitly request overwriting of the file
-	_, err = os.Stat(opts.filename)
-	if err == nil && !opts.overwrite {
-		return errors.New(opts.localizer.MustLocalize("serviceAccount.common.error.credentialsFileAlreadyExists", localize.NewEntry("FilePath", opts.filename)))
+	sink, err := opts.target.NewSink(opts.IO, opts.localizer, &saflags.SinkArgs{
+		FileFormat:   opts.fileFormat,
+		TemplatePath: opts.templatePath,
+		FileLocation: opts.filename,
+		Overwrite:    opts.overwrite,
+		SecretName:   opts.name,
+	})
+	if err != nil {
+		return err
+	}
+
+	// If the credentials already exist, and the --overwrite flag is not set then return an error
+	// indicating that the user should explicitly request overwriting of the credentials
+	if err = sink.Check(context.Background()); err != nil {
+		return saflags.CheckError(opts.localizer, sink, err)
+	}
+
+	cfg, err := opts.Config.Load()
+	if err != nil {
+		return err
+	}
+	bootstrapServerHost, tokenURL, err := serviceaccountutil.KafkaDetails(context.Background(), connection.API().Kafka(), cfg)
+	if err != nil {
+		logger.Warn(opts.localizer.MustLocalize("serviceAccount.common.log.warn.kafkaDetailsUnavailable", localize.NewEntry("ErrorMessage", err)))
 	}
 
 	// create the service account
@@ -145,17 +177,20 @@ func runCreate(opts *Options) error {
 	logger.Info(opts.localizer.MustLocalize("serviceAccount.create.log.info.createdSuccessfully", localize.NewEntry("ID", serviceacct.GetId()), localize.NewEntry("Name", serviceacct.GetName())))
 
 	creds := &credentials.Credentials{
-		ClientID:     serviceacct.GetClientId(),
-		ClientSecret: serviceacct.GetClientSecret(),
+		ClientID:            serviceacct.GetClientId(),
+		ClientSecret:        serviceacct.GetClientSecret(),
+		BootstrapServerHost: bootstrapServerHost,
+		TokenURL:            tokenURL,
 	}
 
-	// save the credentials to a file
-	err = credentials.Write(opts.fileFormat, opts.filename, creds)
-	if err != nil {
-		return fmt.Errorf("%v: %w", opts.localizer.MustLocalize("serviceAccount.common.error.couldNotSaveCredentialsFile"), err)
+	// save the credentials to the target
+	if err = sink.Write(context.Background(), creds); err != nil {
+		return fmt.Errorf("%v: %w", opts.localizer.MustLocalize("serviceAccount.common.error.couldNotSaveCredentials", localize.NewEntry("Location", sink.Location())), err)
 	}
 
-	logger.Info(opts.localizer.MustLocalize("serviceAccount.common.log.info.credentialsSaved", localize.NewEntry("FilePath", opts.filename)))
+	if opts.target.Target != saflags.TargetStdout {
+		logger.Info(opts.localizer.MustLocalize("serviceAccount.common.log.info.credentialsSaved", localize.NewEntry("FilePath", sink.Location())))
+	}
 
 	return nil
 }
@@ -188,7 +223,7 @@ func runInteractivePrompt(opts *Options) (err error) {
 	}
 
 	// if the --file-format flag was not used, ask in the prompt
-	if opts.fileFormat == "" {
+	if opts.fileFormat == "" && opts.target.UsesFileFormat() {
 		logger.Debug(opts.localizer.MustLocalize("serviceAccount.common.log.debug.interactive.fileFormatNotSet"))
 
 		fileFormatPrompt := &survey.Select{
@@ -204,9 +239,23 @@ func runInteractivePrompt(opts *Options) (err error) {
 		}
 	}
 
-	opts.filename, opts.overwrite, err = credentials.ChooseFileLocation(opts.fileFormat, opts.filename, opts.overwrite)
-	if err != nil {
-		return err
+	if opts.fileFormat == credentials.TemplateFormat && opts.templatePath == "" {
+		templatePrompt := &survey.Input{
+			Message: opts.localizer.MustLocalize("serviceAccount.common.input.templatePath.message"),
+			Help:    opts.localizer.MustLocalize("serviceAccount.common.flag.template.description"),
+		}
+
+		err = survey.AskOne(templatePrompt, &opts.templatePath, survey.WithValidator(survey.Required))
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.target.Target == saflags.TargetFile {
+		opts.filename, opts.overwrite, err = credentials.ChooseFileLocation(opts.fileFormat, opts.filename, opts.overwrite)
+		if err != nil {
+			return err
+		}
 	}
 
 	promptDescription := &survey.Multiline{
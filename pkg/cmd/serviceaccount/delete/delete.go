@@ -3,11 +3,14 @@ package delete
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/redhat-developer/app-services-cli/internal/config"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/flag"
+	"github.com/redhat-developer/app-services-cli/pkg/cmdutil/bulk"
+	"github.com/redhat-developer/app-services-cli/pkg/common/clierr"
 	"github.com/redhat-developer/app-services-cli/pkg/connection"
 	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
 	"github.com/redhat-developer/app-services-cli/pkg/localize"
@@ -25,6 +28,7 @@ type Options struct {
 
 	id    string
 	force bool
+	bulk  bulk.Options
 }
 
 // NewDeleteCommand creates a new command to delete a service account
@@ -48,6 +52,23 @@ func NewDeleteCommand(f *factory.Factory) *cobra.Command {
 				return flag.RequiredWhenNonInteractiveError("yes")
 			}
 
+			if opts.bulk.Enabled() {
+				if opts.id != "" {
+					return errors.New(opts.localizer.MustLocalize("bulk.error.nameWithSelection", localize.NewEntry("Field", opts.localizer.MustLocalize("serviceAccount.delete.bulk.field"))))
+				}
+				if err := opts.bulk.Validate(opts.localizer); err != nil {
+					return err
+				}
+				return runBulkDelete(opts)
+			}
+
+			if opts.id == "" {
+				return errors.New(opts.localizer.MustLocalize("bulk.error.nameRequired",
+					localize.NewEntry("Field", opts.localizer.MustLocalize("serviceAccount.delete.bulk.field")),
+					localize.NewEntry("Kind", opts.localizer.MustLocalize("serviceAccount.delete.bulk.kind")),
+				))
+			}
+
 			validator := &validation.Validator{
 				Localizer: opts.localizer,
 			}
@@ -63,8 +84,7 @@ func NewDeleteCommand(f *factory.Factory) *cobra.Command {
 
 	cmd.Flags().StringVar(&opts.id, "id", "", opts.localizer.MustLocalize("serviceAccount.delete.flag.id.description"))
 	cmd.Flags().BoolVarP(&opts.force, "yes", "y", false, opts.localizer.MustLocalize("serviceAccount.delete.flag.yes.description"))
-
-	_ = cmd.MarkFlagRequired("id")
+	bulk.AddFlags(cmd, &opts.bulk, opts.localizer, opts.localizer.MustLocalize("serviceAccount.delete.bulk.kind"), true)
 
 	return cmd
 }
@@ -87,7 +107,7 @@ func runDelete(opts *Options) (err error) {
 		}
 
 		if httpRes.StatusCode == 404 {
-			return errors.New(opts.localizer.MustLocalize("serviceAccount.common.error.notFoundError", localize.NewEntry("ID", opts.id)))
+			return clierr.New(clierr.CodeNotFound, errors.New(opts.localizer.MustLocalize("serviceAccount.common.error.notFoundError", localize.NewEntry("ID", opts.id))))
 		}
 	}
 
@@ -130,9 +150,9 @@ func deleteServiceAccount(opts *Options) error {
 
 		switch httpRes.StatusCode {
 		case 403:
-			return errors.New(opts.localizer.MustLocalize("serviceAccount.common.error.forbidden", localize.NewEntry("Operation", "delete")))
+			return clierr.New(clierr.CodeForbidden, errors.New(opts.localizer.MustLocalize("serviceAccount.common.error.forbidden", localize.NewEntry("Operation", "delete"))))
 		case 500:
-			return errors.New(opts.localizer.MustLocalize("serviceAccount.common.error.internalServerError"))
+			return clierr.New(clierr.CodeServiceUnavailable, errors.New(opts.localizer.MustLocalize("serviceAccount.common.error.internalServerError")))
 		default:
 			return err
 		}
@@ -142,3 +162,57 @@ func deleteServiceAccount(opts *Options) error {
 
 	return nil
 }
+
+// runBulkDelete deletes the service accounts selected by the bulk deletion flags
+func runBulkDelete(opts *Options) error {
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := opts.Config.Load()
+	if err != nil {
+		return err
+	}
+	if err = opts.bulk.ResolveOwner(cfg, opts.localizer); err != nil {
+		return err
+	}
+
+	api := conn.API().ServiceAccount()
+
+	res, _, err := api.GetServiceAccounts(context.Background()).Execute()
+	if err != nil {
+		return err
+	}
+
+	resources := make([]bulk.Resource, len(res.GetItems()))
+	for i, sa := range res.GetItems() {
+		resources[i] = bulk.Resource{
+			ID:        sa.GetId(),
+			Name:      sa.GetName(),
+			Owner:     sa.GetOwner(),
+			CreatedAt: sa.GetCreatedAt(),
+		}
+	}
+
+	deletion := &bulk.Deletion{
+		IO:        opts.IO,
+		Logger:    logger,
+		Localizer: opts.localizer,
+		Kind:      opts.localizer.MustLocalize("serviceAccount.delete.bulk.kind"),
+		Resources: opts.bulk.Match(resources, time.Now()),
+		Force:     opts.force,
+		Delete: func(ctx context.Context, r bulk.Resource) error {
+			_, _, err := api.DeleteServiceAccountById(ctx, r.ID).Execute()
+			return err
+		},
+	}
+	_, err = deletion.Run()
+
+	return err
+}
@@ -0,0 +1,205 @@
+// flags package contains the command line flags shared by the service account commands
+package flags
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/redhat-developer/app-services-cli/pkg/cluster"
+	clusterflags "github.com/redhat-developer/app-services-cli/pkg/cmd/cluster/flags"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/flag"
+	flagutil "github.com/redhat-developer/app-services-cli/pkg/cmdutil/flags"
+	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
+	"github.com/redhat-developer/app-services-cli/pkg/localize"
+	"github.com/redhat-developer/app-services-cli/pkg/serviceaccount/credentials"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// FlagTarget is a flag representing where the credentials are written
+	FlagTarget = "target"
+	// FlagSecretName is a flag representing the name of the Kubernetes secret
+	FlagSecretName = "secret-name"
+	// FlagNamespace is a flag representing the namespace of the Kubernetes secret
+	FlagNamespace = "namespace"
+	// FlagSealCert is a flag representing the path to the certificate of the Sealed Secrets controller
+	FlagSealCert = "seal-cert"
+	// FlagVaultAddress is a flag representing the address of the Vault server
+	FlagVaultAddress = "vault-address"
+	// FlagVaultPath is a flag representing the path of the Vault secret
+	FlagVaultPath = "vault-path"
+)
+
+// Targets which the credentials can be written to
+const (
+	TargetFile       = "file"
+	TargetStdout     = "stdout"
+	TargetKubernetes = "kubernetes"
+	TargetVault      = "vault"
+)
+
+// Targets are the valid values of --target
+var Targets = []string{TargetFile, TargetStdout, TargetKubernetes, TargetVault}
+
+// TargetOptions are the values of the flags selecting where the credentials are written
+type TargetOptions struct {
+	Target       string
+	SecretName   string
+	Namespace    string
+	SealCert     string
+	VaultAddress string
+	VaultPath    string
+	KubeConfig   cluster.KubeConfigOptions
+}
+
+// SinkArgs are the values of the command which the credentials sinks depend on
+type SinkArgs struct {
+	FileFormat   string
+	TemplatePath string
+	FileLocation string
+	Overwrite    bool
+	// SecretName is the name of the Kubernetes secret when --secret-name is not set
+	SecretName string
+}
+
+// AddTargetFlags adds the flags which select where the credentials are written
+func AddTargetFlags(cmd *cobra.Command, opts *TargetOptions, localizer localize.Localizer) {
+	cmd.Flags().StringVar(&opts.Target, FlagTarget, TargetFile, localizer.MustLocalize("serviceAccount.common.flag.target.description"))
+	cmd.Flags().StringVar(&opts.SecretName, FlagSecretName, "", localizer.MustLocalize("serviceAccount.common.flag.secretName.description"))
+	cmd.Flags().StringVarP(&opts.Namespace, FlagNamespace, "n", "", localizer.MustLocalize("serviceAccount.common.flag.namespace.description"))
+	cmd.Flags().StringVar(&opts.SealCert, FlagSealCert, "", localizer.MustLocalize("serviceAccount.common.flag.sealCert.description"))
+	cmd.Flags().StringVar(&opts.VaultAddress, FlagVaultAddress, "", localizer.MustLocalize("serviceAccount.common.flag.vaultAddress.description"))
+	cmd.Flags().StringVar(&opts.VaultPath, FlagVaultPath, "", localizer.MustLocalize("serviceAccount.common.flag.vaultPath.description"))
+	clusterflags.AddKubeConfigFlags(cmd, &opts.KubeConfig, localizer)
+
+	flagutil.EnableStaticFlagCompletion(cmd, FlagTarget, Targets)
+}
+
+// Validate checks the values of the flags
+func (o *TargetOptions) Validate(localizer localize.Localizer) error {
+	if !flagutil.IsValidInput(o.Target, Targets...) {
+		return flag.InvalidValueError(FlagTarget, o.Target, Targets...)
+	}
+	if o.Target == TargetVault && o.VaultPath == "" {
+		return errors.New(localizer.MustLocalize("serviceAccount.common.error.vaultPathRequired"))
+	}
+	return nil
+}
+
+// UsesFileFormat returns true when the credentials are written in the format set by --file-format
+func (o *TargetOptions) UsesFileFormat() bool {
+	return o.Target == TargetFile || o.Target == TargetStdout
+}
+
+// NewSink creates the sink which writes the credentials to the target
+func (o *TargetOptions) NewSink(io *iostreams.IOStreams, localizer localize.Localizer, args *SinkArgs) (credentials.Sink, error) {
+	formatOptions := &credentials.FormatOptions{
+		SecretName:   o.secretName(args),
+		TemplatePath: args.TemplatePath,
+	}
+
+	switch o.Target {
+	case TargetStdout:
+		return &credentials.StdoutSink{Out: io.Out, Format: args.FileFormat, FormatOptions: formatOptions}, nil
+	case TargetKubernetes:
+		return o.newSecretSink(localizer, args)
+	case TargetVault:
+		return o.newVaultSink(localizer, args)
+	default:
+		return &credentials.FileSink{Format: args.FileFormat, FormatOptions: formatOptions, Path: args.FileLocation, Overwrite: args.Overwrite}, nil
+	}
+}
+
+// secretName returns the name of the Kubernetes secret, which --secret-name overrides
+func (o *TargetOptions) secretName(args *SinkArgs) string {
+	if o.SecretName != "" {
+		return o.SecretName
+	}
+	return args.SecretName
+}
+
+func (o *TargetOptions) newSecretSink(localizer localize.Localizer, args *SinkArgs) (credentials.Sink, error) {
+	clients, err := cluster.NewKubernetesClients(&o.KubeConfig, localizer)
+	if err != nil {
+		return nil, err
+	}
+
+	sink := &credentials.SecretSink{
+		Clientset:     clients.Clientset(),
+		DynamicClient: clients.DynamicClient(),
+		Namespace:     o.Namespace,
+		Name:          o.secretName(args),
+		Overwrite:     args.Overwrite,
+	}
+	if sink.Namespace == "" {
+		if sink.Namespace, err = clients.CurrentNamespace(); err != nil {
+			return nil, err
+		}
+	}
+
+	if o.SealCert != "" {
+		data, err := ioutil.ReadFile(o.SealCert)
+		if err != nil {
+			return nil, err
+		}
+		if sink.SealingKey, err = credentials.ParseSealingKey(data); err != nil {
+			return nil, errors.New(localizer.MustLocalize("serviceAccount.common.error.invalidSealCert", localize.NewEntry("Path", o.SealCert), localize.NewEntry("ErrorMessage", err)))
+		}
+	}
+
+	return sink, nil
+}
+
+func (o *TargetOptions) newVaultSink(localizer localize.Localizer, args *SinkArgs) (credentials.Sink, error) {
+	address := o.VaultAddress
+	if address == "" {
+		address = os.Getenv("VAULT_ADDR")
+	}
+	if address == "" {
+		return nil, errors.New(localizer.MustLocalize("serviceAccount.common.error.vaultAddressRequired"))
+	}
+
+	token := vaultToken()
+	if token == "" {
+		return nil, errors.New(localizer.MustLocalize("serviceAccount.common.error.vaultTokenRequired"))
+	}
+
+	return &credentials.VaultSink{
+		Address:   address,
+		Token:     token,
+		Path:      o.VaultPath,
+		Overwrite: args.Overwrite,
+	}, nil
+}
+
+// vaultToken returns the Vault token from the VAULT_TOKEN environment variable,
+// falling back to the token saved by "vault login", the same as the Vault CLI
+func vaultToken() string {
+	if token := os.Getenv("VAULT_TOKEN"); token != "" {
+		return token
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	data, err := ioutil.ReadFile(filepath.Join(home, ".vault-token"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// CheckError returns the error to print when the check of the sink fails
+func CheckError(localizer localize.Localizer, sink credentials.Sink, err error) error {
+	if !errors.Is(err, credentials.ErrAlreadyExists) {
+		return err
+	}
+	if _, ok := sink.(*credentials.FileSink); ok {
+		return errors.New(localizer.MustLocalize("serviceAccount.common.error.credentialsFileAlreadyExists", localize.NewEntry("FilePath", sink.Location())))
+	}
+	return errors.New(localizer.MustLocalize("serviceAccount.common.error.credentialsAlreadyExist", localize.NewEntry("Location", sink.Location())))
+}
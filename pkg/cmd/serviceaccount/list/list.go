@@ -3,6 +3,9 @@ package list
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/redhat-developer/app-services-cli/internal/config"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
@@ -14,6 +17,7 @@ import (
 	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
 	"github.com/redhat-developer/app-services-cli/pkg/localize"
 	"github.com/redhat-developer/app-services-cli/pkg/logging"
+	"github.com/redhat-developer/app-services-cli/pkg/serviceaccount/serviceaccountutil"
 	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
@@ -26,7 +30,16 @@ type Options struct {
 	IO         *iostreams.IOStreams
 	localizer  localize.Localizer
 
-	output string
+	output        string
+	search        string
+	owner         string
+	createdBefore string
+	sortBy        string
+	desc          bool
+	page          int
+	limit         int
+
+	createdBeforeTime *time.Time
 }
 
 // svcAcctRow contains the properties used to
@@ -47,6 +60,9 @@ func NewListCommand(f *factory.Factory) *cobra.Command {
 		Logger:     f.Logger,
 		IO:         f.IOStreams,
 		localizer:  f.Localizer,
+
+		page:  1,
+		limit: 100,
 	}
 
 	cmd := &cobra.Command{
@@ -60,14 +76,44 @@ func NewListCommand(f *factory.Factory) *cobra.Command {
 				return flag.InvalidValueError("output", opts.output, flagutil.ValidOutputFormats...)
 			}
 
+			if opts.sortBy != "" && !flagutil.IsValidInput(opts.sortBy, serviceaccountutil.SortFields...) {
+				return flag.InvalidValueError("sort-by", opts.sortBy, serviceaccountutil.SortFields...)
+			}
+
+			if opts.page < 1 {
+				return errors.New(opts.localizer.MustLocalize("serviceAccount.list.error.invalidPage", localize.NewEntry("Page", opts.page)))
+			}
+			if opts.limit < 1 {
+				return errors.New(opts.localizer.MustLocalize("serviceAccount.list.error.invalidLimit", localize.NewEntry("Limit", opts.limit)))
+			}
+
+			if opts.createdBefore != "" {
+				t, err := parseTime(opts.createdBefore)
+				if err != nil {
+					return errors.New(opts.localizer.MustLocalize("serviceAccount.list.error.invalidCreatedBefore", localize.NewEntry("Value", opts.createdBefore)))
+				}
+				opts.createdBeforeTime = &t
+			}
+
 			return runList(opts)
 		},
 	}
 
 	cmd.Flags().StringVarP(&opts.output, "output", "o", "", opts.localizer.MustLocalize("serviceAccount.list.flag.output.description"))
+	cmd.Flags().StringVar(&opts.search, "search", "", opts.localizer.MustLocalize("serviceAccount.list.flag.search.description"))
+	cmd.Flags().StringVar(&opts.owner, "owner", "", opts.localizer.MustLocalize("serviceAccount.list.flag.owner.description", localize.NewEntry("Me", cmdutil.OwnerMe)))
+	cmd.Flags().StringVar(&opts.createdBefore, "created-before", "", opts.localizer.MustLocalize("serviceAccount.list.flag.createdBefore.description"))
+	cmd.Flags().StringVar(&opts.sortBy, "sort-by", "", opts.localizer.MustLocalize("serviceAccount.list.flag.sortBy.description", localize.NewEntry("Fields", strings.Join(serviceaccountutil.SortFields, ", "))))
+	cmd.Flags().BoolVar(&opts.desc, "desc", false, opts.localizer.MustLocalize("serviceAccount.list.flag.desc.description"))
+	cmd.Flags().IntVar(&opts.page, "page", opts.page, opts.localizer.MustLocalize("serviceAccount.list.flag.page.description"))
+	cmd.Flags().IntVar(&opts.limit, "limit", opts.limit, opts.localizer.MustLocalize("serviceAccount.list.flag.limit.description"))
 
 	flagutil.EnableOutputFlagCompletion(cmd)
 
+	_ = cmd.RegisterFlagCompletionFunc("sort-by", func(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return serviceaccountutil.SortFields, cobra.ShellCompDirectiveNoSpace
+	})
+
 	return cmd
 }
 
@@ -87,7 +133,29 @@ func runList(opts *Options) (err error) {
 		return err
 	}
 
-	serviceaccounts := res.GetItems()
+	cfg, err := opts.Config.Load()
+	if err != nil {
+		return err
+	}
+	owner, ok := cmdutil.ResolveOwner(cfg, opts.owner)
+	if !ok {
+		return errors.New(opts.localizer.MustLocalize("serviceAccount.list.error.unknownCurrentUser"))
+	}
+
+	// the API returns every service account at once, so they are filtered, sorted and paginated here
+	filter := serviceaccountutil.Filter{
+		Search:        opts.search,
+		Owner:         owner,
+		CreatedBefore: opts.createdBeforeTime,
+	}
+	serviceaccounts := filter.Apply(res.GetItems())
+	if opts.sortBy != "" {
+		serviceaccountutil.Sort(serviceaccounts, opts.sortBy, opts.desc)
+	}
+	total := len(serviceaccounts)
+	serviceaccounts = serviceaccountutil.Page(serviceaccounts, opts.page, opts.limit)
+	res.SetItems(serviceaccounts)
+
 	if len(serviceaccounts) == 0 && opts.output == "" {
 		logger.Info(opts.localizer.MustLocalize("serviceAccount.list.log.info.noneFound"))
 		return nil
@@ -106,6 +174,14 @@ func runList(opts *Options) (err error) {
 		dump.Table(outStream, rows)
 	}
 
+	if opts.page*opts.limit < total {
+		logger.Info(opts.localizer.MustLocalize("serviceAccount.list.log.info.partialPage",
+			localize.NewEntry("Count", len(serviceaccounts)),
+			localize.NewEntry("Total", total),
+			localize.NewEntry("NextPage", opts.page+1),
+		))
+	}
+
 	return nil
 }
 
@@ -126,3 +202,11 @@ func mapResponseItemsToRows(svcAccts []kafkamgmtclient.ServiceAccountListItem) [
 
 	return rows
 }
+
+// parseTime parses a time in the RFC 3339 format, or a date in the YYYY-MM-DD format
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02", value, time.Local)
+}
@@ -0,0 +1,174 @@
+package prune
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/cluster"
+	clusterflags "github.com/redhat-developer/app-services-cli/pkg/cmd/cluster/flags"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
+	"github.com/redhat-developer/app-services-cli/pkg/cmdutil"
+	"github.com/redhat-developer/app-services-cli/pkg/cmdutil/bulk"
+	"github.com/redhat-developer/app-services-cli/pkg/connection"
+	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
+	"github.com/redhat-developer/app-services-cli/pkg/localize"
+	"github.com/redhat-developer/app-services-cli/pkg/logging"
+	"github.com/redhat-developer/app-services-cli/pkg/serviceaccount/serviceaccountutil"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	"github.com/spf13/cobra"
+)
+
+type Options struct {
+	IO         *iostreams.IOStreams
+	Config     config.IConfig
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	localizer  localize.Localizer
+
+	kubeConfig      cluster.KubeConfigOptions
+	skipCluster     bool
+	credentialPaths []string
+	dryRun          bool
+	force           bool
+}
+
+// NewPruneCommand creates a new command to delete the service accounts created by the CLI which are no longer used
+func NewPruneCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		Config:     f.Config,
+		Connection: f.Connection,
+		Logger:     f.Logger,
+		IO:         f.IOStreams,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.MustLocalize("serviceAccount.prune.cmd.use"),
+		Short:   opts.localizer.MustLocalize("serviceAccount.prune.cmd.shortDescription"),
+		Long:    opts.localizer.MustLocalize("serviceAccount.prune.cmd.longDescription"),
+		Example: opts.localizer.MustLocalize("serviceAccount.prune.cmd.example"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !opts.IO.CanPrompt() && !opts.force && !opts.dryRun {
+				return errors.New(opts.localizer.MustLocalize("flag.error.requiredWhenNonInteractive", localize.NewEntry("Flag", "yes")))
+			}
+
+			return runPrune(opts)
+		},
+	}
+
+	clusterflags.AddKubeConfigFlags(cmd, &opts.kubeConfig, opts.localizer)
+	cmd.Flags().BoolVar(&opts.skipCluster, "skip-cluster", false, opts.localizer.MustLocalize("serviceAccount.prune.flag.skipCluster.description"))
+	cmd.Flags().StringSliceVar(&opts.credentialPaths, "credentials-path", []string{"."}, opts.localizer.MustLocalize("serviceAccount.prune.flag.credentialsPath.description"))
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, opts.localizer.MustLocalize("serviceAccount.prune.flag.dryRun.description"))
+	cmd.Flags().BoolVarP(&opts.force, "yes", "y", false, opts.localizer.MustLocalize("serviceAccount.prune.flag.yes.description"))
+
+	return cmd
+}
+
+// nolint:funlen
+func runPrune(opts *Options) error {
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := opts.Config.Load()
+	if err != nil {
+		return err
+	}
+	// only the service accounts of the current user are pruned, as the secrets and files of other users cannot be checked
+	username, ok := cmdutil.ResolveOwner(cfg, cmdutil.OwnerMe)
+	if !ok {
+		return errors.New(opts.localizer.MustLocalize("serviceAccount.prune.error.unknownCurrentUser"))
+	}
+
+	api := conn.API().ServiceAccount()
+
+	res, _, err := api.GetServiceAccounts(context.Background()).Execute()
+	if err != nil {
+		return err
+	}
+
+	candidates := []kafkamgmtclient.ServiceAccountListItem{}
+	clientIDs := []string{}
+	for _, sa := range res.GetItems() {
+		if serviceaccountutil.IsGeneratedName(sa.GetName()) && sa.GetOwner() == username {
+			candidates = append(candidates, sa)
+			clientIDs = append(clientIDs, sa.GetClientId())
+		}
+	}
+	logger.Debug(opts.localizer.MustLocalize("serviceAccount.prune.log.debug.candidates", localize.NewEntry("Count", len(candidates))))
+
+	if len(candidates) == 0 {
+		logger.Info(opts.localizer.MustLocalize("serviceAccount.prune.log.info.noneCreatedByCLI"))
+		return nil
+	}
+
+	referenced, err := serviceaccountutil.FindClientIDsInFiles(opts.credentialPaths, clientIDs)
+	if err != nil {
+		return fmt.Errorf("%v: %w", opts.localizer.MustLocalize("serviceAccount.prune.error.couldNotSearchFiles"), err)
+	}
+
+	if !opts.skipCluster {
+		secretClientIDs, err := clusterClientIDs(opts)
+		if err != nil {
+			return fmt.Errorf("%v: %w", opts.localizer.MustLocalize("serviceAccount.prune.error.couldNotSearchCluster"), err)
+		}
+		for _, clientID := range secretClientIDs {
+			referenced[clientID] = true
+		}
+	}
+
+	resources := []bulk.Resource{}
+	for _, sa := range candidates {
+		if referenced[sa.GetClientId()] {
+			logger.Debug(opts.localizer.MustLocalize("serviceAccount.prune.log.debug.referenced", localize.NewEntry("Name", sa.GetName())))
+			continue
+		}
+		resources = append(resources, bulk.Resource{
+			ID:        sa.GetId(),
+			Name:      sa.GetName(),
+			Owner:     sa.GetOwner(),
+			CreatedAt: sa.GetCreatedAt(),
+		})
+	}
+
+	deletion := &bulk.Deletion{
+		IO:        opts.IO,
+		Logger:    logger,
+		Localizer: opts.localizer,
+		Kind:      opts.localizer.MustLocalize("serviceAccount.prune.kind"),
+		Resources: resources,
+		Force:     opts.force,
+		DryRun:    opts.dryRun,
+		Delete: func(ctx context.Context, r bulk.Resource) error {
+			_, _, err := api.DeleteServiceAccountById(ctx, r.ID).Execute()
+			return err
+		},
+	}
+	_, err = deletion.Run()
+
+	return err
+}
+
+// clusterClientIDs returns the client IDs of the service accounts stored in the secrets of the Kubernetes cluster
+func clusterClientIDs(opts *Options) ([]string, error) {
+	clients, err := cluster.NewKubernetesClients(&opts.kubeConfig, opts.localizer)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	return clients.ServiceAccountSecretClientIDs(ctx)
+}
@@ -4,10 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
 
 	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
 
+	"github.com/redhat-developer/app-services-cli/pkg/common/clierr"
 	"github.com/redhat-developer/app-services-cli/pkg/connection"
 	"github.com/redhat-developer/app-services-cli/pkg/localize"
 
@@ -15,11 +15,13 @@ import (
 	flagutil "github.com/redhat-developer/app-services-cli/pkg/cmdutil/flags"
 	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
 	"github.com/redhat-developer/app-services-cli/pkg/serviceaccount/credentials"
+	"github.com/redhat-developer/app-services-cli/pkg/serviceaccount/serviceaccountutil"
 	"github.com/redhat-developer/app-services-cli/pkg/serviceaccount/validation"
 
 	"github.com/redhat-developer/app-services-cli/internal/config"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/flag"
+	saflags "github.com/redhat-developer/app-services-cli/pkg/cmd/serviceaccount/flags"
 	"github.com/redhat-developer/app-services-cli/pkg/logging"
 	"github.com/spf13/cobra"
 )
@@ -31,10 +33,12 @@ type Options struct {
 	Logger     func() (logging.Logger, error)
 	localizer  localize.Localizer
 
-	id         string
-	fileFormat string
-	overwrite  bool
-	filename   string
+	id           string
+	fileFormat   string
+	templatePath string
+	overwrite    bool
+	filename     string
+	target       saflags.TargetOptions
 
 	interactive bool
 	force       bool
@@ -62,14 +66,21 @@ func NewResetCredentialsCommand(f *factory.Factory) *cobra.Command {
 				opts.interactive = true
 			}
 
-			if !opts.interactive && opts.fileFormat == "" {
+			if !opts.interactive && opts.fileFormat == "" && opts.target.UsesFileFormat() {
 				return errors.New(opts.localizer.MustLocalize("flag.error.requiredWhenNonInteractive", localize.NewEntry("Flag", "file-format")))
 			}
 
+			if err := opts.target.Validate(opts.localizer); err != nil {
+				return err
+			}
+
 			validOutput := flagutil.IsValidInput(opts.fileFormat, flagutil.CredentialsOutputFormats...)
 			if !validOutput && opts.fileFormat != "" {
 				return flag.InvalidValueError("file-format", opts.fileFormat, flagutil.CredentialsOutputFormats...)
 			}
+			if !opts.interactive && opts.fileFormat == credentials.TemplateFormat && opts.templatePath == "" {
+				return errors.New(opts.localizer.MustLocalize("serviceAccount.common.error.templateRequired"))
+			}
 
 			if !opts.interactive {
 				validator := &validation.Validator{
@@ -90,8 +101,11 @@ func NewResetCredentialsCommand(f *factory.Factory) *cobra.Command {
 	cmd.Flags().BoolVar(&opts.overwrite, "overwrite", false, opts.localizer.MustLocalize("serviceAccount.common.flag.overwrite.description"))
 	cmd.Flags().StringVar(&opts.filename, "file-location", "", opts.localizer.MustLocalize("serviceAccount.common.flag.fileLocation.description"))
 	cmd.Flags().StringVar(&opts.fileFormat, "file-format", "", opts.localizer.MustLocalize("serviceAccount.common.flag.fileFormat.description"))
+	cmd.Flags().StringVar(&opts.templatePath, "template", "", opts.localizer.MustLocalize("serviceAccount.common.flag.template.description"))
 	cmd.Flags().BoolVarP(&opts.force, "yes", "y", false, opts.localizer.MustLocalize("serviceAccount.resetCredentials.flag.yes.description"))
 
+	saflags.AddTargetFlags(cmd, &opts.target, opts.localizer)
+
 	flagutil.EnableStaticFlagCompletion(cmd, "file-format", flagutil.CredentialsOutputFormats)
 
 	return cmd
@@ -122,15 +136,35 @@ func runResetCredentials(opts *Options) (err error) {
 		if err != nil {
 			return err
 		}
-	} else if opts.filename == "" {
+	} else if opts.filename == "" && opts.target.Target == saflags.TargetFile {
 		// obtain the default absolute path to where credentials will be saved
 		opts.filename = credentials.GetDefaultPath(opts.fileFormat)
 	}
 
-	// If the credentials file already exists, and the --overwrite flag is not set then return an error
-	// indicating that the user should explicitly request overwriting of the file
-	if _, err = os.Stat(opts.filename); err == nil && !opts.overwrite {
-		return errors.New(opts.localizer.MustLocalize("serviceAccount.common.error.credentialsFileAlreadyExists", localize.NewEntry("FilePath", opts.filename)))
+	sink, err := opts.target.NewSink(opts.IO, opts.localizer, &saflags.SinkArgs{
+		FileFormat:   opts.fileFormat,
+		TemplatePath: opts.templatePath,
+		FileLocation: opts.filename,
+		Overwrite:    opts.overwrite,
+		SecretName:   serviceAcctName,
+	})
+	if err != nil {
+		return err
+	}
+
+	// If the credentials already exist, and the --overwrite flag is not set then return an error
+	// indicating that the user should explicitly request overwriting of the credentials
+	if err = sink.Check(context.Background()); err != nil {
+		return saflags.CheckError(opts.localizer, sink, err)
+	}
+
+	cfg, err := opts.Config.Load()
+	if err != nil {
+		return err
+	}
+	bootstrapServerHost, tokenURL, err := serviceaccountutil.KafkaDetails(context.Background(), api.Kafka(), cfg)
+	if err != nil {
+		logger.Warn(opts.localizer.MustLocalize("serviceAccount.common.log.warn.kafkaDetailsUnavailable", localize.NewEntry("ErrorMessage", err)))
 	}
 
 	if !opts.force {
@@ -158,17 +192,20 @@ func runResetCredentials(opts *Options) (err error) {
 	logger.Info(opts.localizer.MustLocalize("serviceAccount.resetCredentials.log.info.resetSuccess", localize.NewEntry("Name", updatedServiceAccount.GetName())))
 
 	creds := &credentials.Credentials{
-		ClientID:     updatedServiceAccount.GetClientId(),
-		ClientSecret: updatedServiceAccount.GetClientSecret(),
+		ClientID:            updatedServiceAccount.GetClientId(),
+		ClientSecret:        updatedServiceAccount.GetClientSecret(),
+		BootstrapServerHost: bootstrapServerHost,
+		TokenURL:            tokenURL,
 	}
 
-	// save the credentials to a file
-	err = credentials.Write(opts.fileFormat, opts.filename, creds)
-	if err != nil {
-		return err
+	// save the credentials to the target
+	if err = sink.Write(context.Background(), creds); err != nil {
+		return fmt.Errorf("%v: %w", opts.localizer.MustLocalize("serviceAccount.common.error.couldNotSaveCredentials", localize.NewEntry("Location", sink.Location())), err)
 	}
 
-	logger.Info(opts.localizer.MustLocalize("serviceAccount.common.log.info.credentialsSaved", localize.NewEntry("FilePath", opts.filename)))
+	if opts.target.Target != saflags.TargetStdout {
+		logger.Info(opts.localizer.MustLocalize("serviceAccount.common.log.info.credentialsSaved", localize.NewEntry("FilePath", sink.Location())))
+	}
 
 	return nil
 }
@@ -200,7 +237,7 @@ func resetCredentials(name string, opts *Options) (*kafkamgmtclient.ServiceAccou
 			opts.localizer.MustLocalize("serviceAccount.common.error.forbidden", localize.NewEntry("Operation", "update"))
 			return nil, fmt.Errorf("%v: %w", opts.localizer.MustLocalize("serviceAccount.common.error.forbidden", localize.NewEntry("Operation", "update")), err)
 		case 500:
-			return nil, errors.New(opts.localizer.MustLocalize("serviceAccount.common.error.internalServerError"))
+			return nil, clierr.New(clierr.CodeServiceUnavailable, errors.New(opts.localizer.MustLocalize("serviceAccount.common.error.internalServerError")))
 		default:
 			return nil, err
 		}
@@ -237,7 +274,7 @@ func runInteractivePrompt(opts *Options) (err error) {
 	}
 
 	// if the --output flag was not used, ask in the prompt
-	if opts.fileFormat == "" {
+	if opts.fileFormat == "" && opts.target.UsesFileFormat() {
 		logger.Debug(opts.localizer.MustLocalize("serviceAccount.common.log.debug.interactive.fileFormatNotSet"))
 
 		fileFormatPrompt := &survey.Select{
@@ -253,9 +290,23 @@ func runInteractivePrompt(opts *Options) (err error) {
 		}
 	}
 
-	opts.filename, opts.overwrite, err = credentials.ChooseFileLocation(opts.fileFormat, opts.filename, opts.overwrite)
-	if err != nil {
-		return err
+	if opts.fileFormat == credentials.TemplateFormat && opts.templatePath == "" {
+		templatePrompt := &survey.Input{
+			Message: opts.localizer.MustLocalize("serviceAccount.common.input.templatePath.message"),
+			Help:    opts.localizer.MustLocalize("serviceAccount.common.flag.template.description"),
+		}
+
+		err = survey.AskOne(templatePrompt, &opts.templatePath, survey.WithValidator(survey.Required))
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.target.Target == saflags.TargetFile {
+		opts.filename, opts.overwrite, err = credentials.ChooseFileLocation(opts.fileFormat, opts.filename, opts.overwrite)
+		if err != nil {
+			return err
+		}
 	}
 
 	return err
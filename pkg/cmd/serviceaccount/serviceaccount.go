@@ -6,6 +6,7 @@ import (
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/serviceaccount/delete"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/serviceaccount/describe"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/serviceaccount/list"
+	"github.com/redhat-developer/app-services-cli/pkg/cmd/serviceaccount/prune"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/serviceaccount/resetcredentials"
 	"github.com/spf13/cobra"
 )
@@ -25,6 +26,7 @@ func NewServiceAccountCommand(f *factory.Factory) *cobra.Command {
 		delete.NewDeleteCommand(f),
 		resetcredentials.NewResetCredentialsCommand(f),
 		describe.NewDescribeCommand(f),
+		prune.NewPruneCommand(f),
 	)
 
 	return cmd
@@ -46,24 +46,31 @@ func NewWhoAmICmd(f *factory.Factory) *cobra.Command {
 }
 
 func runCmd(opts *Options) (err error) {
-	cfg, err := opts.Config.Load()
+	logger, err := opts.Logger()
 	if err != nil {
 		return err
 	}
 
-	logger, err := opts.Logger()
+	_, err = opts.Connection(connection.DefaultConfigSkipMasAuth)
 	if err != nil {
 		return err
 	}
 
-	_, err = opts.Connection(connection.DefaultConfigSkipMasAuth)
+	// the config is loaded after connecting, as the tokens may have been refreshed
+	cfg, err := opts.Config.Load()
 	if err != nil {
 		return err
 	}
 
-	accessTkn, _ := token.Parse(cfg.AccessToken)
+	accessTkn, err := token.Parse(cfg.AccessToken)
+	if err != nil {
+		return err
+	}
 
-	tknClaims, _ := token.MapClaims(accessTkn)
+	tknClaims, err := token.MapClaims(accessTkn)
+	if err != nil {
+		return err
+	}
 
 	userName, ok := tknClaims["preferred_username"]
 
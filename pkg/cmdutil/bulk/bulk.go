@@ -0,0 +1,304 @@
+// Package bulk implements the deletion of every resource matched by a selector,
+// which the delete commands offer next to the deletion of a single resource
+package bulk
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/cmdutil"
+	"github.com/redhat-developer/app-services-cli/pkg/dump"
+	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
+	"github.com/redhat-developer/app-services-cli/pkg/localize"
+	"github.com/redhat-developer/app-services-cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+// Flags selecting the resources to delete
+const (
+	FlagAll       = "all"
+	FlagSelector  = "selector"
+	FlagOlderThan = "older-than"
+	FlagOwner     = "owner"
+)
+
+const (
+	// SelectorNamePrefix is the selector key matching the resources whose name starts with its value
+	SelectorNamePrefix = "name-prefix"
+
+	// Workers is the maximum number of resources which are deleted at the same time
+	Workers = 5
+)
+
+// Selectors are the supported keys of --selector
+var Selectors = []string{SelectorNamePrefix}
+
+// Resource is a resource which can be deleted in bulk.
+// The owner and the creation time are empty for the resources which do not have them
+type Resource struct {
+	ID        string
+	Name      string
+	Owner     string
+	CreatedAt time.Time
+}
+
+// Options are the values of the flags selecting the resources to delete
+type Options struct {
+	All       bool
+	Selector  string
+	OlderThan time.Duration
+	Owner     string
+
+	namePrefix string
+}
+
+// AddFlags adds the flags selecting the resources to delete to the command.
+// kind is the plural name of the resources, used in the descriptions of the flags.
+// The --older-than and --owner flags are only added when the resources have an owner and a creation time
+func AddFlags(cmd *cobra.Command, opts *Options, localizer localize.Localizer, kind string, ownership bool) {
+	kindEntry := localize.NewEntry("Kind", kind)
+
+	cmd.Flags().BoolVar(&opts.All, FlagAll, false, localizer.MustLocalize("bulk.flag.all.description", kindEntry))
+	cmd.Flags().StringVar(&opts.Selector, FlagSelector, "", localizer.MustLocalize("bulk.flag.selector.description", kindEntry, localize.NewEntry("Selectors", strings.Join(Selectors, ", "))))
+	if ownership {
+		cmd.Flags().DurationVar(&opts.OlderThan, FlagOlderThan, 0, localizer.MustLocalize("bulk.flag.olderThan.description", kindEntry))
+		cmd.Flags().StringVar(&opts.Owner, FlagOwner, "", localizer.MustLocalize("bulk.flag.owner.description", kindEntry, localize.NewEntry("Me", cmdutil.OwnerMe)))
+	}
+}
+
+// Enabled returns true if any of the flags selecting the resources to delete is set
+func (o *Options) Enabled() bool {
+	return o.All || o.Selector != "" || o.OlderThan != 0 || o.Owner != ""
+}
+
+// Validate parses the selector and checks the values of the flags
+func (o *Options) Validate(localizer localize.Localizer) error {
+	if o.OlderThan < 0 {
+		return errors.New(localizer.MustLocalize("bulk.error.negativeOlderThan", localize.NewEntry("Value", o.OlderThan)))
+	}
+
+	o.namePrefix = ""
+	if o.Selector == "" {
+		return nil
+	}
+	for _, s := range strings.Split(o.Selector, ",") {
+		parts := strings.SplitN(strings.TrimSpace(s), "=", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return errors.New(localizer.MustLocalize("bulk.error.invalidSelector", localize.NewEntry("Selector", s)))
+		}
+		switch parts[0] {
+		case SelectorNamePrefix:
+			o.namePrefix = parts[1]
+		default:
+			return errors.New(localizer.MustLocalize("bulk.error.unsupportedSelector",
+				localize.NewEntry("Key", parts[0]),
+				localize.NewEntry("Selectors", strings.Join(Selectors, ", ")),
+			))
+		}
+	}
+	return nil
+}
+
+// ResolveOwner replaces the "me" value of --owner with the username of the current user.
+// The configuration should be loaded after connecting, as the tokens may have been refreshed
+func (o *Options) ResolveOwner(cfg *config.Config, localizer localize.Localizer) error {
+	owner, ok := cmdutil.ResolveOwner(cfg, o.Owner)
+	if !ok {
+		return errors.New(localizer.MustLocalize("bulk.error.unknownCurrentUser"))
+	}
+	o.Owner = owner
+	return nil
+}
+
+// Match returns the resources which match the flags, at the given time
+func (o *Options) Match(resources []Resource, now time.Time) []Resource {
+	matched := []Resource{}
+	for _, r := range resources {
+		if o.namePrefix != "" && !strings.HasPrefix(r.Name, o.namePrefix) {
+			continue
+		}
+		if o.Owner != "" && r.Owner != o.Owner {
+			continue
+		}
+		// the resources without a creation time are never old enough
+		if o.OlderThan != 0 && (r.CreatedAt.IsZero() || now.Sub(r.CreatedAt) < o.OlderThan) {
+			continue
+		}
+		matched = append(matched, r)
+	}
+	return matched
+}
+
+// Deletion is the deletion of the resources matched by the flags
+type Deletion struct {
+	IO        *iostreams.IOStreams
+	Logger    logging.Logger
+	Localizer localize.Localizer
+
+	// Kind is the plural name of the resources, such as "topics"
+	Kind string
+	// Resources are the resources to delete
+	Resources []Resource
+	// Force skips the confirmation
+	Force bool
+	// DryRun only prints the resources which would be deleted
+	DryRun bool
+	// Delete deletes a resource
+	Delete func(ctx context.Context, r Resource) error
+}
+
+// Failure is a resource which could not be deleted
+type Failure struct {
+	Resource Resource
+	Err      error
+}
+
+// resourceRow contains the properties used to populate the list of resources to delete into a table row
+type resourceRow struct {
+	Name      string `header:"Name"`
+	ID        string `header:"ID"`
+	Owner     string `header:"Owner"`
+	CreatedAt string `header:"Created At"`
+}
+
+// nameRow is the row of the resources which only have a name
+type nameRow struct {
+	Name string `header:"Name"`
+}
+
+// Run prints the resources to delete and asks for the confirmation of their number, unless Force is set.
+// Nothing is deleted when DryRun is set.
+// The resources are then deleted by a bounded pool of workers, and the result is summarised.
+// It returns the resources which were deleted, along with an error when any of the resources could not be deleted
+func (d *Deletion) Run() ([]Resource, error) {
+	kindEntry := localize.NewEntry("Kind", d.Kind)
+
+	if len(d.Resources) == 0 {
+		d.Logger.Info(d.Localizer.MustLocalize("bulk.log.info.noMatches", kindEntry))
+		return nil, nil
+	}
+
+	countEntry := localize.NewEntry("Count", len(d.Resources))
+	if d.DryRun {
+		d.Logger.Info(d.Localizer.MustLocalize("bulk.log.info.dryRun", kindEntry, countEntry))
+		dump.Table(d.IO.Out, mapResourcesToRows(d.Resources))
+		return nil, nil
+	}
+
+	d.Logger.Info(d.Localizer.MustLocalize("bulk.log.info.matched", kindEntry, countEntry))
+	dump.Table(d.IO.Out, mapResourcesToRows(d.Resources))
+	d.Logger.Info("")
+
+	if !d.Force {
+		if !d.IO.CanPrompt() {
+			return nil, errors.New(d.Localizer.MustLocalize("flag.error.requiredWhenNonInteractive", localize.NewEntry("Flag", "yes")))
+		}
+
+		var confirmedCount string
+		prompt := &survey.Input{
+			Message: d.Localizer.MustLocalize("bulk.input.confirmCount.message", kindEntry),
+		}
+		if err := survey.AskOne(prompt, &confirmedCount); err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(confirmedCount) != strconv.Itoa(len(d.Resources)) {
+			return nil, errors.New(d.Localizer.MustLocalize("bulk.error.mismatchedCountConfirmation",
+				localize.NewEntry("ConfirmedCount", confirmedCount),
+				localize.NewEntry("Count", len(d.Resources)),
+			))
+		}
+	}
+
+	deleted, failures := deleteAll(context.Background(), d.Resources, Workers, d.Delete)
+
+	for _, f := range failures {
+		d.Logger.Warn(d.Localizer.MustLocalize("bulk.log.warn.deleteFailed", localize.NewEntry("Name", f.Resource.Name), localize.NewEntry("ErrorMessage", f.Err)))
+	}
+	d.Logger.Info(d.Localizer.MustLocalize("bulk.log.info.summary",
+		kindEntry,
+		localize.NewEntry("Deleted", len(deleted)),
+		localize.NewEntry("Total", len(d.Resources)),
+	))
+
+	if len(failures) > 0 {
+		return deleted, errors.New(d.Localizer.MustLocalize("bulk.error.partialFailure",
+			kindEntry,
+			localize.NewEntry("Failed", len(failures)),
+			localize.NewEntry("Total", len(d.Resources)),
+		))
+	}
+	return deleted, nil
+}
+
+// deleteAll deletes the resources with at most the given number of workers at the same time.
+// The deleted resources and the failures are returned in the order of the resources
+func deleteAll(ctx context.Context, resources []Resource, workers int, deleteFunc func(context.Context, Resource) error) ([]Resource, []Failure) {
+	errs := make([]error, len(resources))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(resources); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = deleteFunc(ctx, resources[i])
+			}
+		}()
+	}
+	for i := range resources {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	deleted := []Resource{}
+	failures := []Failure{}
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, Failure{Resource: resources[i], Err: err})
+		} else {
+			deleted = append(deleted, resources[i])
+		}
+	}
+	return deleted, failures
+}
+
+func mapResourcesToRows(resources []Resource) interface{} {
+	sorted := make([]Resource, len(resources))
+	copy(sorted, resources)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	nameOnly := true
+	for _, r := range sorted {
+		if r.ID != r.Name || r.Owner != "" || !r.CreatedAt.IsZero() {
+			nameOnly = false
+		}
+	}
+
+	if nameOnly {
+		rows := make([]nameRow, len(sorted))
+		for i, r := range sorted {
+			rows[i] = nameRow{Name: r.Name}
+		}
+		return rows
+	}
+
+	rows := make([]resourceRow, len(sorted))
+	for i, r := range sorted {
+		rows[i] = resourceRow{Name: r.Name, ID: r.ID, Owner: r.Owner}
+		if !r.CreatedAt.IsZero() {
+			rows[i].CreatedAt = r.CreatedAt.Format(time.RFC3339)
+		}
+	}
+	return rows
+}
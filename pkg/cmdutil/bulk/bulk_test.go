@@ -0,0 +1,182 @@
+package bulk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
+	"github.com/redhat-developer/app-services-cli/pkg/localize/goi18n"
+	"github.com/redhat-developer/app-services-cli/pkg/logging"
+)
+
+func TestValidate(t *testing.T) {
+	localizer, _ := goi18n.New(nil)
+
+	tests := []struct {
+		name           string
+		opts           Options
+		wantErr        bool
+		wantNamePrefix string
+	}{
+		{name: "no selector", opts: Options{All: true}},
+		{name: "name prefix", opts: Options{Selector: "name-prefix=ci-"}, wantNamePrefix: "ci-"},
+		{name: "spaces around the selector", opts: Options{Selector: " name-prefix=ci- "}, wantNamePrefix: "ci-"},
+		{name: "missing value", opts: Options{Selector: "name-prefix="}, wantErr: true},
+		{name: "missing separator", opts: Options{Selector: "name-prefix"}, wantErr: true},
+		{name: "unsupported key", opts: Options{Selector: "owner=me"}, wantErr: true},
+		{name: "negative duration", opts: Options{OlderThan: -time.Hour}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate(localizer)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.opts.namePrefix != tt.wantNamePrefix {
+				t.Errorf("name prefix = %q, want %q", tt.opts.namePrefix, tt.wantNamePrefix)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	localizer, _ := goi18n.New(nil)
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	resources := []Resource{
+		{ID: "1", Name: "ci-old", Owner: "alice", CreatedAt: now.Add(-48 * time.Hour)},
+		{ID: "2", Name: "ci-new", Owner: "alice", CreatedAt: now.Add(-time.Hour)},
+		{ID: "3", Name: "ci-bob", Owner: "bob", CreatedAt: now.Add(-48 * time.Hour)},
+		{ID: "4", Name: "prod", Owner: "alice", CreatedAt: now.Add(-48 * time.Hour)},
+		{ID: "5", Name: "ci-unknown-age"},
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+		want []string
+	}{
+		{name: "all", opts: Options{All: true}, want: []string{"1", "2", "3", "4", "5"}},
+		{name: "name prefix", opts: Options{Selector: "name-prefix=ci-"}, want: []string{"1", "2", "3", "5"}},
+		{name: "owner", opts: Options{Owner: "alice"}, want: []string{"1", "2", "4"}},
+		{name: "older than", opts: Options{OlderThan: 24 * time.Hour}, want: []string{"1", "3", "4"}},
+		{
+			name: "combined",
+			opts: Options{Selector: "name-prefix=ci-", OlderThan: 24 * time.Hour, Owner: "alice"},
+			want: []string{"1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.opts.Validate(localizer); err != nil {
+				t.Fatal(err)
+			}
+			matched := tt.opts.Match(resources, now)
+			if len(matched) != len(tt.want) {
+				t.Fatalf("Match() = %v, want the IDs %v", matched, tt.want)
+			}
+			for i, r := range matched {
+				if r.ID != tt.want[i] {
+					t.Errorf("Match()[%v] = %v, want the ID %v", i, r, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteAll(t *testing.T) {
+	resources := make([]Resource, 20)
+	for i := range resources {
+		resources[i] = Resource{ID: string(rune('a' + i)), Name: string(rune('a' + i))}
+	}
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	deleteFunc := func(_ context.Context, r Resource) error {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+
+		if r.ID == "c" || r.ID == "k" {
+			return errors.New("failed")
+		}
+		return nil
+	}
+
+	deleted, failures := deleteAll(context.Background(), resources, 3, deleteFunc)
+
+	if maxRunning > 3 {
+		t.Errorf("expected at most 3 deletions at the same time, got %v", maxRunning)
+	}
+	if len(deleted) != 18 {
+		t.Errorf("expected 18 deleted resources, got %v", len(deleted))
+	}
+	if len(failures) != 2 || failures[0].Resource.ID != "c" || failures[1].Resource.ID != "k" {
+		t.Errorf("unexpected failures: %v", failures)
+	}
+}
+
+func TestRun_LogsFailuresAsWarnings(t *testing.T) {
+	localizer, err := goi18n.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var logs bytes.Buffer
+	logger, err := logging.NewJSONLoggerBuilder().Stream(&logs).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := &Deletion{
+		IO:        &iostreams.IOStreams{Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}},
+		Logger:    logger,
+		Localizer: localizer,
+		Kind:      "topics",
+		Resources: []Resource{{Name: "orders"}, {Name: "payments"}},
+		Force:     true,
+		Delete: func(_ context.Context, r Resource) error {
+			if r.Name == "payments" {
+				return errors.New("not authorized")
+			}
+			return nil
+		},
+	}
+	if _, err = d.Run(); err == nil {
+		t.Fatal("expected an error when a resource could not be deleted")
+	}
+
+	levels := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var entry map[string]interface{}
+		if err = json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid JSON %q: %v", line, err)
+		}
+		msg, _ := entry["msg"].(string)
+		level, _ := entry["level"].(string)
+		if strings.Contains(msg, "payments") {
+			levels["failure"] = level
+		}
+		if strings.HasPrefix(msg, "Deleted 1 of 2 topics") {
+			levels["summary"] = level
+		}
+	}
+	// the failures stand out from the progress, which is logged at the info level
+	if levels["failure"] != logging.WarnLevel || levels["summary"] != logging.InfoLevel {
+		t.Errorf("unexpected levels %v:\n%v", levels, logs.String())
+	}
+}
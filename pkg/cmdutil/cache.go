@@ -0,0 +1,70 @@
+package cmdutil
+
+import (
+	"context"
+
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/cache"
+	"github.com/redhat-developer/app-services-cli/pkg/cloudregion/cloudregionutil"
+	"github.com/redhat-developer/app-services-cli/pkg/connection"
+	"github.com/redhat-developer/app-services-cli/pkg/logging"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+)
+
+// GetCloudProviders returns the cloud providers which Kafka instances can be created on.
+// They are read from the cache when they were fetched recently
+func GetCloudProviders(cfgFile config.IConfig, conn connection.Connection) ([]kafkamgmtclient.CloudProvider, error) {
+	cfg, err := cfgFile.Load()
+	if err != nil {
+		return nil, err
+	}
+	c, err := cache.New(cfgFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var cloudProviders []kafkamgmtclient.CloudProvider
+	err = c.Fetch(cache.CloudProvidersNamespace, cache.Key(cfg.APIUrl), cache.CloudProvidersTTL, &cloudProviders, func() error {
+		res, _, err := conn.API().Kafka().GetCloudProviders(context.Background()).Execute()
+		if err != nil {
+			return err
+		}
+		cloudProviders = res.GetItems()
+		return nil
+	})
+
+	return cloudProviders, err
+}
+
+// GetCloudRegions returns the regions of a cloud provider which Kafka instances can be created in.
+// They are read from the cache when they were fetched recently
+func GetCloudRegions(cfgFile config.IConfig, conn connection.Connection, providerID string) ([]cloudregionutil.Region, error) {
+	cfg, err := cfgFile.Load()
+	if err != nil {
+		return nil, err
+	}
+	c, err := cache.New(cfgFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var regions []cloudregionutil.Region
+	err = c.Fetch(cache.CloudRegionsNamespace, cache.Key(cfg.APIUrl, providerID), cache.CloudRegionsTTL, &regions, func() error {
+		res, httpRes, err := conn.API().Kafka().GetCloudProviderRegions(context.Background(), providerID).Execute()
+		if err != nil {
+			return err
+		}
+		regions = cloudregionutil.FromResponse(res, httpRes)
+		return nil
+	})
+
+	return regions, err
+}
+
+// InvalidateCache removes the cached responses of the namespaces, after a command changed the resources they contain.
+// Failures are only logged, as the command itself has succeeded
+func InvalidateCache(cfg config.IConfig, logger logging.Logger, namespaces ...string) {
+	if err := cache.Invalidate(cfg, namespaces...); err != nil {
+		logger.Debug("Unable to invalidate the cache:", err)
+	}
+}
@@ -4,11 +4,17 @@ import (
 	"context"
 	"errors"
 	"os"
+	"strings"
 
 	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/redhat-developer/app-services-cli/pkg/cache"
 	"github.com/redhat-developer/app-services-cli/pkg/cloudprovider/cloudproviderutil"
+	"github.com/redhat-developer/app-services-cli/pkg/cloudregion/cloudregionutil"
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
 	"github.com/redhat-developer/app-services-cli/pkg/connection"
+	"github.com/redhat-developer/app-services-cli/pkg/kafka"
+	"github.com/redhat-developer/app-services-cli/pkg/kafka/consumergroup"
+	topicutil "github.com/redhat-developer/app-services-cli/pkg/kafka/topic"
 	"github.com/spf13/cobra"
 )
 
@@ -26,6 +32,7 @@ func CheckSurveyError(err error) error {
 
 // FilterValidTopicNameArgs filters topics from the API and returns the names
 // This is used in the cobra.ValidArgsFunction for dynamic completion of topic names
+// All the names are cached, so that the API is not called on every key press
 func FilterValidTopicNameArgs(f *factory.Factory, toComplete string) (validNames []string, directive cobra.ShellCompDirective) {
 	validNames = []string{}
 	directive = cobra.ShellCompDirectiveNoSpace
@@ -39,34 +46,42 @@ func FilterValidTopicNameArgs(f *factory.Factory, toComplete string) (validNames
 		return validNames, directive
 	}
 
-	conn, err := f.Connection(connection.DefaultConfigRequireMasAuth)
+	c, err := cache.New(f.Config)
 	if err != nil {
 		return validNames, directive
 	}
 
-	api, _, err := conn.API().KafkaAdmin(cfg.Services.Kafka.ClusterID)
-	if err != nil {
-		return validNames, directive
-	}
-	req := api.TopicsApi.GetTopics(context.Background())
-	if toComplete != "" {
-		req = req.Filter(toComplete)
-	}
-
-	topicRes, _, err := req.Execute()
+	var names []string
+	err = c.Fetch(cache.TopicsNamespace(cfg.Services.Kafka.ClusterID), cache.Key(cfg.APIUrl, "names"), cache.TopicsTTL, &names, func() error {
+		conn, err := f.Connection(connection.DefaultConfigRequireMasAuth)
+		if err != nil {
+			return err
+		}
+
+		api, _, err := conn.API().KafkaAdmin(cfg.Services.Kafka.ClusterID)
+		if err != nil {
+			return err
+		}
+
+		topics, err := topicutil.ListTopics(context.Background(), api)
+		if err != nil {
+			return err
+		}
+
+		for _, topic := range topics {
+			names = append(names, topic.GetName())
+		}
+		return nil
+	})
 	if err != nil {
 		return validNames, directive
 	}
 
-	items := topicRes.GetItems()
-	for _, topic := range items {
-		validNames = append(validNames, topic.GetName())
-	}
-
-	return validNames, directive
+	return filterPrefix(names, toComplete), directive
 }
 
 // FilterValidConsumerGroups returns the list of consumer group IDs from the API
+// All the IDs are cached, so that the API is not called on every key press
 func FilterValidConsumerGroupIDs(f *factory.Factory, toComplete string) (validIDs []string, directive cobra.ShellCompDirective) {
 	validIDs = []string{}
 	directive = cobra.ShellCompDirectiveNoSpace
@@ -80,60 +95,79 @@ func FilterValidConsumerGroupIDs(f *factory.Factory, toComplete string) (validID
 		return validIDs, directive
 	}
 
-	conn, err := f.Connection(connection.DefaultConfigRequireMasAuth)
-	if err != nil {
-		return validIDs, directive
-	}
-
-	api, _, err := conn.API().KafkaAdmin(cfg.Services.Kafka.ClusterID)
+	c, err := cache.New(f.Config)
 	if err != nil {
 		return validIDs, directive
 	}
-	req := api.GroupsApi.GetConsumerGroups(context.Background())
-	if toComplete != "" {
-		req = req.GroupIdFilter(toComplete)
-	}
 
-	cgRes, _, err := req.Execute()
+	var ids []string
+	err = c.Fetch(cache.ConsumerGroupsNamespace(cfg.Services.Kafka.ClusterID), cache.Key(cfg.APIUrl, "ids"), cache.ConsumerGroupsTTL, &ids, func() error {
+		conn, err := f.Connection(connection.DefaultConfigRequireMasAuth)
+		if err != nil {
+			return err
+		}
+
+		api, _, err := conn.API().KafkaAdmin(cfg.Services.Kafka.ClusterID)
+		if err != nil {
+			return err
+		}
+
+		groups, err := consumergroup.ListConsumerGroups(context.Background(), api)
+		if err != nil {
+			return err
+		}
+
+		for _, cg := range groups {
+			ids = append(ids, cg.GetGroupId())
+		}
+		return nil
+	})
 	if err != nil {
 		return validIDs, directive
 	}
 
-	items := cgRes.GetItems()
-	for _, cg := range items {
-		validIDs = append(validIDs, cg.GetGroupId())
-	}
-
-	return validIDs, directive
+	return filterPrefix(ids, toComplete), directive
 }
 
 // FilterValidKafkaNames filters Kafkas by name from the API and returns the names
 // This is used in the cobra.ValidArgsFunction for dynamic completion of Kafka instance names
+// All the names are cached, so that the API is not called on every key press
 func FilterValidKafkas(f *factory.Factory, toComplete string) (validNames []string, directive cobra.ShellCompDirective) {
 	validNames = []string{}
 	directive = cobra.ShellCompDirectiveNoSpace
 
-	conn, err := f.Connection(connection.DefaultConfigSkipMasAuth)
+	cfg, err := f.Config.Load()
 	if err != nil {
 		return validNames, directive
 	}
 
-	req := conn.API().Kafka().GetKafkas(context.Background())
-	if toComplete != "" {
-		searchQ := "name like " + toComplete + "%"
-		req = req.Search(searchQ)
-	}
-	kafkas, _, err := req.Execute()
+	c, err := cache.New(f.Config)
 	if err != nil {
 		return validNames, directive
 	}
 
-	items := kafkas.GetItems()
-	for _, kafka := range items {
-		validNames = append(validNames, kafka.GetName())
+	var names []string
+	err = c.Fetch(cache.KafkasNamespace, cache.Key(cfg.APIUrl, "names"), cache.KafkasTTL, &names, func() error {
+		conn, err := f.Connection(connection.DefaultConfigSkipMasAuth)
+		if err != nil {
+			return err
+		}
+
+		kafkas, err := kafka.ListKafkas(context.Background(), conn.API().Kafka())
+		if err != nil {
+			return err
+		}
+
+		for _, kafkaInstance := range kafkas {
+			names = append(names, kafkaInstance.GetName())
+		}
+		return nil
+	})
+	if err != nil {
+		return validNames, directive
 	}
 
-	return validNames, directive
+	return filterPrefix(names, toComplete), directive
 }
 
 // FetchCloudProviders returns the list of supported cloud providers for creating a Kafka instance
@@ -147,13 +181,58 @@ func FetchCloudProviders(f *factory.Factory) (validProviders []string, directive
 		return validProviders, directive
 	}
 
-	cloudProviderResponse, _, err := conn.API().Kafka().GetCloudProviders(context.Background()).Execute()
+	cloudProviders, err := GetCloudProviders(f.Config, conn)
 	if err != nil {
 		return validProviders, directive
 	}
 
-	cloudProviders := cloudProviderResponse.GetItems()
 	validProviders = cloudproviderutil.GetEnabledNames(cloudProviders)
 
 	return validProviders, directive
 }
+
+// FetchCloudRegions returns the list of supported regions of a cloud provider for creating a Kafka instance
+// This is used in the cmd.RegisterFlagCompletionFunc for dynamic completion of --region
+func FetchCloudRegions(f *factory.Factory, providerName string) (validRegions []string, directive cobra.ShellCompDirective) {
+	validRegions = []string{}
+	directive = cobra.ShellCompDirectiveNoSpace
+
+	if providerName == "" {
+		return validRegions, directive
+	}
+
+	conn, err := f.Connection(connection.DefaultConfigSkipMasAuth)
+	if err != nil {
+		return validRegions, directive
+	}
+
+	cloudProviders, err := GetCloudProviders(f.Config, conn)
+	if err != nil {
+		return validRegions, directive
+	}
+
+	cloudProvider := cloudproviderutil.FindByName(cloudProviders, providerName)
+	if cloudProvider == nil {
+		return validRegions, directive
+	}
+
+	regions, err := GetCloudRegions(f.Config, conn, cloudProvider.GetId())
+	if err != nil {
+		return validRegions, directive
+	}
+
+	validRegions = cloudregionutil.GetEnabledIDs(regions)
+
+	return validRegions, directive
+}
+
+// filterPrefix returns the values which start with prefix
+func filterPrefix(values []string, prefix string) []string {
+	filtered := []string{}
+	for _, value := range values {
+		if strings.HasPrefix(value, prefix) {
+			filtered = append(filtered, value)
+		}
+	}
+	return filtered
+}
@@ -1,8 +1,10 @@
 package cmdutil
 
+import "github.com/redhat-developer/app-services-cli/pkg/dump"
+
 const (
 	// The default indentation to use when printing data to stdout
-	DefaultJSONIndent = "    "
+	DefaultJSONIndent = dump.JSONIndent
 
 	// DefaultPageSize is the default number of items per page when using list commands
 	DefaultPageSize = 10
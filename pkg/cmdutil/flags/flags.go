@@ -8,7 +8,7 @@ import (
 
 var (
 	ValidOutputFormats       = []string{dump.JSONFormat, dump.YAMLFormat, dump.YMLFormat}
-	CredentialsOutputFormats = []string{"env", "json", "properties"}
+	CredentialsOutputFormats = []string{"env", "json", "properties", "yaml", "jaas", "librdkafka", "secret", "template"}
 )
 
 // IsValidInput checks if the input value is in the range of valid values
@@ -0,0 +1,19 @@
+package cmdutil
+
+import (
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/auth/token"
+)
+
+// OwnerMe is the value of the --owner flags which stands for the current user
+const OwnerMe = "me"
+
+// ResolveOwner returns the username of the current user when the owner is "me", and the owner as is otherwise.
+// It returns false when the username cannot be read from the access token.
+// The configuration should be loaded after connecting, as the tokens may have been refreshed
+func ResolveOwner(cfg *config.Config, owner string) (string, bool) {
+	if owner != OwnerMe {
+		return owner, true
+	}
+	return token.GetUsername(cfg.AccessToken)
+}
@@ -0,0 +1,30 @@
+package cmdutil
+
+import "github.com/spf13/cobra"
+
+// PluginAnnotation is the annotation of the commands which run plugins, set to the path of the plugin
+const PluginAnnotation = "rhoas.plugin"
+
+// IsPluginCommand returns true if the command runs a plugin
+func IsPluginCommand(cmd *cobra.Command) bool {
+	_, ok := cmd.Annotations[PluginAnnotation]
+	return ok
+}
+
+// IsBuiltinCommand returns true if a built-in sub-command of the root command
+// has the name or alias, in which case a plugin with the name is ignored
+func IsBuiltinCommand(root *cobra.Command, name string) bool {
+	// the help command is only added when the command is executed
+	if name == "help" {
+		return true
+	}
+	for _, cmd := range root.Commands() {
+		if IsPluginCommand(cmd) {
+			continue
+		}
+		if cmd.Name() == name || cmd.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,220 @@
+// Package clierr classifies the errors returned by the commands into a stable taxonomy,
+// which scripts can rely on through the exit code of the process and the JSON error envelope
+package clierr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-developer/app-services-cli/pkg/api/kas"
+)
+
+// Code is the category of an error
+type Code string
+
+// Codes of the errors, each of which is reported with its own exit code
+const (
+	CodeGeneral            Code = "general"
+	CodeValidation         Code = "validation"
+	CodeNotLoggedIn        Code = "not_logged_in"
+	CodeForbidden          Code = "forbidden"
+	CodeNotFound           Code = "not_found"
+	CodeConflict           Code = "conflict"
+	CodeQuotaExceeded      Code = "quota_exceeded"
+	CodeServiceUnavailable Code = "service_unavailable"
+	CodeTimeout            Code = "timeout"
+	// CodeDifferences is returned when the compared resources are different, which is not a failure of the command
+	CodeDifferences Code = "differences"
+)
+
+// exitCodes are the exit codes of the process for each code.
+// They are part of the interface of the CLI, so they must never change
+var exitCodes = map[Code]int{
+	CodeGeneral:            1,
+	CodeValidation:         2,
+	CodeNotLoggedIn:        3,
+	CodeForbidden:          4,
+	CodeNotFound:           5,
+	CodeConflict:           6,
+	CodeQuotaExceeded:      7,
+	CodeServiceUnavailable: 8,
+	CodeTimeout:            9,
+	CodeDifferences:        10,
+}
+
+// FormatEnvName is the environment variable which sets the format of the errors to "json"
+const FormatEnvName = "RHOAS_ERROR_FORMAT"
+
+// JSONFormat is the format of the errors printed as a JSON envelope
+const JSONFormat = "json"
+
+// Coder is implemented by the errors which know their code
+type Coder interface {
+	ErrorCode() Code
+}
+
+// Error is an error with a code
+type Error struct {
+	Code Code
+	Err  error
+}
+
+// New returns err with the given code
+func New(code Code, err error) *Error {
+	return &Error{Code: code, Err: err}
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCode returns the code of the error
+func (e *Error) ErrorCode() Code {
+	return e.Code
+}
+
+// Envelope is the description of an error printed with the JSON format
+type Envelope struct {
+	Code     Code   `json:"code"`
+	ExitCode int    `json:"exit_code"`
+	Message  string `json:"message"`
+	// HTTPStatus is the status of the failed API request, if any
+	HTTPStatus int `json:"http_status,omitempty"`
+	// OperationID identifies the failed API request for the support team, if any
+	OperationID string `json:"operation_id,omitempty"`
+}
+
+// apiError is implemented by the errors of the API clients generated by OpenAPI Generator,
+// whose message is the status of the response
+type apiError interface {
+	error
+	Body() []byte
+}
+
+// apiErrorBody contains the fields common to the error responses of the APIs
+type apiErrorBody struct {
+	Code        string `json:"code"`
+	OperationID string `json:"operation_id"`
+}
+
+// Describe classifies the error
+func Describe(err error) *Envelope {
+	envelope := &Envelope{Code: CodeGeneral, Message: err.Error()}
+
+	var apiErr apiError
+	if errors.As(err, &apiErr) {
+		envelope.HTTPStatus = statusFromMessage(apiErr.Error())
+		var body apiErrorBody
+		// the body of the response may not be JSON, such as the pages of the proxies
+		_ = json.Unmarshal(apiErr.Body(), &body)
+		envelope.OperationID = body.OperationID
+		envelope.Code = codeFromAPIError(body.Code, envelope.HTTPStatus)
+	}
+
+	// the code set by the commands takes precedence over the one guessed from the API error
+	var coder Coder
+	var netErr net.Error
+	switch {
+	case errors.As(err, &coder):
+		envelope.Code = coder.ErrorCode()
+	case errors.Is(err, context.DeadlineExceeded):
+		envelope.Code = CodeTimeout
+	case errors.As(err, &netErr) && netErr.Timeout():
+		envelope.Code = CodeTimeout
+	case envelope.HTTPStatus == 0 && errors.As(err, &netErr):
+		envelope.Code = CodeServiceUnavailable
+	}
+
+	envelope.ExitCode = ExitCode(envelope.Code)
+	return envelope
+}
+
+// ExitCode returns the exit code of the process for the code
+func ExitCode(code Code) int {
+	if exitCode, ok := exitCodes[code]; ok {
+		return exitCode
+	}
+	return exitCodes[CodeGeneral]
+}
+
+// WriteJSON prints the envelope of the error to w
+func WriteJSON(w io.Writer, envelope *Envelope) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		Error *Envelope `json:"error"`
+	}{envelope})
+}
+
+// serviceErrorCodes are the codes of the errors of the Kafka Management API
+var serviceErrorCodes = map[kas.ServiceErrorCode]Code{
+	kas.ErrorMaxAllowedInstanceReached: CodeQuotaExceeded,
+	kas.ErrorUnauthenticated:           CodeNotLoggedIn,
+	kas.ErrorForbidden:                 CodeForbidden,
+	kas.ErrorUnauthorized:              CodeForbidden,
+	kas.ErrorNotFound:                  CodeNotFound,
+	kas.ErrorConflict:                  CodeConflict,
+	kas.ErrorDuplicateKafkaClusterName: CodeConflict,
+	kas.ErrorValidation:                CodeValidation,
+	kas.ErrorBadRequest:                CodeValidation,
+	kas.ErrorMalformedRequest:          CodeValidation,
+	kas.ErrorMalformedKafkaClusterName: CodeValidation,
+	kas.ErrorFailedToParseSearch:       CodeValidation,
+	kas.ErrorProviderNotSupported:      CodeValidation,
+	kas.ErrorRegionNotSupported:        CodeValidation,
+	kas.ErrorMinimumFieldLength:        CodeValidation,
+	kas.ErrorMaximumFieldLength:        CodeValidation,
+}
+
+// codeFromAPIError returns the code of an API error from its service error code, such as "KAFKAS-MGMT-5",
+// falling back to its HTTP status
+func codeFromAPIError(serviceCode string, status int) Code {
+	prefix := kas.ErrCodePrefix + "-"
+	if strings.HasPrefix(serviceCode, prefix) {
+		n, err := strconv.Atoi(strings.TrimPrefix(serviceCode, prefix))
+		if code, ok := serviceErrorCodes[kas.ServiceErrorCode(n)]; err == nil && ok {
+			return code
+		}
+	}
+
+	switch {
+	case status == 400 || status == 422:
+		return CodeValidation
+	case status == 401:
+		return CodeNotLoggedIn
+	case status == 403:
+		return CodeForbidden
+	case status == 404:
+		return CodeNotFound
+	case status == 409 || status == 423:
+		return CodeConflict
+	case status == 408 || status == 504:
+		return CodeTimeout
+	case status == 429 || status >= 500:
+		return CodeServiceUnavailable
+	default:
+		return CodeGeneral
+	}
+}
+
+// statusFromMessage returns the HTTP status code from the message of an API error, such as "404 Not Found"
+func statusFromMessage(message string) int {
+	fields := strings.Fields(message)
+	if len(fields) == 0 {
+		return 0
+	}
+	status, err := strconv.Atoi(fields[0])
+	if err != nil || status < 100 || status > 599 {
+		return 0
+	}
+	return status
+}
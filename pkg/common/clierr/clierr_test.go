@@ -0,0 +1,136 @@
+package clierr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+)
+
+// fakeAPIError is an error of the API clients, whose message is the status of the response
+type fakeAPIError struct {
+	status string
+	body   string
+}
+
+func (e fakeAPIError) Error() string {
+	return e.status
+}
+
+func (e fakeAPIError) Body() []byte {
+	return []byte(e.body)
+}
+
+type fakeTimeoutError struct{}
+
+func (fakeTimeoutError) Error() string   { return "i/o timeout" }
+func (fakeTimeoutError) Timeout() bool   { return true }
+func (fakeTimeoutError) Temporary() bool { return true }
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		name            string
+		err             error
+		wantCode        Code
+		wantExitCode    int
+		wantStatus      int
+		wantOperationID string
+	}{
+		{name: "plain error", err: errors.New("failed"), wantCode: CodeGeneral, wantExitCode: 1},
+		{name: "tagged error", err: New(CodeConflict, errors.New("exists")), wantCode: CodeConflict, wantExitCode: 6},
+		{
+			name:         "wrapped tagged error",
+			err:          fmt.Errorf("wrapped: %w", New(CodeNotFound, errors.New("missing"))),
+			wantCode:     CodeNotFound,
+			wantExitCode: 5,
+		},
+		{
+			name:            "API error with a service error code",
+			err:             fakeAPIError{status: "403 Forbidden", body: `{"code":"KAFKAS-MGMT-5","operation_id":"op-1"}`},
+			wantCode:        CodeQuotaExceeded,
+			wantExitCode:    7,
+			wantStatus:      403,
+			wantOperationID: "op-1",
+		},
+		{
+			name:            "API error with a status only",
+			err:             fakeAPIError{status: "404 Not Found", body: `{"operation_id":"op-2"}`},
+			wantCode:        CodeNotFound,
+			wantExitCode:    5,
+			wantStatus:      404,
+			wantOperationID: "op-2",
+		},
+		{
+			name:         "API error with an unexpected body",
+			err:          fakeAPIError{status: "503 Service Unavailable", body: "<html>"},
+			wantCode:     CodeServiceUnavailable,
+			wantExitCode: 8,
+			wantStatus:   503,
+		},
+		{
+			name:         "tagged API error",
+			err:          New(CodeConflict, fakeAPIError{status: "400 Bad Request", body: `{}`}),
+			wantCode:     CodeConflict,
+			wantExitCode: 6,
+			wantStatus:   400,
+		},
+		{name: "deadline", err: fmt.Errorf("waiting: %w", context.DeadlineExceeded), wantCode: CodeTimeout, wantExitCode: 9},
+		{
+			name:         "network timeout",
+			err:          &net.OpError{Op: "dial", Err: fakeTimeoutError{}},
+			wantCode:     CodeTimeout,
+			wantExitCode: 9,
+		},
+		{
+			name:         "network error",
+			err:          &net.OpError{Op: "dial", Err: errors.New("connection refused")},
+			wantCode:     CodeServiceUnavailable,
+			wantExitCode: 8,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Describe(tt.err)
+			if got.Code != tt.wantCode || got.ExitCode != tt.wantExitCode {
+				t.Errorf("Describe() = %v (exit code %v), want %v (exit code %v)", got.Code, got.ExitCode, tt.wantCode, tt.wantExitCode)
+			}
+			if got.HTTPStatus != tt.wantStatus {
+				t.Errorf("HTTPStatus = %v, want %v", got.HTTPStatus, tt.wantStatus)
+			}
+			if got.OperationID != tt.wantOperationID {
+				t.Errorf("OperationID = %q, want %q", got.OperationID, tt.wantOperationID)
+			}
+			if got.Message != tt.err.Error() {
+				t.Errorf("Message = %q, want %q", got.Message, tt.err.Error())
+			}
+		})
+	}
+}
+
+func TestExitCodeUnknown(t *testing.T) {
+	if got := ExitCode("unknown"); got != 1 {
+		t.Errorf("ExitCode() = %v, want 1", got)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, Describe(New(CodeNotFound, errors.New("missing")))); err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	envelope := got["error"]
+	if envelope["code"] != "not_found" || envelope["exit_code"] != float64(5) || envelope["message"] != "missing" {
+		t.Errorf("unexpected envelope: %v", envelope)
+	}
+	if _, ok := envelope["http_status"]; ok {
+		t.Errorf("expected no HTTP status, got %v", envelope)
+	}
+}
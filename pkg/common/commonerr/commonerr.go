@@ -1,10 +1,14 @@
 package commonerr
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/redhat-developer/app-services-cli/pkg/common/clierr"
+)
 
 var CastErr error
 
 func NewCastError(v interface{}, t string) error {
-	CastErr = fmt.Errorf(`could not cast %v, to type "%v"`, v, t)
+	CastErr = clierr.New(clierr.CodeValidation, fmt.Errorf(`could not cast %v, to type "%v"`, v, t))
 	return CastErr
 }
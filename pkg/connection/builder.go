@@ -23,6 +23,7 @@ import (
 // Don't create instances of this type directly, use the NewBulder function instead
 type Builder struct {
 	trustedCAs        *x509.CertPool
+	clientCert        *tls.Certificate
 	insecure          bool
 	disableKeepAlives bool
 	accessToken       string
@@ -76,6 +77,12 @@ func (b *Builder) WithTrustedCAs(value *x509.CertPool) *Builder {
 	return b
 }
 
+// WithClientCertificate sets the certificate used to authenticate to servers which require mutual TLS
+func (b *Builder) WithClientCertificate(cert *tls.Certificate) *Builder {
+	b.clientCert = cert
+	return b
+}
+
 func (b *Builder) WithInsecure(insecure bool) *Builder {
 	b.insecure = insecure
 	return b
@@ -246,23 +253,22 @@ func (b *Builder) BuildContext(ctx context.Context) (connection *KeycloakConnect
 		return nil, fmt.Errorf("unable to get realm name from Auth URL: '%s'", b.authURL)
 	}
 
+	// the SSO clients use the same TLS configuration as the API clients
 	keycloak := gocloak.NewClient(baseAuthURL)
 	restyClient := *keycloak.RestyClient()
-	// #nosec 402
-	restyClient.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: b.insecure})
+	restyClient.SetTLSClientConfig(b.tlsConfig())
 	keycloak.SetRestyClient(&restyClient)
 
 	baseMasAuthURL := fmt.Sprintf("%v://%v", masAuthURL.Scheme, masAuthURL.Host)
 	masKc := gocloak.NewClient(baseMasAuthURL)
-	masRestyClient := *keycloak.RestyClient()
+	masRestyClient := *masKc.RestyClient()
 
 	_, masKcRealm, ok := SplitKeycloakRealmURL(masAuthURL)
 	if !ok {
 		return nil, fmt.Errorf("unable to get realm name from Auth URL: '%s'", b.masAuthURL)
 	}
 
-	// #nosec 402
-	restyClient.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: b.insecure})
+	masRestyClient.SetTLSClientConfig(b.tlsConfig())
 	masKc.SetRestyClient(&masRestyClient)
 
 	connection = &KeycloakConnection{
@@ -282,18 +288,15 @@ func (b *Builder) BuildContext(ctx context.Context) (connection *KeycloakConnect
 		Config:            b.config,
 		connectionConfig:  b.connectionConfig,
 	}
+	connection.newTokenSources()
 
 	return connection, nil
 }
 
 func (b *Builder) createTransport() (transport http.RoundTripper) {
 	// Create the raw transport:
-	// #nosec 402
 	transport = &http.Transport{
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: b.insecure,
-			RootCAs:            b.trustedCAs,
-		},
+		TLSClientConfig:   b.tlsConfig(),
 		Proxy:             http.ProxyFromEnvironment,
 		DisableKeepAlives: b.disableKeepAlives,
 	}
@@ -305,3 +308,7 @@ func (b *Builder) createTransport() (transport http.RoundTripper) {
 
 	return
 }
+
+func (b *Builder) tlsConfig() *tls.Config {
+	return NewTLSConfig(b.insecure, b.trustedCAs, b.clientCert)
+}
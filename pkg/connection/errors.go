@@ -3,6 +3,8 @@ package connection
 import (
 	"errors"
 	"fmt"
+
+	"github.com/redhat-developer/app-services-cli/pkg/common/clierr"
 )
 
 // AuthError defines an Authentication error
@@ -30,6 +32,16 @@ func (e *AuthError) Unwrap() error {
 	return e.Err
 }
 
+// ErrorCode returns the code of authentication errors, which are resolved by logging in again
+func (e *AuthError) ErrorCode() clierr.Code {
+	return clierr.CodeNotLoggedIn
+}
+
+// ErrorCode returns the code of authentication errors, which are resolved by logging in again
+func (e *MasAuthError) ErrorCode() clierr.Code {
+	return clierr.CodeNotLoggedIn
+}
+
 func AuthErrorf(format string, a ...interface{}) *AuthError {
 	err := fmt.Errorf(format, a...)
 	return &AuthError{err}
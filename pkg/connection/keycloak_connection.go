@@ -54,67 +54,61 @@ type KeycloakConnection struct {
 	logger            logging.Logger
 	Config            config.IConfig
 	connectionConfig  *Config
+	tokenSource       *refreshingTokenSource
+	masTokenSource    *refreshingTokenSource
 }
 
-// RefreshTokens will fetch a refreshed copy of the access token and refresh token from the authentication server
-// The new tokens will have an increased expiry time and are persisted in the config and connection
+// RefreshTokens refreshes the access tokens required by the connection when they are missing or about to expire.
+// The new tokens are persisted in the config and connection
 func (c *KeycloakConnection) RefreshTokens(ctx context.Context) (err error) {
-	c.logger.Debug("Refreshing tokens")
-
-	cfg, err := c.Config.Load()
-	if err != nil {
-		return err
-	}
-
-	// track if we need to update the config with new token values
-	var cfgChanged bool
 	if c.connectionConfig.RequireAuth {
-		// nolint:govet
-		refreshedTk, err := c.keycloakClient.RefreshToken(ctx, c.Token.RefreshToken, c.clientID, "", c.defaultRealm)
-		if err != nil {
-			return &AuthError{err}
-		}
-
-		if refreshedTk.AccessToken != c.Token.AccessToken {
-			c.Token.AccessToken = refreshedTk.AccessToken
-			cfg.AccessToken = refreshedTk.AccessToken
-			cfgChanged = true
-		}
-		if refreshedTk.RefreshToken != c.Token.RefreshToken {
-			c.Token.RefreshToken = refreshedTk.RefreshToken
-			cfg.RefreshToken = refreshedTk.RefreshToken
-			cfgChanged = true
+		if _, err = c.tokenSource.accessToken(ctx); err != nil {
+			return err
 		}
 	}
 
 	if c.connectionConfig.RequireMASAuth {
-		// nolint:govet
-		refreshedMasTk, err := c.masKeycloakClient.RefreshToken(ctx, c.MASToken.RefreshToken, c.clientID, "", c.masRealm)
-		if err != nil {
-			return &MasAuthError{err}
-		}
-		if refreshedMasTk.AccessToken != c.MASToken.AccessToken {
-			c.MASToken.AccessToken = refreshedMasTk.AccessToken
-			cfg.MasAccessToken = refreshedMasTk.AccessToken
-			cfgChanged = true
-		}
-		if refreshedMasTk.RefreshToken != c.MASToken.RefreshToken {
-			c.MASToken.RefreshToken = refreshedMasTk.RefreshToken
-			cfg.MasRefreshToken = refreshedMasTk.RefreshToken
-			cfgChanged = true
+		if _, err = c.masTokenSource.accessToken(ctx); err != nil {
+			return err
 		}
 	}
 
-	if !cfgChanged {
-		return nil
-	}
+	return nil
+}
 
-	if err = c.Config.Save(cfg); err != nil {
-		return err
+// newTokenSources creates the token sources which refresh the tokens of the connection
+func (c *KeycloakConnection) newTokenSources() {
+	c.tokenSource = &refreshingTokenSource{
+		token:  c.Token,
+		logger: c.logger,
+		config: c.Config,
+		refresh: func(ctx context.Context, refreshToken string) (*gocloak.JWT, error) {
+			return c.keycloakClient.RefreshToken(ctx, refreshToken, c.clientID, "", c.defaultRealm)
+		},
+		persist: func(cfg *config.Config, t *token.Token) {
+			cfg.AccessToken = t.AccessToken
+			cfg.RefreshToken = t.RefreshToken
+		},
+		wrapErr: func(err error) error {
+			return &AuthError{err}
+		},
 	}
-	c.logger.Debug("Tokens refreshed")
 
-	return nil
+	c.masTokenSource = &refreshingTokenSource{
+		token:  c.MASToken,
+		logger: c.logger,
+		config: c.Config,
+		refresh: func(ctx context.Context, refreshToken string) (*gocloak.JWT, error) {
+			return c.masKeycloakClient.RefreshToken(ctx, refreshToken, c.clientID, "", c.masRealm)
+		},
+		persist: func(cfg *config.Config, t *token.Token) {
+			cfg.MasAccessToken = t.AccessToken
+			cfg.MasRefreshToken = t.RefreshToken
+		},
+		wrapErr: func(err error) error {
+			return &MasAuthError{err}
+		},
+	}
 }
 
 // Logout logs the user out from the authentication server
@@ -138,17 +132,13 @@ func (c *KeycloakConnection) Logout(ctx context.Context) (err error) {
 	c.MASToken.AccessToken = ""
 	c.MASToken.RefreshToken = ""
 
-	cfg, err := c.Config.Load()
-	if err != nil {
-		return err
-	}
-
-	cfg.AccessToken = ""
-	cfg.RefreshToken = ""
-	cfg.MasAccessToken = ""
-	cfg.MasRefreshToken = ""
-
-	return c.Config.Save(cfg)
+	return c.Config.Update(func(cfg *config.Config) error {
+		cfg.AccessToken = ""
+		cfg.RefreshToken = ""
+		cfg.MasAccessToken = ""
+		cfg.MasRefreshToken = ""
+		return nil
+	})
 }
 
 // API Creates a new API type which is a single type for multiple APIs
@@ -231,7 +221,7 @@ func (c *KeycloakConnection) API() *api.API {
 
 // Create a new Kafka API client
 func (c *KeycloakConnection) createKafkaAPIClient() *kafkamgmtclient.APIClient {
-	tc := c.createOAuthTransport(c.Token.AccessToken)
+	tc := c.createOAuthTransport(c.tokenSource)
 	client := kafkamgmt.NewAPIClient(&kafkamgmt.Config{
 		BaseURL:    c.apiURL.String(),
 		Debug:      c.logger.DebugEnabled(),
@@ -243,7 +233,7 @@ func (c *KeycloakConnection) createKafkaAPIClient() *kafkamgmtclient.APIClient {
 
 // Create a new Registry API client
 func (c *KeycloakConnection) createServiceRegistryAPIClient() *registrymgmtclient.APIClient {
-	tc := c.createOAuthTransport(c.Token.AccessToken)
+	tc := c.createOAuthTransport(c.tokenSource)
 	client := registrymgmt.NewAPIClient(&registrymgmt.Config{
 		BaseURL:    c.apiURL.String(),
 		Debug:      c.logger.DebugEnabled(),
@@ -279,7 +269,7 @@ func (c *KeycloakConnection) createKafkaAdminAPI(bootstrapURL string) *kafkainst
 	client := kafkainstance.NewAPIClient(&kafkainstance.Config{
 		BaseURL:    apiURL.String(),
 		Debug:      c.logger.DebugEnabled(),
-		HTTPClient: c.createOAuthTransport(c.MASToken.AccessToken),
+		HTTPClient: c.createOAuthTransport(c.masTokenSource),
 	})
 
 	return client
@@ -291,7 +281,7 @@ func (c *KeycloakConnection) createAmsAPIClient() *amsclient.APIClient {
 	cfg.Scheme = c.apiURL.Scheme
 	cfg.Host = c.apiURL.Host
 
-	cfg.HTTPClient = c.createOAuthTransport(c.Token.AccessToken)
+	cfg.HTTPClient = c.createOAuthTransport(c.tokenSource)
 
 	apiClient := amsclient.NewAPIClient(cfg)
 
@@ -299,17 +289,14 @@ func (c *KeycloakConnection) createAmsAPIClient() *amsclient.APIClient {
 }
 
 // wraps the HTTP client with an OAuth2 Transport layer to provide automatic token refreshing
-func (c *KeycloakConnection) createOAuthTransport(accessToken string) *http.Client {
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{
-			AccessToken: accessToken,
-		},
-	)
-
+func (c *KeycloakConnection) createOAuthTransport(source *refreshingTokenSource) *http.Client {
 	return &http.Client{
-		Transport: &oauth2.Transport{
-			Base:   c.defaultHTTPClient.Transport,
-			Source: oauth2.ReuseTokenSource(nil, ts),
+		Transport: &unauthorizedRetryTransport{
+			Base: &oauth2.Transport{
+				Base:   c.defaultHTTPClient.Transport,
+				Source: source,
+			},
+			Source: source,
 		},
 	}
 }
@@ -0,0 +1,116 @@
+package connection
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/api"
+	"github.com/redhat-developer/app-services-cli/pkg/api/ams/amsclient"
+	"github.com/redhat-developer/app-services-cli/pkg/logging"
+	kafkainstance "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+	kafkamgmt "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	registrymgmt "github.com/redhat-developer/app-services-sdk-go/registrymgmt/apiv1"
+	registrymgmtclient "github.com/redhat-developer/app-services-sdk-go/registrymgmt/apiv1/client"
+)
+
+// ErrUnavailableInLocalProfile is returned by the control plane APIs of a LocalConnection
+var ErrUnavailableInLocalProfile = errors.New(`this command is not available with the local profile, run "rhoas login" to use the cloud services`)
+
+// localBaseURL is the placeholder base URL of the control plane APIs, which are not available locally
+const localBaseURL = "http://localhost"
+
+// LocalConnection connects to the local development environment created by "rhoas dev init".
+// There is no control plane locally, so only the Kafka instance APIs are available
+type LocalConnection struct {
+	profile    *config.LocalProfileConfig
+	httpClient *http.Client
+	logger     logging.Logger
+}
+
+// NewLocalConnection creates a connection to the local development environment.
+// transportWrapper may be nil
+func NewLocalConnection(profile *config.LocalProfileConfig, logger logging.Logger, transportWrapper TransportWrapper) *LocalConnection {
+	var transport http.RoundTripper = http.DefaultTransport
+	if transportWrapper != nil {
+		transport = transportWrapper(transport)
+	}
+
+	return &LocalConnection{
+		profile:    profile,
+		httpClient: &http.Client{Transport: transport},
+		logger:     logger,
+	}
+}
+
+// RefreshTokens is a no-op, the local environment does not require authentication
+func (c *LocalConnection) RefreshTokens(ctx context.Context) error {
+	return nil
+}
+
+// Logout is a no-op, the local environment does not require authentication
+func (c *LocalConnection) Logout(ctx context.Context) error {
+	return nil
+}
+
+// API creates the API clients for the local environment
+func (c *LocalConnection) API() *api.API {
+	unavailableClient := &http.Client{Transport: unavailableTransport{}}
+
+	kafkaAPIClient := func() *kafkamgmtclient.APIClient {
+		return kafkamgmt.NewAPIClient(&kafkamgmt.Config{
+			BaseURL:    localBaseURL,
+			HTTPClient: unavailableClient,
+		})
+	}
+
+	return &api.API{
+		Kafka: func() kafkamgmtclient.DefaultApi {
+			return kafkaAPIClient().DefaultApi
+		},
+		ServiceAccount: func() kafkamgmtclient.SecurityApi {
+			return kafkaAPIClient().SecurityApi
+		},
+		AccountMgmt: func() amsclient.DefaultApi {
+			cfg := amsclient.NewConfiguration()
+			cfg.HTTPClient = unavailableClient
+			return amsclient.NewAPIClient(cfg).DefaultApi
+		},
+		ServiceRegistryMgmt: func() registrymgmtclient.RegistriesApi {
+			return registrymgmt.NewAPIClient(&registrymgmt.Config{
+				BaseURL:    localBaseURL,
+				HTTPClient: unavailableClient,
+			}).RegistriesApi
+		},
+		KafkaAdmin: c.kafkaAdminAPI,
+	}
+}
+
+// kafkaAdminAPI creates the client of the local admin server, whatever the requested Kafka ID
+func (c *LocalConnection) kafkaAdminAPI(_ string) (*kafkainstanceclient.APIClient, *kafkamgmtclient.KafkaRequest, error) {
+	c.logger.Debugf("Making request to %v", c.profile.AdminAPIURL)
+
+	client := kafkainstance.NewAPIClient(&kafkainstance.Config{
+		BaseURL:    c.profile.AdminAPIURL,
+		Debug:      c.logger.DebugEnabled(),
+		HTTPClient: c.httpClient,
+	})
+
+	kafkaInstance := kafkamgmtclient.NewKafkaRequestWithDefaults()
+	kafkaInstance.SetId(config.LocalInstanceID)
+	kafkaInstance.SetName(config.LocalInstanceID)
+	kafkaInstance.SetStatus("ready")
+	kafkaInstance.SetBootstrapServerHost(c.profile.BootstrapServerHost)
+
+	return client, kafkaInstance, nil
+}
+
+// unavailableTransport fails every request, as the control plane is not available locally
+type unavailableTransport struct{}
+
+func (unavailableTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, ErrUnavailableInLocalProfile
+}
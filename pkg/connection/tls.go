@@ -0,0 +1,55 @@
+package connection
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+)
+
+// LoadTrustedCAs returns the system certificate pool with the PEM encoded certificates of caFile added to it.
+// This makes it possible to trust the certificate authority of a TLS-intercepting proxy
+func LoadTrustedCAs(caFile string) (*x509.CertPool, error) {
+	// #nosec G304
+	data, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read CA file: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		// the system pool is not available on every platform
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM encoded certificates found in CA file '%v'", caFile)
+	}
+
+	return pool, nil
+}
+
+// LoadClientCertificate loads the PEM encoded client certificate and private key
+// used to authenticate to servers which require mutual TLS
+func LoadClientCertificate(certFile string, keyFile string) (*tls.Certificate, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load client certificate: %w", err)
+	}
+
+	return &cert, nil
+}
+
+// NewTLSConfig creates the TLS configuration shared by every client of a connection
+func NewTLSConfig(insecure bool, trustedCAs *x509.CertPool, clientCert *tls.Certificate) *tls.Config {
+	// #nosec 402
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: insecure,
+		RootCAs:            trustedCAs,
+	}
+	if clientCert != nil {
+		tlsConfig.Certificates = []tls.Certificate{*clientCert}
+	}
+
+	return tlsConfig
+}
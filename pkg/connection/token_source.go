@@ -0,0 +1,144 @@
+package connection
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"github.com/Nerzal/gocloak/v7"
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/auth/token"
+	"github.com/redhat-developer/app-services-cli/pkg/logging"
+	"golang.org/x/oauth2"
+)
+
+// refreshingTokenSource is an oauth2.TokenSource which returns the access token of a connection,
+// refreshing it only when it is missing or about to expire.
+// The refreshed tokens are persisted to the config
+type refreshingTokenSource struct {
+	mu     sync.Mutex
+	token  *token.Token
+	logger logging.Logger
+	config config.IConfig
+
+	// refresh exchanges the refresh token for new tokens
+	refresh func(ctx context.Context, refreshToken string) (*gocloak.JWT, error)
+	// persist sets the new tokens in the config
+	persist func(cfg *config.Config, t *token.Token)
+	// wrapErr wraps the errors returned by refresh
+	wrapErr func(err error) error
+}
+
+// Token returns the current access token, refreshing it first if needed
+func (s *refreshingTokenSource) Token() (*oauth2.Token, error) {
+	accessToken, err := s.accessToken(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Token{AccessToken: accessToken}, nil
+}
+
+// accessToken returns the current access token, refreshing it first if needed
+func (s *refreshingTokenSource) accessToken(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.NeedsRefresh() {
+		if err := s.refreshLocked(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	return s.token.AccessToken, nil
+}
+
+// invalidate refreshes the tokens after the server rejected rejectedAccessToken.
+// Nothing is done when the tokens were already refreshed since the token was rejected
+func (s *refreshingTokenSource) invalidate(ctx context.Context, rejectedAccessToken string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.AccessToken != rejectedAccessToken {
+		return nil
+	}
+
+	return s.refreshLocked(ctx)
+}
+
+func (s *refreshingTokenSource) refreshLocked(ctx context.Context) error {
+	s.logger.Debug("Refreshing tokens")
+
+	refreshed, err := s.refresh(ctx, s.token.RefreshToken)
+	if err != nil {
+		return s.wrapErr(err)
+	}
+
+	if refreshed.AccessToken == s.token.AccessToken && refreshed.RefreshToken == s.token.RefreshToken {
+		return nil
+	}
+
+	s.token.AccessToken = refreshed.AccessToken
+	if refreshed.RefreshToken != "" {
+		s.token.RefreshToken = refreshed.RefreshToken
+	}
+
+	err = s.config.Update(func(cfg *config.Config) error {
+		s.persist(cfg, s.token)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logger.Debug("Tokens refreshed")
+
+	return nil
+}
+
+// unauthorizedRetryTransport retries a request once with refreshed tokens
+// when the server responds with 401 Unauthorized, as the access token may have been revoked
+// or may have expired while the command was running
+type unauthorizedRetryTransport struct {
+	// Base sets the access token of the source on the requests
+	Base   http.RoundTripper
+	Source *refreshingTokenSource
+}
+
+func (t *unauthorizedRetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// the access token which will be used by the first attempt
+	accessToken, err := t.Source.accessToken(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	// the body can only be sent again when it can be recreated
+	retryable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || !retryable {
+		return resp, err
+	}
+
+	if err = t.Source.invalidate(req.Context(), accessToken); err != nil {
+		t.Source.logger.Debug("Could not refresh tokens after an unauthorized response:", err)
+		return resp, nil
+	}
+
+	retryReq := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retryReq.Body, err = req.GetBody(); err != nil {
+			return resp, nil
+		}
+	}
+
+	// the first response is discarded
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	t.Source.logger.Debug("Retrying request with refreshed tokens:", req.URL.String())
+
+	return t.Base.RoundTrip(retryReq)
+}
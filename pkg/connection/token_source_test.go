@@ -0,0 +1,198 @@
+package connection
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nerzal/gocloak/v7"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/auth/token"
+	"github.com/redhat-developer/app-services-cli/pkg/logging"
+	"golang.org/x/oauth2"
+)
+
+func newJWT(t *testing.T, expiresIn time.Duration) string {
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"exp": time.Now().Add(expiresIn).Unix(),
+		// makes every generated token unique
+		"jti": time.Now().UnixNano(),
+	}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func newTestTokenSource(t *testing.T, accessToken string, cfg *config.Config, refreshed ...string) (*refreshingTokenSource, *int) {
+	logger, err := logging.NewStdLoggerBuilder().Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := 0
+	return &refreshingTokenSource{
+		token: &token.Token{
+			AccessToken:  accessToken,
+			RefreshToken: "refresh-token",
+			Logger:       logger,
+		},
+		logger: logger,
+		config: &config.IConfigMock{
+			UpdateFunc: func(fn func(c *config.Config) error) error {
+				return fn(cfg)
+			},
+		},
+		refresh: func(_ context.Context, refreshToken string) (*gocloak.JWT, error) {
+			if calls >= len(refreshed) {
+				return nil, errors.New("refresh token expired")
+			}
+			calls++
+			return &gocloak.JWT{AccessToken: refreshed[calls-1], RefreshToken: refreshToken}, nil
+		},
+		persist: func(cfg *config.Config, t *token.Token) {
+			cfg.AccessToken = t.AccessToken
+			cfg.RefreshToken = t.RefreshToken
+		},
+		wrapErr: func(err error) error {
+			return &AuthError{err}
+		},
+	}, &calls
+}
+
+func TestRefreshingTokenSource_DoesNotRefreshValidToken(t *testing.T) {
+	accessToken := newJWT(t, time.Hour)
+	cfg := &config.Config{AccessToken: accessToken}
+	source, calls := newTestTokenSource(t, accessToken, cfg)
+
+	got, err := source.Token()
+	if err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if got.AccessToken != accessToken {
+		t.Errorf("Token() = %v, want the current access token", got.AccessToken)
+	}
+	if *calls != 0 {
+		t.Errorf("expected no refresh, got %v", *calls)
+	}
+}
+
+func TestRefreshingTokenSource_RefreshesExpiringToken(t *testing.T) {
+	newAccessToken := newJWT(t, time.Hour)
+	cfg := &config.Config{}
+	source, calls := newTestTokenSource(t, newJWT(t, time.Minute), cfg, newAccessToken)
+
+	got, err := source.Token()
+	if err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if got.AccessToken != newAccessToken {
+		t.Errorf("Token() did not return the refreshed access token")
+	}
+	if *calls != 1 {
+		t.Errorf("expected 1 refresh, got %v", *calls)
+	}
+	if cfg.AccessToken != newAccessToken || cfg.RefreshToken != "refresh-token" {
+		t.Errorf("refreshed tokens were not persisted: %+v", cfg)
+	}
+
+	// the refreshed token is valid, so it is not refreshed again
+	if _, err = source.Token(); err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if *calls != 1 {
+		t.Errorf("expected 1 refresh, got %v", *calls)
+	}
+}
+
+func TestRefreshingTokenSource_WrapsRefreshError(t *testing.T) {
+	source, _ := newTestTokenSource(t, newJWT(t, -time.Minute), &config.Config{})
+
+	_, err := source.Token()
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		t.Errorf("Token() error = %v, want *AuthError", err)
+	}
+}
+
+func TestUnauthorizedRetryTransport(t *testing.T) {
+	revokedAccessToken := newJWT(t, time.Hour)
+	newAccessToken := newJWT(t, time.Hour)
+
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		if r.Header.Get("Authorization") != "Bearer "+newAccessToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{}
+	source, calls := newTestTokenSource(t, revokedAccessToken, cfg, newAccessToken)
+	client := &http.Client{
+		Transport: &unauthorizedRetryTransport{
+			Base:   &oauth2.Transport{Base: http.DefaultTransport, Source: source},
+			Source: source,
+		},
+	}
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"name":"my-topic"}`))
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	if *calls != 1 {
+		t.Errorf("expected 1 refresh, got %v", *calls)
+	}
+	if len(bodies) != 2 || bodies[1] != `{"name":"my-topic"}` {
+		t.Errorf("the request body was not sent again: %q", bodies)
+	}
+	if cfg.AccessToken != newAccessToken {
+		t.Error("refreshed tokens were not persisted")
+	}
+}
+
+func TestUnauthorizedRetryTransport_RetriesOnce(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	source, _ := newTestTokenSource(t, newJWT(t, time.Hour), &config.Config{}, newJWT(t, time.Hour), newJWT(t, time.Hour))
+	client := &http.Client{
+		Transport: &unauthorizedRetryTransport{
+			Base:   &oauth2.Transport{Base: http.DefaultTransport, Source: source},
+			Source: source,
+		},
+	}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("StatusCode = %v, want %v", resp.StatusCode, http.StatusUnauthorized)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %v", requests)
+	}
+}
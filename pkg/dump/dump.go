@@ -11,7 +11,6 @@ import (
 	"strings"
 
 	"github.com/landoop/tableprinter"
-	"github.com/redhat-developer/app-services-cli/pkg/cmdutil"
 
 	"gitlab.com/c0b/go-ordered-json"
 	"gopkg.in/yaml.v2"
@@ -23,6 +22,9 @@ const (
 	YMLFormat  = "yml"
 )
 
+// JSONIndent is the indentation of the JSON documents
+const JSONIndent = "    "
+
 // JSON dumps the given data to the given stream so that it looks pretty. If the data is a valid
 // JSON document then it will be indented before printing it. If the `jq` tool is available in the
 // path then it will be used for syntax highlighting.
@@ -96,7 +98,7 @@ func dumpYQ(stream io.Writer, data []byte) error {
 
 func dumpJSON(stream io.Writer, data interface{}) error {
 	encoder := json.NewEncoder(stream)
-	encoder.SetIndent("", cmdutil.DefaultJSONIndent)
+	encoder.SetIndent("", JSONIndent)
 	return encoder.Encode(data)
 }
 
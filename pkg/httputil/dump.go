@@ -0,0 +1,67 @@
+package httputil
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// requestBody returns a copy of the body of the request, without consuming it
+func requestBody(r *http.Request) []byte {
+	if r.Body == nil || r.Body == http.NoBody || r.GetBody == nil {
+		return nil
+	}
+	body, err := r.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+	data, _ := ioutil.ReadAll(body)
+	return data
+}
+
+// responseBody reads the body of the response and replaces it, so it can still be read by the caller
+func responseBody(resp *http.Response) ([]byte, error) {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return nil, nil
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+	return data, err
+}
+
+// dumpRequest returns the request in its HTTP/1.x wire representation, with the secrets redacted
+func dumpRequest(r *http.Request, body []byte) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v %v %v\r\n", r.Method, RedactURL(r.URL), r.Proto)
+	writeHeaders(&b, RedactHeaders(r.Header))
+	b.Write(RedactBody(r.Header.Get("Content-Type"), body))
+	return b.String()
+}
+
+// dumpResponse returns the response in its HTTP/1.x wire representation, with the secrets redacted
+func dumpResponse(resp *http.Response, body []byte) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v %v\r\n", resp.Proto, resp.Status)
+	writeHeaders(&b, RedactHeaders(resp.Header))
+	b.Write(RedactBody(resp.Header.Get("Content-Type"), body))
+	return b.String()
+}
+
+func writeHeaders(b *strings.Builder, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range header[name] {
+			fmt.Fprintf(b, "%v: %v\r\n", name, value)
+		}
+	}
+	b.WriteString("\r\n")
+}
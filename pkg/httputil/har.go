@@ -0,0 +1,204 @@
+package httputil
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HARRecorder records requests and responses in the HTTP Archive (HAR) 1.2 format,
+// which can be opened by browsers and shared with support.
+// The file is rewritten after every request, so it is complete even when the command fails
+type HARRecorder struct {
+	// Filename is the path of the HAR file
+	Filename string
+	// CreatorVersion is the version of the CLI recorded in the file
+	CreatorVersion string
+
+	mu      sync.Mutex
+	entries []harEntry
+}
+
+type harLog struct {
+	Log harLogContent `json:"log"`
+}
+
+type harLogContent struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+	// Error is the transport error of a request which failed without a response, as recorded by browsers
+	Error string `json:"_error,omitempty"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// Record adds a request and its response to the HAR file, with the secrets redacted
+func (h *HARRecorder) Record(start time.Time, r *http.Request, reqBody []byte, resp *http.Response, respBody []byte, wait time.Duration, receive time.Duration) error {
+	entry := newHAREntry(start, r, reqBody, wait+receive)
+	entry.Response = harResponse{
+		Status:      resp.StatusCode,
+		StatusText:  http.StatusText(resp.StatusCode),
+		HTTPVersion: resp.Proto,
+		Cookies:     []harNameValue{},
+		Headers:     harHeaders(RedactHeaders(resp.Header)),
+		Content: harContent{
+			Size:     len(respBody),
+			MimeType: resp.Header.Get("Content-Type"),
+			Text:     string(RedactBody(resp.Header.Get("Content-Type"), respBody)),
+		},
+		RedirectURL: resp.Header.Get("Location"),
+		HeadersSize: -1,
+		BodySize:    len(respBody),
+	}
+	entry.Timings = harTimings{
+		Wait:    milliseconds(wait),
+		Receive: milliseconds(receive),
+	}
+
+	return h.add(entry)
+}
+
+// RecordFailure adds a request which failed without a response to the HAR file, with the status 0
+// and the error, as browsers record the requests which failed
+func (h *HARRecorder) RecordFailure(start time.Time, r *http.Request, reqBody []byte, requestErr error, wait time.Duration) error {
+	entry := newHAREntry(start, r, reqBody, wait)
+	entry.Response = harResponse{
+		Cookies:     []harNameValue{},
+		Headers:     []harNameValue{},
+		HeadersSize: -1,
+		BodySize:    -1,
+		Error:       requestErr.Error(),
+	}
+	entry.Timings = harTimings{Wait: milliseconds(wait)}
+
+	return h.add(entry)
+}
+
+// newHAREntry returns an entry for the request, with the secrets redacted
+func newHAREntry(start time.Time, r *http.Request, reqBody []byte, total time.Duration) harEntry {
+	entry := harEntry{
+		StartedDateTime: start.Format(time.RFC3339Nano),
+		Time:            milliseconds(total),
+		Request: harRequest{
+			Method:      r.Method,
+			URL:         RedactURL(r.URL),
+			HTTPVersion: r.Proto,
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(RedactHeaders(r.Header)),
+			QueryString: []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(reqBody),
+		},
+	}
+
+	for name, values := range redactValues(r.URL.Query()) {
+		for _, value := range values {
+			entry.Request.QueryString = append(entry.Request.QueryString, harNameValue{Name: name, Value: value})
+		}
+	}
+
+	if len(reqBody) > 0 {
+		entry.Request.PostData = &harPostData{
+			MimeType: r.Header.Get("Content-Type"),
+			Text:     string(RedactBody(r.Header.Get("Content-Type"), reqBody)),
+		}
+	}
+
+	return entry
+}
+
+// add appends the entry and rewrites the HAR file
+func (h *HARRecorder) add(entry harEntry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.entries = append(h.entries, entry)
+
+	data, err := json.MarshalIndent(harLog{
+		Log: harLogContent{
+			Version: "1.2",
+			Creator: harCreator{Name: "rhoas", Version: h.CreatorVersion},
+			Entries: h.entries,
+		},
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(h.Filename, data, 0o600)
+}
+
+func harHeaders(header http.Header) []harNameValue {
+	headers := []harNameValue{}
+	for name, values := range header {
+		for _, value := range values {
+			headers = append(headers, harNameValue{Name: name, Value: value})
+		}
+	}
+	return headers
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -3,7 +3,6 @@ package httputil
 
 import (
 	"net/http"
-	"net/http/httputil"
 
 	"github.com/redhat-developer/app-services-cli/pkg/logging"
 )
@@ -15,7 +14,8 @@ type LoggingRoundTripper struct {
 }
 
 // RoundTrip logs the http request and response in debug mode
-// for all errors, where status code >= 400
+// for all errors, where status code >= 400.
+// Credentials and secrets are redacted from the logs
 func (c LoggingRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
 	resp, err := c.Proxied.RoundTrip(r)
 	if err != nil {
@@ -27,19 +27,14 @@ func (c LoggingRoundTripper) RoundTrip(r *http.Request) (*http.Response, error)
 		return resp, nil
 	}
 
-	requestDump, err := httputil.DumpRequest(r, true)
-	if err != nil {
-		return nil, err
-	}
-
-	c.Logger.Debug(string(requestDump))
+	c.Logger.Debug(dumpRequest(r, requestBody(r)))
 
-	responseDump, err := httputil.DumpResponse(resp, true)
+	body, err := responseBody(resp)
 	if err != nil {
 		return nil, err
 	}
 
-	c.Logger.Debug(string(responseDump))
+	c.Logger.Debug(dumpResponse(resp, body))
 
 	return resp, nil
 }
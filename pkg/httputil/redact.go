@@ -0,0 +1,76 @@
+package httputil
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces the secrets removed from logged requests and responses
+const Redacted = "REDACTED"
+
+// sensitiveHeaders are the headers whose values are credentials
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// sensitiveFieldPattern matches the names of the fields, form values and query parameters which hold secrets,
+// such as the client secret of a new service account or the tokens returned by the identity provider
+const sensitiveFieldPattern = `client_?secret|secret|password|access_?token|refresh_?token|id_?token|offline_?token|token`
+
+var (
+	sensitiveFieldRegexp = regexp.MustCompile(`(?i)^(` + sensitiveFieldPattern + `)$`)
+	// matches a JSON string field with a sensitive name, keeping the name in the first group
+	sensitiveJSONFieldRegexp = regexp.MustCompile(`(?i)("(?:` + sensitiveFieldPattern + `)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+)
+
+// RedactHeaders returns a copy of the headers where the credentials are redacted.
+// The scheme of the authorization headers is kept, so "Bearer" tokens can be told apart from others
+func RedactHeaders(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range sensitiveHeaders {
+		values := redacted.Values(name)
+		for i, value := range values {
+			if scheme := strings.SplitN(value, " ", 2); len(scheme) == 2 && strings.HasSuffix(name, "Authorization") {
+				values[i] = scheme[0] + " " + Redacted
+			} else {
+				values[i] = Redacted
+			}
+		}
+	}
+	return redacted
+}
+
+// RedactURL returns the URL with the values of the sensitive query parameters redacted
+func RedactURL(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.String()
+	}
+	redacted := *u
+	redacted.RawQuery = redactValues(u.Query()).Encode()
+	return redacted.String()
+}
+
+// RedactBody returns the body with the values of the sensitive fields redacted.
+// JSON and form encoded bodies are supported, other bodies are returned as they are
+func RedactBody(contentType string, body []byte) []byte {
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return []byte(Redacted)
+		}
+		return []byte(redactValues(values).Encode())
+	}
+
+	return sensitiveJSONFieldRegexp.ReplaceAll(body, []byte(`${1}"`+Redacted+`"`))
+}
+
+func redactValues(values url.Values) url.Values {
+	for name, v := range values {
+		if sensitiveFieldRegexp.MatchString(name) {
+			for i := range v {
+				v[i] = Redacted
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,84 @@
+package httputil
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        string
+	}{
+		{
+			name:        "service account secret",
+			contentType: "application/json",
+			body:        `{"id":"1","client_id":"srvc-acct-1","client_secret":"s3cr3t"}`,
+			want:        `{"id":"1","client_id":"srvc-acct-1","client_secret":"REDACTED"}`,
+		},
+		{
+			name:        "camel case and escaped quotes",
+			contentType: "application/json",
+			body:        "{\n  \"clientSecret\": \"s3\\\"cr3t\",\n  \"name\": \"token\"\n}",
+			want:        "{\n  \"clientSecret\": \"REDACTED\",\n  \"name\": \"token\"\n}",
+		},
+		{
+			name:        "tokens",
+			contentType: "application/json",
+			body:        `{"access_token":"a","refresh_token":"r","token_type":"Bearer"}`,
+			want:        `{"access_token":"REDACTED","refresh_token":"REDACTED","token_type":"Bearer"}`,
+		},
+		{
+			name:        "form",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "client_id=cli&grant_type=refresh_token&refresh_token=r",
+			want:        "client_id=cli&grant_type=refresh_token&refresh_token=REDACTED",
+		},
+		{
+			name:        "no secrets",
+			contentType: "application/json",
+			body:        `{"name":"my-kafka"}`,
+			want:        `{"name":"my-kafka"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(RedactBody(tt.contentType, []byte(tt.body))); got != tt.want {
+				t.Errorf("RedactBody() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	header := http.Header{
+		"Authorization": []string{"Bearer eyJhbGciOi"},
+		"Cookie":        []string{"session=abc"},
+		"Accept":        []string{"application/json"},
+	}
+
+	got := RedactHeaders(header)
+
+	if got.Get("Authorization") != "Bearer REDACTED" {
+		t.Errorf("Authorization = %v, want Bearer REDACTED", got.Get("Authorization"))
+	}
+	if got.Get("Cookie") != Redacted {
+		t.Errorf("Cookie = %v, want %v", got.Get("Cookie"), Redacted)
+	}
+	if got.Get("Accept") != "application/json" {
+		t.Errorf("Accept = %v, want application/json", got.Get("Accept"))
+	}
+	if header.Get("Authorization") != "Bearer eyJhbGciOi" {
+		t.Error("the original headers were modified")
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	u, _ := url.Parse("https://sso.redhat.com/auth?client_id=cli&token=abc")
+	if got := RedactURL(u); got != "https://sso.redhat.com/auth?client_id=cli&token=REDACTED" {
+		t.Errorf("RedactURL() = %v", got)
+	}
+}
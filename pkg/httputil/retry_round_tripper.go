@@ -0,0 +1,176 @@
+package httputil
+
+import (
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/redhat-developer/app-services-cli/pkg/logging"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a request is retried when no other value is configured
+	DefaultMaxRetries = 3
+	// DefaultMinBackoff is the delay before the first retry
+	DefaultMinBackoff = 500 * time.Millisecond
+	// DefaultMaxBackoff is the longest delay between two retries
+	DefaultMaxBackoff = 10 * time.Second
+	// DefaultMaxRetryAfter is the longest "Retry-After" delay which is waited for.
+	// Responses asking to wait longer are returned as they are
+	DefaultMaxRetryAfter = time.Minute
+)
+
+// RetryRoundTripper implements http.RoundTripper. When set as Transport of http.Client, it retries requests
+// which failed because of a transient error, waiting between attempts with a jittered exponential backoff.
+//
+// Requests with an idempotent method are retried on network errors and on
+// 502 Bad Gateway, 503 Service Unavailable and 504 Gateway Timeout responses.
+// Every request is retried on 429 Too Many Requests responses, as the server did not process it.
+// The "Retry-After" header of the responses is honored.
+type RetryRoundTripper struct {
+	Proxied http.RoundTripper
+	Logger  logging.Logger
+	// MaxRetries is the number of times a request is retried. Zero disables retries
+	MaxRetries int
+	// MinBackoff and MaxBackoff bound the delay between attempts, DefaultMinBackoff and DefaultMaxBackoff are used when unset
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// MaxRetryAfter is the longest "Retry-After" delay which is waited for, DefaultMaxRetryAfter is used when unset
+	MaxRetryAfter time.Duration
+}
+
+// RoundTrip executes the request, retrying it when it fails because of a transient error
+func (c RetryRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	// the body can only be sent again when it can be recreated
+	replayable := r.Body == nil || r.Body == http.NoBody || r.GetBody != nil
+
+	for attempt := 0; ; attempt++ {
+		req := r
+		if attempt > 0 && r.GetBody != nil {
+			body, err := r.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = r.Clone(r.Context())
+			req.Body = body
+		}
+
+		resp, err := c.Proxied.RoundTrip(req)
+
+		if attempt >= c.MaxRetries || !replayable || !c.shouldRetry(r, resp, err) {
+			return resp, err
+		}
+
+		delay := c.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				if retryAfter > c.maxRetryAfter() {
+					return resp, nil
+				}
+				delay = retryAfter
+			}
+		}
+
+		var reason string
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = resp.Status
+			// the response is discarded, so the connection can be reused
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		c.Logger.Debugf("%v %v failed (%v), retrying in %v (retry %v of %v)", r.Method, r.URL.String(), reason, delay.Round(time.Millisecond), attempt+1, c.MaxRetries)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-r.Context().Done():
+			timer.Stop()
+			return nil, r.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (c RetryRoundTripper) shouldRetry(r *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		// the request was canceled or has timed out
+		if r.Context().Err() != nil {
+			return false
+		}
+		return isIdempotent(r)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(r)
+	default:
+		return false
+	}
+}
+
+// backoff returns the exponential backoff of the attempt, of which up to a half is randomized
+// so that clients which failed at the same time do not retry at the same time
+func (c RetryRoundTripper) backoff(attempt int) time.Duration {
+	minBackoff, maxBackoff := c.MinBackoff, c.MaxBackoff
+	if minBackoff <= 0 {
+		minBackoff = DefaultMinBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultMaxBackoff
+	}
+
+	backoff := maxBackoff
+	if attempt < 32 {
+		if exp := minBackoff << uint(attempt); exp > 0 && exp < maxBackoff {
+			backoff = exp
+		}
+	}
+
+	// #nosec G404
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+func (c RetryRoundTripper) maxRetryAfter() time.Duration {
+	if c.MaxRetryAfter <= 0 {
+		return DefaultMaxRetryAfter
+	}
+	return c.MaxRetryAfter
+}
+
+// isIdempotent checks if sending the request several times has the same effect as sending it once
+func isIdempotent(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return r.Header.Get("Idempotency-Key") != ""
+	}
+}
+
+// parseRetryAfter parses the value of a "Retry-After" header, which is either a number of seconds or a date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
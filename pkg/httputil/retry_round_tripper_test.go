@@ -0,0 +1,221 @@
+package httputil
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redhat-developer/app-services-cli/pkg/logging"
+)
+
+func newTestClient(t *testing.T, maxRetries int) *http.Client {
+	logger, err := logging.NewStdLoggerBuilder().Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &http.Client{
+		Transport: RetryRoundTripper{
+			Proxied:    http.DefaultTransport,
+			Logger:     logger,
+			MaxRetries: maxRetries,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: 5 * time.Millisecond,
+		},
+	}
+}
+
+// newFailingServer returns a server which responds with the status codes in order, then with 200 OK
+func newFailingServer(statusCodes ...int) (*httptest.Server, *int, *[]string) {
+	requests := 0
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		requests++
+		if requests <= len(statusCodes) {
+			w.Header().Set("Retry-After", r.Header.Get("X-Retry-After"))
+			w.WriteHeader(statusCodes[requests-1])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	return server, &requests, &bodies
+}
+
+func TestRetryRoundTripper(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		body         string
+		header       http.Header
+		maxRetries   int
+		statusCodes  []int
+		wantStatus   int
+		wantRequests int
+	}{
+		{
+			name:         "retries GET on 503",
+			method:       http.MethodGet,
+			maxRetries:   3,
+			statusCodes:  []int{http.StatusServiceUnavailable, http.StatusBadGateway},
+			wantStatus:   http.StatusOK,
+			wantRequests: 3,
+		},
+		{
+			name:         "gives up after the max retries",
+			method:       http.MethodGet,
+			maxRetries:   2,
+			statusCodes:  []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			wantStatus:   http.StatusServiceUnavailable,
+			wantRequests: 3,
+		},
+		{
+			name:         "retries are disabled",
+			method:       http.MethodGet,
+			maxRetries:   0,
+			statusCodes:  []int{http.StatusServiceUnavailable},
+			wantStatus:   http.StatusServiceUnavailable,
+			wantRequests: 1,
+		},
+		{
+			name:         "does not retry POST on 503",
+			method:       http.MethodPost,
+			body:         `{"name":"my-kafka"}`,
+			maxRetries:   3,
+			statusCodes:  []int{http.StatusServiceUnavailable},
+			wantStatus:   http.StatusServiceUnavailable,
+			wantRequests: 1,
+		},
+		{
+			name:         "retries POST on 429",
+			method:       http.MethodPost,
+			body:         `{"name":"my-kafka"}`,
+			maxRetries:   3,
+			statusCodes:  []int{http.StatusTooManyRequests},
+			wantStatus:   http.StatusOK,
+			wantRequests: 2,
+		},
+		{
+			name:         "retries POST with an idempotency key",
+			method:       http.MethodPost,
+			body:         `{"name":"my-kafka"}`,
+			header:       http.Header{"Idempotency-Key": []string{"123"}},
+			maxRetries:   3,
+			statusCodes:  []int{http.StatusBadGateway},
+			wantStatus:   http.StatusOK,
+			wantRequests: 2,
+		},
+		{
+			name:         "does not retry client errors",
+			method:       http.MethodGet,
+			maxRetries:   3,
+			statusCodes:  []int{http.StatusNotFound},
+			wantStatus:   http.StatusNotFound,
+			wantRequests: 1,
+		},
+		{
+			name:         "honors Retry-After",
+			method:       http.MethodGet,
+			header:       http.Header{"X-Retry-After": []string{"0"}},
+			maxRetries:   3,
+			statusCodes:  []int{http.StatusTooManyRequests},
+			wantStatus:   http.StatusOK,
+			wantRequests: 2,
+		},
+		{
+			name:         "does not wait for a long Retry-After",
+			method:       http.MethodGet,
+			header:       http.Header{"X-Retry-After": []string{"3600"}},
+			maxRetries:   3,
+			statusCodes:  []int{http.StatusTooManyRequests},
+			wantStatus:   http.StatusTooManyRequests,
+			wantRequests: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests, bodies := newFailingServer(tt.statusCodes...)
+			defer server.Close()
+
+			req, err := http.NewRequest(tt.method, server.URL, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for name, values := range tt.header {
+				req.Header[name] = values
+			}
+
+			resp, err := newTestClient(t, tt.maxRetries).Do(req)
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("StatusCode = %v, want %v", resp.StatusCode, tt.wantStatus)
+			}
+			if *requests != tt.wantRequests {
+				t.Errorf("requests = %v, want %v", *requests, tt.wantRequests)
+			}
+			for _, body := range *bodies {
+				if body != tt.body {
+					t.Errorf("body = %q, want %q", body, tt.body)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryRoundTripper_StopsWhenCanceled(t *testing.T) {
+	server, requests, _ := newFailingServer(http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	client := newTestClient(t, 3)
+	transport := client.Transport.(RetryRoundTripper)
+	transport.MinBackoff = time.Hour
+	transport.MaxBackoff = time.Hour
+	client.Transport = transport
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Do(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Do() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if *requests != 1 {
+		t.Errorf("requests = %v, want 1", *requests)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{value: "", wantOK: false},
+		{value: "120", want: 2 * time.Minute, wantOK: true},
+		{value: "-1", wantOK: false},
+		{value: "soon", wantOK: false},
+		{value: "Wed, 21 Oct 2015 07:28:00 GMT", want: 0, wantOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseRetryAfter() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
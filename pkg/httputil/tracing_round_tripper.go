@@ -0,0 +1,61 @@
+package httputil
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/redhat-developer/app-services-cli/pkg/logging"
+)
+
+// TracingRoundTripper implements http.RoundTripper. When set as Transport of http.Client, it traces every
+// request and response with its timing, to the logs and/or to a HAR file.
+// Credentials and secrets are redacted from the traces
+type TracingRoundTripper struct {
+	Proxied http.RoundTripper
+	// Logger receives the traces when set
+	Logger logging.Logger
+	// HAR records the traces when set
+	HAR *HARRecorder
+}
+
+// RoundTrip executes the request and traces it along with its response
+func (c TracingRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	reqBody := requestBody(r)
+	if c.Logger != nil {
+		c.Logger.Info("--> " + dumpRequest(r, reqBody))
+	}
+
+	start := time.Now()
+	resp, err := c.Proxied.RoundTrip(r)
+	wait := time.Since(start)
+	if err != nil {
+		if c.Logger != nil {
+			c.Logger.Infof("<-- %v %v failed after %v: %v", r.Method, RedactURL(r.URL), wait.Round(time.Millisecond), err)
+		}
+		if c.HAR != nil {
+			if harErr := c.HAR.RecordFailure(start, r, reqBody, err, wait); harErr != nil && c.Logger != nil {
+				c.Logger.Info("unable to write HAR file:", harErr)
+			}
+		}
+		return nil, err
+	}
+
+	// the body is read here, so the timing includes its download
+	respBody, err := responseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	receive := time.Since(start) - wait
+
+	if c.Logger != nil {
+		c.Logger.Infof("<-- %v %v (%v)\n%v", r.Method, RedactURL(r.URL), (wait + receive).Round(time.Millisecond), dumpResponse(resp, respBody))
+	}
+
+	if c.HAR != nil {
+		if err = c.HAR.Record(start, r, reqBody, resp, respBody, wait, receive); err != nil && c.Logger != nil {
+			c.Logger.Info("unable to write HAR file:", err)
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,180 @@
+package httputil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/redhat-developer/app-services-cli/pkg/logging"
+)
+
+const serviceAccountResponse = `{"client_id":"srvc-acct-1","client_secret":"s3cr3t"}`
+
+func newServiceAccountServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("fail") != "" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		_, _ = w.Write([]byte(serviceAccountResponse))
+	}))
+}
+
+func newCapturingLogger(t *testing.T, out *bytes.Buffer) logging.Logger {
+	logger, err := logging.NewStdLoggerBuilder().Streams(out, out).Debug(true).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return logger
+}
+
+func postServiceAccount(t *testing.T, transport http.RoundTripper, url string) {
+	client := &http.Client{Transport: transport}
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(`{"name":"my-sa"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer secret-access-token")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	// the body can still be read by the caller
+	body, _ := ioutil.ReadAll(resp.Body)
+	if string(body) != serviceAccountResponse {
+		t.Errorf("body = %v, want %v", string(body), serviceAccountResponse)
+	}
+}
+
+func assertRedacted(t *testing.T, output string) {
+	for _, secret := range []string{"secret-access-token", "s3cr3t"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("output contains the secret %q:\n%v", secret, output)
+		}
+	}
+}
+
+func TestTracingRoundTripper_Logs(t *testing.T) {
+	server := newServiceAccountServer()
+	defer server.Close()
+
+	var out bytes.Buffer
+	postServiceAccount(t, TracingRoundTripper{Proxied: http.DefaultTransport, Logger: newCapturingLogger(t, &out)}, server.URL)
+
+	for _, want := range []string{"--> POST", `{"name":"my-sa"}`, "<-- POST", "200 OK", "Bearer REDACTED", `"client_secret":"REDACTED"`} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output does not contain %q:\n%v", want, out.String())
+		}
+	}
+	assertRedacted(t, out.String())
+}
+
+func TestTracingRoundTripper_HAR(t *testing.T) {
+	server := newServiceAccountServer()
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "rhoas-har")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	har := &HARRecorder{Filename: filepath.Join(dir, "rhoas.har"), CreatorVersion: "dev"}
+	transport := TracingRoundTripper{Proxied: http.DefaultTransport, HAR: har}
+	postServiceAccount(t, transport, server.URL)
+	postServiceAccount(t, transport, server.URL)
+
+	data, err := ioutil.ReadFile(har.Filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertRedacted(t, string(data))
+
+	var archive harLog
+	if err = json.Unmarshal(data, &archive); err != nil {
+		t.Fatalf("invalid HAR file: %v", err)
+	}
+	if archive.Log.Version != "1.2" || len(archive.Log.Entries) != 2 {
+		t.Fatalf("unexpected HAR log: %+v", archive.Log)
+	}
+	entry := archive.Log.Entries[0]
+	if entry.Request.Method != http.MethodPost || entry.Request.PostData == nil || entry.Request.PostData.Text != `{"name":"my-sa"}` {
+		t.Errorf("unexpected request: %+v", entry.Request)
+	}
+	if entry.Response.Status != http.StatusOK || entry.Response.Content.Text != `{"client_id":"srvc-acct-1","client_secret":"REDACTED"}` {
+		t.Errorf("unexpected response: %+v", entry.Response)
+	}
+}
+
+func TestLoggingRoundTripper_Redacts(t *testing.T) {
+	server := newServiceAccountServer()
+	defer server.Close()
+
+	var out bytes.Buffer
+	postServiceAccount(t, LoggingRoundTripper{Proxied: http.DefaultTransport, Logger: newCapturingLogger(t, &out)}, server.URL+"?fail=true")
+
+	if !strings.Contains(out.String(), "400 Bad Request") {
+		t.Errorf("the error response was not logged:\n%v", out.String())
+	}
+	assertRedacted(t, out.String())
+}
+
+type failingRoundTripper struct{}
+
+func (failingRoundTripper) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestTracingRoundTripper_HARAlongWithLogs(t *testing.T) {
+	server := newServiceAccountServer()
+	defer server.Close()
+
+	har := &HARRecorder{Filename: filepath.Join(t.TempDir(), "rhoas.har"), CreatorVersion: "dev"}
+
+	// the error responses are still logged when the HAR file is recorded
+	var out bytes.Buffer
+	loggingTransport := LoggingRoundTripper{Proxied: http.DefaultTransport, Logger: newCapturingLogger(t, &out)}
+	postServiceAccount(t, TracingRoundTripper{Proxied: loggingTransport, HAR: har}, server.URL+"?fail=true")
+	if !strings.Contains(out.String(), "400 Bad Request") {
+		t.Errorf("the error response was not logged:\n%v", out.String())
+	}
+
+	// the requests which failed without a response are recorded with the status 0
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = (TracingRoundTripper{Proxied: failingRoundTripper{}, HAR: har}).RoundTrip(req); err == nil {
+		t.Fatal("expected the error of the transport")
+	}
+
+	data, err := ioutil.ReadFile(har.Filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var archive harLog
+	if err = json.Unmarshal(data, &archive); err != nil {
+		t.Fatalf("invalid HAR file: %v", err)
+	}
+	if len(archive.Log.Entries) != 2 {
+		t.Fatalf("unexpected HAR log: %+v", archive.Log)
+	}
+	if got := archive.Log.Entries[0].Response.Status; got != http.StatusBadRequest {
+		t.Errorf("status of the error response = %v, want %v", got, http.StatusBadRequest)
+	}
+	failed := archive.Log.Entries[1]
+	if failed.Request.Method != http.MethodGet || failed.Response.Status != 0 || failed.Response.Error != "connection refused" {
+		t.Errorf("unexpected entry of the failed request: %+v", failed)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/redhat-developer/app-services-cli/pkg/api/kas"
 	"github.com/redhat-developer/app-services-cli/pkg/kafka/kafkaerr"
@@ -38,3 +39,30 @@ func GetKafkaByName(ctx context.Context, api kafkamgmtclient.DefaultApi, name st
 
 	return &kafkaReq, httpResponse, err
 }
+
+// ListKafkas returns all the Kafka instances, fetching every page of the list
+func ListKafkas(ctx context.Context, api kafkamgmtclient.DefaultApi) ([]kafkamgmtclient.KafkaRequest, error) {
+	return SearchKafkas(ctx, api, "")
+}
+
+// SearchKafkas returns all the Kafka instances which match the search query, fetching every page of the list.
+// All the Kafka instances are returned when the query is empty
+func SearchKafkas(ctx context.Context, api kafkamgmtclient.DefaultApi, query string) ([]kafkamgmtclient.KafkaRequest, error) {
+	const pageSize = 100
+
+	kafkas := []kafkamgmtclient.KafkaRequest{}
+	for page := 1; ; page++ {
+		req := api.GetKafkas(ctx).Page(strconv.Itoa(page)).Size(strconv.Itoa(pageSize))
+		if query != "" {
+			req = req.Search(query)
+		}
+		kafkaList, _, err := req.Execute()
+		if err != nil {
+			return nil, err
+		}
+		kafkas = append(kafkas, kafkaList.GetItems()...)
+		if len(kafkaList.GetItems()) < pageSize || len(kafkas) >= int(kafkaList.GetTotal()) {
+			return kafkas, nil
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package consumergroup
+
+import (
+	"context"
+
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+)
+
+// ListConsumerGroups returns all the consumer groups of the Kafka instance, fetching every page of the list
+func ListConsumerGroups(ctx context.Context, api *kafkainstanceclient.APIClient) ([]kafkainstanceclient.ConsumerGroup, error) {
+	const pageSize = 100
+
+	groups := []kafkainstanceclient.ConsumerGroup{}
+	for page := int32(1); ; page++ {
+		groupList, _, err := api.GroupsApi.GetConsumerGroups(ctx).Page(page).Size(pageSize).Execute()
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, groupList.GetItems()...)
+		if len(groupList.GetItems()) < pageSize || len(groups) >= int(groupList.GetTotal()) {
+			return groups, nil
+		}
+	}
+}
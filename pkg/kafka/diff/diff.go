@@ -0,0 +1,248 @@
+// Package diff compares the topics and consumer groups of two Kafka instances
+package diff
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-developer/app-services-cli/pkg/kafka/consumergroup"
+	topicutil "github.com/redhat-developer/app-services-cli/pkg/kafka/topic"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+)
+
+// Resources which are compared
+const (
+	ResourceTopic         = "topic"
+	ResourceConsumerGroup = "consumer_group"
+)
+
+// Fields of the topics which are compared
+const (
+	FieldPartitions = "partitions"
+	FieldConfig     = "config"
+)
+
+// Layout is the layout of a Kafka instance which is compared
+type Layout struct {
+	// Topics are the topics by name
+	Topics map[string]Topic
+	// ConsumerGroups are the IDs of the consumer groups
+	ConsumerGroups map[string]bool
+}
+
+// Topic is the layout of a topic
+type Topic struct {
+	Partitions int
+	Config     map[string]string
+}
+
+// Difference is a difference between the layouts of two Kafka instances.
+// A and B are the values in each instance, which are nil when the resource or the config entry
+// does not exist in the instance.
+// A topic or a consumer group which only exists in one instance is a difference without a field,
+// whose value is its name in the instance it exists in
+type Difference struct {
+	Resource string  `json:"resource" yaml:"resource"`
+	Name     string  `json:"name" yaml:"name"`
+	Field    string  `json:"field,omitempty" yaml:"field,omitempty"`
+	Key      string  `json:"key,omitempty" yaml:"key,omitempty"`
+	A        *string `json:"a" yaml:"a"`
+	B        *string `json:"b" yaml:"b"`
+}
+
+// Fetch returns the layout of the Kafka instance from its admin API
+func Fetch(ctx context.Context, api *kafkainstanceclient.APIClient) (*Layout, error) {
+	topics, err := topicutil.ListTopics(ctx, api)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := consumergroup.ListConsumerGroups(ctx, api)
+	if err != nil {
+		return nil, err
+	}
+
+	layout := &Layout{Topics: map[string]Topic{}, ConsumerGroups: map[string]bool{}}
+	for _, t := range topics {
+		topic := Topic{Partitions: len(t.GetPartitions()), Config: map[string]string{}}
+		for _, entry := range t.GetConfig() {
+			topic.Config[entry.GetKey()] = entry.GetValue()
+		}
+		layout.Topics[t.GetName()] = topic
+	}
+	for _, g := range groups {
+		layout.ConsumerGroups[g.GetGroupId()] = true
+	}
+	return layout, nil
+}
+
+// Compare returns the differences between the layouts, sorted by resource and name.
+// The topics which only exist in one instance are not compared further
+func Compare(a *Layout, b *Layout) []Difference {
+	differences := []Difference{}
+
+	for _, name := range union(topicNames(a), topicNames(b)) {
+		topicA, inA := a.Topics[name]
+		topicB, inB := b.Topics[name]
+		if !inA || !inB {
+			differences = append(differences, Difference{
+				Resource: ResourceTopic,
+				Name:     name,
+				A:        presence(name, inA),
+				B:        presence(name, inB),
+			})
+			continue
+		}
+
+		if topicA.Partitions != topicB.Partitions {
+			differences = append(differences, Difference{
+				Resource: ResourceTopic,
+				Name:     name,
+				Field:    FieldPartitions,
+				A:        stringPtr(strconv.Itoa(topicA.Partitions)),
+				B:        stringPtr(strconv.Itoa(topicB.Partitions)),
+			})
+		}
+
+		for _, key := range union(configKeys(topicA.Config), configKeys(topicB.Config)) {
+			valueA, inA := topicA.Config[key]
+			valueB, inB := topicB.Config[key]
+			if inA == inB && valueA == valueB {
+				continue
+			}
+			d := Difference{Resource: ResourceTopic, Name: name, Field: FieldConfig, Key: key}
+			if inA {
+				d.A = stringPtr(valueA)
+			}
+			if inB {
+				d.B = stringPtr(valueB)
+			}
+			differences = append(differences, d)
+		}
+	}
+
+	for _, id := range union(groupIDs(a), groupIDs(b)) {
+		inA, inB := a.ConsumerGroups[id], b.ConsumerGroups[id]
+		if inA != inB {
+			differences = append(differences, Difference{
+				Resource: ResourceConsumerGroup,
+				Name:     id,
+				A:        presence(id, inA),
+				B:        presence(id, inB),
+			})
+		}
+	}
+
+	return differences
+}
+
+// WriteReport writes the differences in the style of a unified diff, with the lines of instance A
+// prefixed by "-" and the lines of instance B prefixed by "+".
+// The topics which only exist in one instance come first, followed by a hunk for the fields of each topic
+// and by the consumer groups which only exist in one instance
+func WriteReport(w io.Writer, labelA string, labelB string, differences []Difference) error {
+	lines := []string{"--- " + labelA, "+++ " + labelB}
+
+	lines = append(lines, existenceHunk("@@ topics @@", ResourceTopic, differences)...)
+
+	header := ""
+	for _, d := range differences {
+		if d.Resource != ResourceTopic || d.Field == "" {
+			continue
+		}
+		if h := "@@ topic " + d.Name + " @@"; h != header {
+			header = h
+			lines = append(lines, header)
+		}
+
+		prefix := FieldPartitions + ": "
+		if d.Field == FieldConfig {
+			prefix = d.Key + "="
+		}
+		if d.A != nil {
+			lines = append(lines, "-"+prefix+*d.A)
+		}
+		if d.B != nil {
+			lines = append(lines, "+"+prefix+*d.B)
+		}
+	}
+
+	lines = append(lines, existenceHunk("@@ consumer groups @@", ResourceConsumerGroup, differences)...)
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+// existenceHunk returns the lines of the resources which only exist in one instance, if any
+func existenceHunk(header string, resource string, differences []Difference) []string {
+	lines := []string{}
+	for _, d := range differences {
+		if d.Resource != resource || d.Field != "" {
+			continue
+		}
+		if d.A != nil {
+			lines = append(lines, "-"+d.Name)
+		}
+		if d.B != nil {
+			lines = append(lines, "+"+d.Name)
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return append([]string{header}, lines...)
+}
+
+// presence returns the name of a resource which exists in an instance, or nil
+func presence(name string, exists bool) *string {
+	if !exists {
+		return nil
+	}
+	return &name
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func topicNames(l *Layout) []string {
+	names := make([]string, 0, len(l.Topics))
+	for name := range l.Topics {
+		names = append(names, name)
+	}
+	return names
+}
+
+func configKeys(config map[string]string) []string {
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func groupIDs(l *Layout) []string {
+	ids := make([]string, 0, len(l.ConsumerGroups))
+	for id := range l.ConsumerGroups {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// union returns the sorted strings which are in either list, once
+func union(a []string, b []string) []string {
+	seen := map[string]bool{}
+	all := []string{}
+	for _, list := range [][]string{a, b} {
+		for _, s := range list {
+			if !seen[s] {
+				seen[s] = true
+				all = append(all, s)
+			}
+		}
+	}
+	sort.Strings(all)
+	return all
+}
@@ -0,0 +1,94 @@
+package diff
+
+import (
+	"bytes"
+	"testing"
+)
+
+func newLayouts() (*Layout, *Layout) {
+	a := &Layout{
+		Topics: map[string]Topic{
+			"orders":   {Partitions: 3, Config: map[string]string{"retention.ms": "604800000", "cleanup.policy": "delete"}},
+			"payments": {Partitions: 1, Config: map[string]string{"cleanup.policy": "compact"}},
+			"staging":  {Partitions: 1, Config: map[string]string{}},
+		},
+		ConsumerGroups: map[string]bool{"billing": true, "audit": true},
+	}
+	b := &Layout{
+		Topics: map[string]Topic{
+			"orders":    {Partitions: 6, Config: map[string]string{"retention.ms": "86400000", "max.message.bytes": "1048588"}},
+			"payments":  {Partitions: 1, Config: map[string]string{"cleanup.policy": "compact"}},
+			"analytics": {Partitions: 1, Config: map[string]string{}},
+		},
+		ConsumerGroups: map[string]bool{"billing": true, "reports": true},
+	}
+	return a, b
+}
+
+func TestCompare(t *testing.T) {
+	a, b := newLayouts()
+
+	got := Compare(a, b)
+
+	type diff struct{ resource, name, field, key, a, b string }
+	value := func(s *string) string {
+		if s == nil {
+			return "<nil>"
+		}
+		return *s
+	}
+	want := []diff{
+		{ResourceTopic, "analytics", "", "", "<nil>", "analytics"},
+		{ResourceTopic, "orders", FieldPartitions, "", "3", "6"},
+		{ResourceTopic, "orders", FieldConfig, "cleanup.policy", "delete", "<nil>"},
+		{ResourceTopic, "orders", FieldConfig, "max.message.bytes", "<nil>", "1048588"},
+		{ResourceTopic, "orders", FieldConfig, "retention.ms", "604800000", "86400000"},
+		{ResourceTopic, "staging", "", "", "staging", "<nil>"},
+		{ResourceConsumerGroup, "audit", "", "", "audit", "<nil>"},
+		{ResourceConsumerGroup, "reports", "", "", "<nil>", "reports"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Compare() returned %v differences, want %v: %+v", len(got), len(want), got)
+	}
+	for i, d := range got {
+		if g := (diff{d.Resource, d.Name, d.Field, d.Key, value(d.A), value(d.B)}); g != want[i] {
+			t.Errorf("Compare()[%v] = %+v, want %+v", i, g, want[i])
+		}
+	}
+}
+
+func TestCompareEqual(t *testing.T) {
+	a, _ := newLayouts()
+	if got := Compare(a, a); len(got) != 0 {
+		t.Errorf("expected no differences, got %+v", got)
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	a, b := newLayouts()
+
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, "staging (1)", "production (2)", Compare(a, b)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `--- staging (1)
++++ production (2)
+@@ topics @@
++analytics
+-staging
+@@ topic orders @@
+-partitions: 3
++partitions: 6
+-cleanup.policy=delete
++max.message.bytes=1048588
+-retention.ms=604800000
++retention.ms=86400000
+@@ consumer groups @@
+-audit
++reports
+`
+	if buf.String() != want {
+		t.Errorf("WriteReport() =\n%v\nwant\n%v", buf.String(), want)
+	}
+}
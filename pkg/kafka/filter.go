@@ -0,0 +1,124 @@
+package kafka
+
+import (
+	"strings"
+
+	"github.com/redhat-developer/app-services-cli/pkg/kafka/kafkaerr"
+)
+
+// SearchFields are the fields of Kafka instances the search of the management API supports
+var SearchFields = []string{"name", "owner", "cloud_provider", "region", "status"}
+
+// Statuses are the statuses of Kafka instances
+var Statuses = []string{"accepted", "preparing", "provisioning", "ready", "failed", "deprovision", "deleting"}
+
+// Condition is a condition on a field of Kafka instances
+type Condition struct {
+	Field string
+	// Negate is true when the field must not match the value
+	Negate bool
+	// Value is the value of the field, in which "*" matches any characters
+	Value string
+}
+
+// ParseFilter parses a comma-separated list of conditions, such as "status=ready,region=us-east-1".
+// A condition is either "field=value" or "field!=value", and "*" in a value matches any characters.
+// The fields are validated against the fields the search of the management API supports
+func ParseFilter(filter string) ([]Condition, error) {
+	conditions := []Condition{}
+	if strings.TrimSpace(filter) == "" {
+		return conditions, nil
+	}
+
+	for _, s := range strings.Split(filter, ",") {
+		i := strings.Index(s, "=")
+		if i < 0 {
+			return nil, kafkaerr.InvalidFilterError(s)
+		}
+
+		var c Condition
+		field := s[:i]
+		if strings.HasSuffix(field, "!") {
+			c.Negate = true
+			field = strings.TrimSuffix(field, "!")
+		}
+		c.Field, c.Value = strings.ToLower(strings.TrimSpace(field)), strings.TrimSpace(s[i+1:])
+		if err := c.Validate(); err != nil {
+			return nil, err
+		}
+
+		conditions = append(conditions, c)
+	}
+
+	return conditions, nil
+}
+
+// Validate checks that the field is supported by the search of the management API, and that the value can be searched for
+func (c Condition) Validate() error {
+	if !contains(SearchFields, c.Field) {
+		return kafkaerr.UnsupportedFilterFieldError(c.Field, SearchFields)
+	}
+	if c.Value == "" {
+		return kafkaerr.InvalidFilterError(c.String())
+	}
+
+	wildcard := strings.Contains(c.Value, "*")
+	// the search has no negated form of the "like" operator
+	if c.Negate && wildcard {
+		return kafkaerr.NegatedWildcardFilterError(c.String())
+	}
+	if c.Field == "status" && !wildcard && !contains(Statuses, c.Value) {
+		return kafkaerr.InvalidStatusFilterError(c.Value, Statuses)
+	}
+
+	return nil
+}
+
+// String returns the condition in the format of ParseFilter
+func (c Condition) String() string {
+	if c.Negate {
+		return c.Field + "!=" + c.Value
+	}
+	return c.Field + "=" + c.Value
+}
+
+// BuildSearch translates the conditions into a search query of the management API, which matches
+// the Kafka instances satisfying all of them.
+// The values are quoted, so that they cannot change the structure of the query
+func BuildSearch(conditions []Condition) string {
+	clauses := make([]string, len(conditions))
+	for i, c := range conditions {
+		var operator, value string
+		switch {
+		case strings.Contains(c.Value, "*"):
+			operator, value = "like", likePattern(c.Value)
+		case c.Negate:
+			operator, value = "<>", c.Value
+		default:
+			operator, value = "=", c.Value
+		}
+		clauses[i] = c.Field + " " + operator + " " + quote(value)
+	}
+	return strings.Join(clauses, " and ")
+}
+
+// likePattern turns a value with "*" wildcards into a pattern of the "like" operator,
+// escaping the characters which are wildcards of this operator
+func likePattern(value string) string {
+	value = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+	return strings.ReplaceAll(value, "*", "%")
+}
+
+// quote quotes a value of the search query
+func quote(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(value) + "'"
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package kafka
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  string
+		want    []Condition
+		wantErr bool
+	}{
+		{
+			name:   "empty filter",
+			filter: "",
+			want:   []Condition{},
+		},
+		{
+			name:   "several conditions",
+			filter: "status=ready, Region = us-east-1,owner!=jdoe",
+			want: []Condition{
+				{Field: "status", Value: "ready"},
+				{Field: "region", Value: "us-east-1"},
+				{Field: "owner", Negate: true, Value: "jdoe"},
+			},
+		},
+		{
+			name:   "wildcard",
+			filter: "name=dev-*",
+			want:   []Condition{{Field: "name", Value: "dev-*"}},
+		},
+		{
+			name:   "value containing an equals sign",
+			filter: "name=a=b",
+			want:   []Condition{{Field: "name", Value: "a=b"}},
+		},
+		{
+			name:    "missing operator",
+			filter:  "status",
+			wantErr: true,
+		},
+		{
+			name:    "empty value",
+			filter:  "status=",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported field",
+			filter:  "created_at=2021-01-01",
+			wantErr: true,
+		},
+		{
+			name:    "unknown status",
+			filter:  "status=running",
+			wantErr: true,
+		},
+		{
+			name:    "negated wildcard",
+			filter:  "name!=dev-*",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFilter(tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildSearch(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []Condition
+		want       string
+	}{
+		{
+			name: "conditions are combined with and",
+			conditions: []Condition{
+				{Field: "status", Value: "ready"},
+				{Field: "region", Value: "us-east-1"},
+				{Field: "owner", Negate: true, Value: "jdoe"},
+			},
+			want: "status = 'ready' and region = 'us-east-1' and owner <> 'jdoe'",
+		},
+		{
+			name:       "wildcards use the like operator",
+			conditions: []Condition{{Field: "name", Value: "dev-*"}},
+			want:       "name like 'dev-%'",
+		},
+		{
+			name:       "wildcards of the like operator are escaped",
+			conditions: []Condition{{Field: "owner", Value: "j_doe%*"}},
+			want:       `owner like 'j\\_doe\\%%'`,
+		},
+		{
+			name:       "or in a value is not a keyword",
+			conditions: []Condition{{Field: "name", Value: "x or owner like %"}},
+			want:       "name = 'x or owner like %'",
+		},
+		{
+			name:       "and in a value is not a keyword",
+			conditions: []Condition{{Field: "owner", Value: "me and status <> ready"}},
+			want:       "owner = 'me and status <> ready'",
+		},
+		{
+			name:       "quotes cannot close the value",
+			conditions: []Condition{{Field: "name", Value: "x' or name like '%"}},
+			want:       `name = 'x\' or name like \'%'`,
+		},
+		{
+			name:       "backslashes cannot escape the closing quote",
+			conditions: []Condition{{Field: "name", Value: `x\`}},
+			want:       `name = 'x\\'`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BuildSearch(tt.conditions); got != tt.want {
+				t.Errorf("BuildSearch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
+	"github.com/redhat-developer/app-services-cli/pkg/common/clierr"
 	"github.com/redhat-developer/app-services-cli/pkg/common/commonerr"
 	"github.com/redhat-developer/app-services-cli/pkg/connection"
 	"github.com/redhat-developer/app-services-cli/pkg/kafka/kafkaerr"
@@ -107,7 +108,7 @@ func (v *Validator) ValidateNameIsAvailable(val interface{}) error {
 	_, httpRes, _ := GetKafkaByName(context.Background(), api.Kafka(), name)
 
 	if httpRes != nil && httpRes.StatusCode == 200 {
-		return errors.New(v.Localizer.MustLocalize("kafka.create.error.conflictError", localize.NewEntry("Name", name)))
+		return clierr.New(clierr.CodeConflict, errors.New(v.Localizer.MustLocalize("kafka.create.error.conflictError", localize.NewEntry("Name", name))))
 	}
 
 	if httpRes != nil && httpRes.Body != nil {
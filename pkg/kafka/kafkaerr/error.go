@@ -2,6 +2,9 @@ package kafkaerr
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/redhat-developer/app-services-cli/pkg/common/clierr"
 )
 
 var (
@@ -12,33 +15,49 @@ var (
 )
 
 func NotFoundByIDError(id string) error {
-	NotFoundByIDErr = fmt.Errorf(`Kafka instance with ID "%v" not found`, id)
+	NotFoundByIDErr = clierr.New(clierr.CodeNotFound, fmt.Errorf(`Kafka instance with ID "%v" not found`, id))
 	return NotFoundByIDErr
 }
 
 func NotFoundByNameError(name string) error {
-	NotFoundByNameErr = fmt.Errorf(`Kafka instance "%v" not found`, name)
+	NotFoundByNameErr = clierr.New(clierr.CodeNotFound, fmt.Errorf(`Kafka instance "%v" not found`, name))
 	return NotFoundByNameErr
 }
 
 func InvalidSearchValueError(v string) error {
-	IllegalSearchValueError = fmt.Errorf(`
+	IllegalSearchValueError = clierr.New(clierr.CodeValidation, fmt.Errorf(`
 	illegal search value "%v", search input must satisfy the following conditions:
 
   - must be of 1 or more characters
   - must only consist of alphanumeric characters, '-', '_' and '%%'
-	`, v)
+	`, v))
 
 	return IllegalSearchValueError
 }
 
 func InvalidNameError(v string) error {
-	InvalidNameErr = fmt.Errorf(`invalid Kafka instance name "%v". Valid names must satisfy the following conditions:
+	InvalidNameErr = clierr.New(clierr.CodeValidation, fmt.Errorf(`invalid Kafka instance name "%v". Valid names must satisfy the following conditions:
 
   - must be between 1 and 32 characters
   - must only consist of lower case, alphanumeric characters and '-'
   - must start with an alphabetic character
   - must end with an alphanumeric character
-	`, v)
+	`, v))
 	return InvalidNameErr
 }
+
+func InvalidFilterError(condition string) error {
+	return clierr.New(clierr.CodeValidation, fmt.Errorf(`invalid filter condition "%v", conditions must be in the "field=value" or "field!=value" format`, condition))
+}
+
+func UnsupportedFilterFieldError(field string, fields []string) error {
+	return clierr.New(clierr.CodeValidation, fmt.Errorf(`unsupported filter field "%v" (choose from: "%v")`, field, strings.Join(fields, `", "`)))
+}
+
+func NegatedWildcardFilterError(condition string) error {
+	return clierr.New(clierr.CodeValidation, fmt.Errorf(`invalid filter condition "%v", the "*" wildcard cannot be used with "!="`, condition))
+}
+
+func InvalidStatusFilterError(status string, statuses []string) error {
+	return clierr.New(clierr.CodeValidation, fmt.Errorf(`invalid status "%v" (choose from: "%v")`, status, strings.Join(statuses, `", "`)))
+}
@@ -0,0 +1,187 @@
+// Package metrics queries the metrics of Kafka instances from the management API and summarises them
+package metrics
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+)
+
+// Names of the metrics which are summarised
+const (
+	BytesIn      = "kafka_server_brokertopicmetrics_bytes_in_total"
+	BytesOut     = "kafka_server_brokertopicmetrics_bytes_out_total"
+	MessagesIn   = "kafka_server_brokertopicmetrics_messages_in_total"
+	Partitions   = "kafka_controller_kafkacontroller_global_partition_count"
+	StorageUsed  = "kafka_broker_quota_totalstorageusedbytes"
+	StorageLimit = "kafka_broker_quota_softlimitbytes"
+	Connections  = "kafka_namespace:kafka_server_socket_server_metrics_connection_count:sum"
+)
+
+// Names are the metrics requested from the API
+var Names = []string{BytesIn, BytesOut, MessagesIn, Partitions, StorageUsed, StorageLimit, Connections}
+
+const (
+	// NameLabel is the label holding the name of the metric in the responses of the API
+	NameLabel = "__name__"
+	// TopicLabel is the label holding the topic of the metrics of topics
+	TopicLabel = "topic"
+)
+
+// Limits of the range queries of the API, which takes the range in minutes and the step in seconds
+const (
+	MinRange = time.Minute
+	MaxRange = 72 * time.Hour
+	MinStep  = time.Second
+	MaxStep  = 3 * time.Hour
+)
+
+// Sample is a value of a metric.
+// The timestamp is zero when the API has not reported it
+type Sample struct {
+	Name      string
+	Labels    map[string]string
+	Timestamp time.Time
+	Value     float64
+}
+
+// TopicMessages is the number of messages produced to a topic
+type TopicMessages struct {
+	Topic    string  `json:"topic" yaml:"topic"`
+	Messages float64 `json:"messages" yaml:"messages"`
+}
+
+// Summary is the sum of the values of the metrics reported by every broker
+type Summary struct {
+	Timestamp    *time.Time      `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+	BytesIn      float64         `json:"bytes_in" yaml:"bytes_in"`
+	BytesOut     float64         `json:"bytes_out" yaml:"bytes_out"`
+	Partitions   float64         `json:"partitions" yaml:"partitions"`
+	StorageUsed  float64         `json:"storage_used_bytes" yaml:"storage_used_bytes"`
+	StorageLimit float64         `json:"storage_limit_bytes" yaml:"storage_limit_bytes"`
+	Connections  float64         `json:"connections" yaml:"connections"`
+	Topics       []TopicMessages `json:"topics" yaml:"topics"`
+}
+
+// Query returns the current values of the metrics of the Kafka instance
+func Query(ctx context.Context, api kafkamgmtclient.DefaultApi, id string) ([]Sample, error) {
+	res, _, err := api.GetMetricsByInstantQuery(ctx, id).Filters(Names).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	samples := []Sample{}
+	for _, item := range res.GetItems() {
+		s := newSample(item.GetMetric(), item.Value)
+		if item.Timestamp != nil {
+			s.Timestamp = fromMillis(item.GetTimestamp())
+		}
+		samples = append(samples, s)
+	}
+	return samples, nil
+}
+
+// QueryRange returns the values of the metrics of the Kafka instance over the range of time until now, at every step.
+// The range is rounded down to minutes and the step to seconds
+func QueryRange(ctx context.Context, api kafkamgmtclient.DefaultApi, id string, timeRange time.Duration, step time.Duration) ([]Sample, error) {
+	res, _, err := api.GetMetricsByRangeQuery(ctx, id).
+		Duration(int64(timeRange / time.Minute)).
+		Interval(int64(step / time.Second)).
+		Filters(Names).
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	samples := []Sample{}
+	for _, item := range res.GetItems() {
+		for _, v := range item.GetValues() {
+			s := newSample(item.GetMetric(), v.Value)
+			if v.Timestamp != nil {
+				s.Timestamp = fromMillis(v.GetTimestamp())
+			}
+			samples = append(samples, s)
+		}
+	}
+	return samples, nil
+}
+
+// Total sums the samples of each metric, whatever their timestamp.
+// The topics are sorted by name
+func Total(samples []Sample) Summary {
+	summary := Summary{Topics: []TopicMessages{}}
+	messages := map[string]float64{}
+	for _, s := range samples {
+		switch s.Name {
+		case BytesIn:
+			summary.BytesIn += s.Value
+		case BytesOut:
+			summary.BytesOut += s.Value
+		case MessagesIn:
+			// the brokers also report the total of every topic, without the topic label
+			if topic := s.Labels[TopicLabel]; topic != "" {
+				messages[topic] += s.Value
+			}
+		case Partitions:
+			summary.Partitions += s.Value
+		case StorageUsed:
+			summary.StorageUsed += s.Value
+		case StorageLimit:
+			summary.StorageLimit += s.Value
+		case Connections:
+			summary.Connections += s.Value
+		}
+	}
+
+	for topic, n := range messages {
+		summary.Topics = append(summary.Topics, TopicMessages{Topic: topic, Messages: n})
+	}
+	sort.Slice(summary.Topics, func(i, j int) bool {
+		return summary.Topics[i].Topic < summary.Topics[j].Topic
+	})
+	return summary
+}
+
+// Summarize sums the samples of each metric at each timestamp, in the order of the timestamps
+func Summarize(samples []Sample) []Summary {
+	byTimestamp := map[time.Time][]Sample{}
+	timestamps := []time.Time{}
+	for _, s := range samples {
+		if _, ok := byTimestamp[s.Timestamp]; !ok {
+			timestamps = append(timestamps, s.Timestamp)
+		}
+		byTimestamp[s.Timestamp] = append(byTimestamp[s.Timestamp], s)
+	}
+	sort.Slice(timestamps, func(i, j int) bool {
+		return timestamps[i].Before(timestamps[j])
+	})
+
+	summaries := make([]Summary, len(timestamps))
+	for i, t := range timestamps {
+		summaries[i] = Total(byTimestamp[t])
+		if !t.IsZero() {
+			timestamp := t
+			summaries[i].Timestamp = &timestamp
+		}
+	}
+	return summaries
+}
+
+func newSample(metric map[string]string, value float64) Sample {
+	s := Sample{Labels: map[string]string{}, Value: value}
+	for k, v := range metric {
+		if k == NameLabel {
+			s.Name = v
+			continue
+		}
+		s.Labels[k] = v
+	}
+	return s
+}
+
+// fromMillis returns the time of a timestamp of the API, in milliseconds since the epoch
+func fromMillis(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC()
+}
@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTotal(t *testing.T) {
+	samples := []Sample{
+		{Name: BytesIn, Labels: map[string]string{"broker": "0"}, Value: 100},
+		{Name: BytesIn, Labels: map[string]string{"broker": "1"}, Value: 50},
+		{Name: BytesOut, Labels: map[string]string{"broker": "0"}, Value: 20},
+		{Name: MessagesIn, Labels: map[string]string{"broker": "0", "topic": "payments"}, Value: 3},
+		{Name: MessagesIn, Labels: map[string]string{"broker": "1", "topic": "payments"}, Value: 4},
+		{Name: MessagesIn, Labels: map[string]string{"broker": "0", "topic": "orders"}, Value: 1},
+		{Name: MessagesIn, Labels: map[string]string{"broker": "0"}, Value: 8},
+		{Name: Partitions, Value: 6},
+		{Name: StorageUsed, Labels: map[string]string{"broker": "0"}, Value: 1000},
+		{Name: StorageLimit, Labels: map[string]string{"broker": "0"}, Value: 5000},
+		{Name: Connections, Value: 2},
+		{Name: "unknown", Value: 42},
+	}
+
+	got := Total(samples)
+	if got.BytesIn != 150 || got.BytesOut != 20 || got.Partitions != 6 || got.StorageUsed != 1000 || got.StorageLimit != 5000 || got.Connections != 2 {
+		t.Errorf("unexpected summary: %+v", got)
+	}
+	want := []TopicMessages{{Topic: "orders", Messages: 1}, {Topic: "payments", Messages: 7}}
+	if len(got.Topics) != len(want) {
+		t.Fatalf("Topics = %v, want %v", got.Topics, want)
+	}
+	for i := range want {
+		if got.Topics[i] != want[i] {
+			t.Errorf("Topics[%v] = %v, want %v", i, got.Topics[i], want[i])
+		}
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	t0 := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Minute)
+	samples := []Sample{
+		{Name: BytesIn, Timestamp: t1, Value: 30},
+		{Name: BytesIn, Timestamp: t0, Value: 10},
+		{Name: BytesIn, Timestamp: t0, Value: 5},
+		{Name: Connections, Timestamp: t1, Value: 1},
+	}
+
+	got := Summarize(samples)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 summaries, got %v", got)
+	}
+	if !got[0].Timestamp.Equal(t0) || got[0].BytesIn != 15 || got[0].Connections != 0 {
+		t.Errorf("unexpected first summary: %+v", got[0])
+	}
+	if !got[1].Timestamp.Equal(t1) || got[1].BytesIn != 30 || got[1].Connections != 1 {
+		t.Errorf("unexpected second summary: %+v", got[1])
+	}
+}
+
+func TestWritePrometheus(t *testing.T) {
+	t0 := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	samples := []Sample{
+		{Name: Partitions, Value: 6},
+		{Name: BytesIn, Labels: map[string]string{"topic": "orders", "broker": "1"}, Timestamp: t0.Add(time.Minute), Value: 2048},
+		{Name: BytesIn, Labels: map[string]string{"topic": "orders", "broker": "1"}, Timestamp: t0, Value: 1024},
+		{Name: BytesIn, Labels: map[string]string{"broker": "0", "topic": `a"b\c`}, Timestamp: t0, Value: 0.5},
+		{Name: Connections, Value: math.Inf(1)},
+	}
+
+	var buf bytes.Buffer
+	if err := WritePrometheus(&buf, samples); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# TYPE kafka_controller_kafkacontroller_global_partition_count gauge
+kafka_controller_kafkacontroller_global_partition_count 6
+# TYPE kafka_namespace:kafka_server_socket_server_metrics_connection_count:sum gauge
+kafka_namespace:kafka_server_socket_server_metrics_connection_count:sum +Inf
+# TYPE kafka_server_brokertopicmetrics_bytes_in_total counter
+kafka_server_brokertopicmetrics_bytes_in_total{broker="0",topic="a\"b\\c"} 0.5 1622548800000
+kafka_server_brokertopicmetrics_bytes_in_total{broker="1",topic="orders"} 1024 1622548800000
+kafka_server_brokertopicmetrics_bytes_in_total{broker="1",topic="orders"} 2048 1622548860000
+`
+	if buf.String() != want {
+		t.Errorf("WritePrometheus() =\n%v\nwant\n%v", buf.String(), want)
+	}
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WritePrometheus writes the samples in the Prometheus text exposition format.
+// The samples of each metric are grouped under their type, which is "counter" for the metrics
+// whose name ends with "_total" and "gauge" otherwise
+func WritePrometheus(w io.Writer, samples []Sample) error {
+	sorted := make([]Sample, len(samples))
+	copy(sorted, samples)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if la, lb := formatLabels(a.Labels), formatLabels(b.Labels); la != lb {
+			return la < lb
+		}
+		return a.Timestamp.Before(b.Timestamp)
+	})
+
+	bw := bufio.NewWriter(w)
+	for i, s := range sorted {
+		if i == 0 || sorted[i-1].Name != s.Name {
+			bw.WriteString("# TYPE " + s.Name + " " + metricType(s.Name) + "\n")
+		}
+		bw.WriteString(s.Name + formatLabels(s.Labels) + " " + formatValue(s.Value))
+		if !s.Timestamp.IsZero() {
+			bw.WriteString(" " + strconv.FormatInt(s.Timestamp.UnixNano()/1e6, 10))
+		}
+		bw.WriteString("\n")
+	}
+	return bw.Flush()
+}
+
+func metricType(name string) string {
+	if strings.HasSuffix(name, "_total") {
+		return "counter"
+	}
+	return "gauge"
+}
+
+// formatLabels returns the labels sorted by name, such as `{broker="0",topic="orders"}`
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + `="` + escapeLabelValue(labels[name]) + `"`
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(v string) string {
+	return labelValueReplacer.Replace(v)
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
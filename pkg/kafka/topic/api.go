@@ -0,0 +1,24 @@
+package topic
+
+import (
+	"context"
+
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+)
+
+// ListTopics returns all the topics of the Kafka instance, fetching every page of the list
+func ListTopics(ctx context.Context, api *kafkainstanceclient.APIClient) ([]kafkainstanceclient.Topic, error) {
+	const pageSize = 100
+
+	topics := []kafkainstanceclient.Topic{}
+	for page := int32(1); ; page++ {
+		topicList, _, err := api.TopicsApi.GetTopics(ctx).Page(page).Size(pageSize).Execute()
+		if err != nil {
+			return nil, err
+		}
+		topics = append(topics, topicList.GetItems()...)
+		if len(topicList.GetItems()) < pageSize || len(topics) >= int(topicList.GetTotal()) {
+			return topics, nil
+		}
+	}
+}
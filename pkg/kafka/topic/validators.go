@@ -8,6 +8,7 @@ import (
 	"strconv"
 
 	"github.com/redhat-developer/app-services-cli/pkg/cmd/factory"
+	"github.com/redhat-developer/app-services-cli/pkg/common/clierr"
 	"github.com/redhat-developer/app-services-cli/pkg/common/commonerr"
 	"github.com/redhat-developer/app-services-cli/pkg/connection"
 	"github.com/redhat-developer/app-services-cli/pkg/localize"
@@ -156,7 +157,7 @@ func (v *Validator) ValidateNameIsAvailable(val interface{}) error {
 	_, httpRes, _ := api.TopicsApi.GetTopic(context.Background(), name).Execute()
 
 	if httpRes != nil && httpRes.StatusCode == 200 {
-		return errors.New(v.Localizer.MustLocalize("kafka.topic.create.error.conflictError", localize.NewEntry("TopicName", name), localize.NewEntry("InstanceName", kafkaInstance.GetName())))
+		return clierr.New(clierr.CodeConflict, errors.New(v.Localizer.MustLocalize("kafka.topic.create.error.conflictError", localize.NewEntry("TopicName", name), localize.NewEntry("InstanceName", kafkaInstance.GetName()))))
 	}
 
 	return nil
@@ -0,0 +1,145 @@
+// localdev package scaffolds a local development environment
+// with a Kafka instance, its admin server and a Service Registry instance
+package localdev
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/clientconfig"
+)
+
+// Container images used by the local environment.
+// The admin server is pinned to the version of the API implemented by the kafkainstance client
+const (
+	KafkaImage      = "quay.io/strimzi/kafka:0.25.0-kafka-2.8.0"
+	KafkaAdminImage = "quay.io/bf2/kafka-admin-api:0.3.0"
+	RegistryImage   = "quay.io/apicurio/apicurio-registry-mem:2.1.0.Final"
+)
+
+// Default ports exposed on the host
+const (
+	DefaultKafkaPort    = 9092
+	DefaultAdminPort    = 8080
+	DefaultRegistryPort = 8081
+)
+
+// ComposeFileName is the name of the compose file, as expected by docker-compose and podman-compose
+const ComposeFileName = "docker-compose.yaml"
+
+// ClientConfigFiles are the names of the client configuration files by configuration type
+var ClientConfigFiles = map[string]string{
+	clientconfig.QuarkusType:    "quarkus.properties",
+	clientconfig.SpringType:     "spring.properties",
+	clientconfig.LibrdkafkaType: "librdkafka.properties",
+	clientconfig.EnvType:        "rhoas.env",
+	clientconfig.JSONType:       "rhoas.json",
+}
+
+// Environment describes the ports of the local services exposed on the host
+type Environment struct {
+	KafkaPort    int
+	AdminPort    int
+	RegistryPort int
+}
+
+var templateCompose = heredoc.Doc(`
+## Generated by rhoas cli
+## Start the environment with "docker-compose up -d" or "podman-compose up -d"
+version: "3"
+services:
+  zookeeper:
+    image: {{.KafkaImage}}
+    command: ["sh", "-c", "bin/zookeeper-server-start.sh config/zookeeper.properties"]
+    environment:
+      LOG_DIR: /tmp/logs
+  kafka:
+    image: {{.KafkaImage}}
+    command:
+      - sh
+      - -c
+      - >-
+        bin/kafka-server-start.sh config/server.properties
+        --override zookeeper.connect=zookeeper:2181
+        --override listeners=INTERNAL://0.0.0.0:29092,EXTERNAL://0.0.0.0:{{.KafkaPort}}
+        --override advertised.listeners=INTERNAL://kafka:29092,EXTERNAL://localhost:{{.KafkaPort}}
+        --override listener.security.protocol.map=INTERNAL:PLAINTEXT,EXTERNAL:PLAINTEXT
+        --override inter.broker.listener.name=INTERNAL
+        --override offsets.topic.replication.factor=1
+    depends_on:
+      - zookeeper
+    ports:
+      - "{{.KafkaPort}}:{{.KafkaPort}}"
+    environment:
+      LOG_DIR: /tmp/logs
+  kafka-admin:
+    image: {{.KafkaAdminImage}}
+    depends_on:
+      - kafka
+    ports:
+      - "{{.AdminPort}}:8080"
+    environment:
+      KAFKA_ADMIN_BOOTSTRAP_SERVERS: kafka:29092
+      KAFKA_ADMIN_OAUTH_ENABLED: "false"
+      KAFKA_ADMIN_BASIC_ENABLED: "false"
+  registry:
+    image: {{.RegistryImage}}
+    ports:
+      - "{{.RegistryPort}}:8080"
+`)
+
+// DefaultEnvironment returns an environment using the default ports
+func DefaultEnvironment() *Environment {
+	return &Environment{
+		KafkaPort:    DefaultKafkaPort,
+		AdminPort:    DefaultAdminPort,
+		RegistryPort: DefaultRegistryPort,
+	}
+}
+
+// WriteComposeFile renders the compose file of the environment to w
+func (e *Environment) WriteComposeFile(w io.Writer) error {
+	tmpl, err := template.New("compose").Parse(templateCompose)
+	if err != nil {
+		return err
+	}
+
+	return tmpl.Execute(w, struct {
+		*Environment
+		KafkaImage      string
+		KafkaAdminImage string
+		RegistryImage   string
+	}{
+		Environment:     e,
+		KafkaImage:      KafkaImage,
+		KafkaAdminImage: KafkaAdminImage,
+		RegistryImage:   RegistryImage,
+	})
+}
+
+// BootstrapServerHost returns the address of Kafka on the host
+func (e *Environment) BootstrapServerHost() string {
+	return fmt.Sprintf("localhost:%v", e.KafkaPort)
+}
+
+// Profile returns the configuration of the local profile for the environment
+func (e *Environment) Profile(composeFile string) *config.LocalProfileConfig {
+	return &config.LocalProfileConfig{
+		BootstrapServerHost: e.BootstrapServerHost(),
+		AdminAPIURL:         fmt.Sprintf("http://localhost:%v/rest", e.AdminPort),
+		RegistryURL:         fmt.Sprintf("http://localhost:%v", e.RegistryPort),
+		ComposeFile:         composeFile,
+	}
+}
+
+// ClientConfiguration returns the client configuration of a local profile
+func ClientConfiguration(profile *config.LocalProfileConfig) *clientconfig.Configuration {
+	return &clientconfig.Configuration{
+		BootstrapServerHost: profile.BootstrapServerHost,
+		RegistryURL:         profile.RegistryURL,
+		Local:               true,
+	}
+}
@@ -0,0 +1,64 @@
+package localdev
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestWriteComposeFile(t *testing.T) {
+	env := &Environment{KafkaPort: 19092, AdminPort: 18080, RegistryPort: 18081}
+
+	var buf bytes.Buffer
+	if err := env.WriteComposeFile(&buf); err != nil {
+		t.Fatalf("WriteComposeFile() error = %v", err)
+	}
+
+	var compose struct {
+		Services map[string]struct {
+			Image   string   `yaml:"image"`
+			Ports   []string `yaml:"ports"`
+			Command []string `yaml:"command"`
+		} `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(buf.Bytes(), &compose); err != nil {
+		t.Fatalf("invalid compose file: %v\n%v", err, buf.String())
+	}
+
+	wantPorts := map[string]string{
+		"kafka":       "19092:19092",
+		"kafka-admin": "18080:8080",
+		"registry":    "18081:8080",
+	}
+	for name, wantPort := range wantPorts {
+		service, ok := compose.Services[name]
+		if !ok {
+			t.Errorf("service %v is missing", name)
+			continue
+		}
+		if len(service.Ports) != 1 || service.Ports[0] != wantPort {
+			t.Errorf("service %v ports = %v, want [%v]", name, service.Ports, wantPort)
+		}
+	}
+
+	kafkaCommand := strings.Join(compose.Services["kafka"].Command, " ")
+	if !strings.Contains(kafkaCommand, "EXTERNAL://localhost:19092") {
+		t.Errorf("kafka does not advertise the host port: %v", kafkaCommand)
+	}
+}
+
+func TestProfile(t *testing.T) {
+	profile := DefaultEnvironment().Profile("/tmp/docker-compose.yaml")
+
+	if profile.BootstrapServerHost != "localhost:9092" {
+		t.Errorf("BootstrapServerHost = %v", profile.BootstrapServerHost)
+	}
+	if profile.AdminAPIURL != "http://localhost:8080/rest" {
+		t.Errorf("AdminAPIURL = %v", profile.AdminAPIURL)
+	}
+	if profile.RegistryURL != "http://localhost:8081" {
+		t.Errorf("RegistryURL = %v", profile.RegistryURL)
+	}
+}
@@ -0,0 +1,232 @@
+// This file contains a logger that writes every message as a JSON object on its own line.
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Levels of the messages written by the JSON logger
+const (
+	DebugLevel = "debug"
+	InfoLevel  = "info"
+	WarnLevel  = "warn"
+	ErrorLevel = "error"
+)
+
+// JSONLoggerBuilder contains the configuration and logic needed to build a logger that writes
+// structured messages which can be parsed by log processors.
+type JSONLoggerBuilder struct {
+	debugEnabled bool
+	infoEnabled  bool
+	warnEnabled  bool
+	errorEnabled bool
+	stream       io.Writer
+	fields       []field
+}
+
+// JSONLogger is a logger that writes every message as a JSON object on its own line, with the
+// "time", "level" and "msg" keys followed by the fields of the logger.
+type JSONLogger struct {
+	debugEnabled bool
+	infoEnabled  bool
+	warnEnabled  bool
+	errorEnabled bool
+	stream       io.Writer
+	// fields are encoded once, as they are the same for every message
+	fields []byte
+	mu     sync.Mutex
+}
+
+type field struct {
+	key   string
+	value interface{}
+}
+
+// NewJSONLoggerBuilder creates a builder that knows how to build a JSON logger. By default these
+// loggers will have enabled the information, warning and error levels, and write to os.Stderr
+func NewJSONLoggerBuilder() *JSONLoggerBuilder {
+	return &JSONLoggerBuilder{
+		infoEnabled:  true,
+		warnEnabled:  true,
+		errorEnabled: true,
+	}
+}
+
+// Stream sets the stream to write the messages to.
+func (b *JSONLoggerBuilder) Stream(stream io.Writer) *JSONLoggerBuilder {
+	b.stream = stream
+	return b
+}
+
+// Debug enables or disables the debug level.
+func (b *JSONLoggerBuilder) Debug(flag bool) *JSONLoggerBuilder {
+	b.debugEnabled = flag
+	return b
+}
+
+// Info enables or disables the information level.
+func (b *JSONLoggerBuilder) Info(flag bool) *JSONLoggerBuilder {
+	b.infoEnabled = flag
+	return b
+}
+
+// Warn enables or disables the warning level.
+func (b *JSONLoggerBuilder) Warn(flag bool) *JSONLoggerBuilder {
+	b.warnEnabled = flag
+	return b
+}
+
+// Error enables or disables the error level.
+func (b *JSONLoggerBuilder) Error(flag bool) *JSONLoggerBuilder {
+	b.errorEnabled = flag
+	return b
+}
+
+// Field adds a key/value field to every message, such as the command being run.
+// The fields are written in the order they were added
+func (b *JSONLoggerBuilder) Field(key string, value interface{}) *JSONLoggerBuilder {
+	b.fields = append(b.fields, field{key: key, value: value})
+	return b
+}
+
+// Build creates a new logger using the configuration stored in the builder.
+func (b *JSONLoggerBuilder) Build() (*JSONLogger, error) {
+	logger := &JSONLogger{
+		debugEnabled: b.debugEnabled,
+		infoEnabled:  b.infoEnabled,
+		warnEnabled:  b.warnEnabled,
+		errorEnabled: b.errorEnabled,
+		stream:       b.stream,
+	}
+	if logger.stream == nil {
+		logger.stream = os.Stderr
+	}
+
+	var fields bytes.Buffer
+	for _, f := range b.fields {
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode log field %q: %w", f.key, err)
+		}
+		key, _ := json.Marshal(f.key)
+		fields.WriteByte(',')
+		fields.Write(key)
+		fields.WriteByte(':')
+		fields.Write(value)
+	}
+	logger.fields = fields.Bytes()
+
+	return logger, nil
+}
+
+// DebugEnabled returns true iff the debug level is enabled.
+func (l *JSONLogger) DebugEnabled() bool {
+	return l.debugEnabled
+}
+
+// InfoEnabled returns true iff the information level is enabled.
+func (l *JSONLogger) InfoEnabled() bool {
+	return l.infoEnabled
+}
+
+// WarnEnabled returns true iff the warning level is enabled.
+func (l *JSONLogger) WarnEnabled() bool {
+	return l.warnEnabled
+}
+
+// ErrorEnabled returns true iff the error level is enabled.
+func (l *JSONLogger) ErrorEnabled() bool {
+	return l.errorEnabled
+}
+
+// Debug sends to the log a debug message formatted using the fmt.Sprintln function and the given
+// arguments.
+func (l *JSONLogger) Debug(args ...interface{}) {
+	if l.debugEnabled {
+		l.write(DebugLevel, fmt.Sprintln(args...))
+	}
+}
+
+// Debugf sends to the log a debug message formatted using the fmt.Sprintf function and the given
+// format and arguments.
+func (l *JSONLogger) Debugf(format string, args ...interface{}) {
+	if l.debugEnabled {
+		l.write(DebugLevel, fmt.Sprintf(format, args...))
+	}
+}
+
+// Info sends to the log an information message formatted using the fmt.Sprintln function and the
+// given arguments.
+func (l *JSONLogger) Info(args ...interface{}) {
+	if l.infoEnabled {
+		l.write(InfoLevel, fmt.Sprintln(args...))
+	}
+}
+
+// Infof sends to the log an information message formatted using the fmt.Sprintf function and the
+// given format and arguments.
+func (l *JSONLogger) Infof(format string, args ...interface{}) {
+	if l.infoEnabled {
+		l.write(InfoLevel, fmt.Sprintf(format, args...))
+	}
+}
+
+// Warn sends to the log a warning message formatted using the fmt.Sprintln function and the given
+// arguments.
+func (l *JSONLogger) Warn(args ...interface{}) {
+	if l.warnEnabled {
+		l.write(WarnLevel, fmt.Sprintln(args...))
+	}
+}
+
+// Warnf sends to the log a warning message formatted using the fmt.Sprintf function and the given
+// format and arguments.
+func (l *JSONLogger) Warnf(format string, args ...interface{}) {
+	if l.warnEnabled {
+		l.write(WarnLevel, fmt.Sprintf(format, args...))
+	}
+}
+
+// Error sends to the log an error message formatted using the fmt.Sprintln function and the given
+// arguments.
+func (l *JSONLogger) Error(args ...interface{}) {
+	if l.errorEnabled {
+		l.write(ErrorLevel, fmt.Sprintln(args...))
+	}
+}
+
+// Errorf sends to the log an error message formatted using the fmt.Sprintf function and the given
+// format and arguments.
+func (l *JSONLogger) Errorf(format string, args ...interface{}) {
+	if l.errorEnabled {
+		l.write(ErrorLevel, fmt.Sprintf(format, args...))
+	}
+}
+
+func (l *JSONLogger) write(level string, msg string) {
+	// blank messages are used to space out the text output, they carry no information
+	msg = strings.TrimRight(msg, "\n")
+	if strings.TrimSpace(msg) == "" {
+		return
+	}
+
+	encodedMsg, _ := json.Marshal(msg)
+
+	var line bytes.Buffer
+	fmt.Fprintf(&line, `{"time":%q,"level":%q,"msg":`, time.Now().Format(time.RFC3339Nano), level)
+	line.Write(encodedMsg)
+	line.Write(l.fields)
+	line.WriteString("}\n")
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = l.stream.Write(line.Bytes())
+}
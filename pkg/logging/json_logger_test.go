@@ -0,0 +1,54 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestJSONLogger(t *testing.T) {
+	var out bytes.Buffer
+	logger, err := NewJSONLoggerBuilder().
+		Stream(&out).
+		Field("command", "rhoas kafka list").
+		Field("pid", 42).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger.Debug("not written")
+	logger.Info("")
+	logger.Info("Kafka instance", "created")
+	logger.Warnf("%v is deprecated", "--name")
+	logger.Error("failed")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %v lines, want 3:\n%v", len(lines), out.String())
+	}
+
+	// the keys are written in a stable order
+	if !strings.HasPrefix(lines[0], `{"time":`) || !strings.HasSuffix(lines[0], `"command":"rhoas kafka list","pid":42}`) {
+		t.Errorf("unexpected line: %v", lines[0])
+	}
+
+	want := []struct {
+		level string
+		msg   string
+	}{
+		{InfoLevel, "Kafka instance created"},
+		{WarnLevel, "--name is deprecated"},
+		{ErrorLevel, "failed"},
+	}
+	for i, line := range lines {
+		var entry map[string]interface{}
+		if err = json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid JSON %q: %v", line, err)
+		}
+		if entry["level"] != want[i].level || entry["msg"] != want[i].msg || entry["command"] != "rhoas kafka list" || entry["time"] == "" {
+			t.Errorf("unexpected entry: %v", entry)
+		}
+	}
+}
@@ -23,6 +23,14 @@ type Logger interface {
 	// the given format and arguments.
 	Infof(format string, args ...interface{})
 
+	// Warn sends to the log a warning message formatted using the fmt.Sprintln function and the
+	// given arguments.
+	Warn(args ...interface{})
+
+	// Warnf sends to the log a warning message formatted using the fmt.Sprintf function and the
+	// given format and arguments.
+	Warnf(format string, args ...interface{})
+
 	// Error sends to the log an error message formatted using the fmt.Sprintln function and the
 	// given format and arguments.
 	Error(args ...interface{})
@@ -13,6 +13,7 @@ import (
 type StdLoggerBuilder struct {
 	debugEnabled bool
 	infoEnabled  bool
+	warnEnabled  bool
 	errorEnabled bool
 	outStream    io.Writer
 	errStream    io.Writer
@@ -22,6 +23,7 @@ type StdLoggerBuilder struct {
 type StdLogger struct {
 	debugEnabled bool
 	infoEnabled  bool
+	warnEnabled  bool
 	errorEnabled bool
 	outStream    io.Writer
 	errStream    io.Writer
@@ -37,6 +39,7 @@ func NewStdLoggerBuilder() *StdLoggerBuilder {
 	// Set default values:
 	builder.debugEnabled = false
 	builder.infoEnabled = true
+	builder.warnEnabled = true
 	builder.errorEnabled = true
 
 	return builder
@@ -62,6 +65,12 @@ func (b *StdLoggerBuilder) Info(flag bool) *StdLoggerBuilder {
 	return b
 }
 
+// Warn enables or disables the warning level.
+func (b *StdLoggerBuilder) Warn(flag bool) *StdLoggerBuilder {
+	b.warnEnabled = flag
+	return b
+}
+
 // Error enables or disables the error level.
 func (b *StdLoggerBuilder) Error(flag bool) *StdLoggerBuilder {
 	b.errorEnabled = flag
@@ -74,6 +83,7 @@ func (b *StdLoggerBuilder) Build() (logger *StdLogger, err error) {
 	logger = new(StdLogger)
 	logger.debugEnabled = b.debugEnabled
 	logger.infoEnabled = b.infoEnabled
+	logger.warnEnabled = b.warnEnabled
 	logger.errorEnabled = b.errorEnabled
 	logger.outStream = b.outStream
 	logger.errStream = b.errStream
@@ -97,6 +107,11 @@ func (l *StdLogger) InfoEnabled() bool {
 	return l.infoEnabled
 }
 
+// WarnEnabled returns true iff the warning level is enabled.
+func (l *StdLogger) WarnEnabled() bool {
+	return l.warnEnabled
+}
+
 // ErrorEnabled returns true iff the error level is enabled.
 func (l *StdLogger) ErrorEnabled() bool {
 	return l.errorEnabled
@@ -134,6 +149,22 @@ func (l *StdLogger) Debugf(format string, args ...interface{}) {
 	}
 }
 
+// Warn sends to the log a warning message formatted using the fmt.Fprintln function and the given
+// arguments.
+func (l *StdLogger) Warn(args ...interface{}) {
+	if l.warnEnabled {
+		fmt.Fprintln(l.errStream, args...)
+	}
+}
+
+// Warnf sends to the log a warning message formatted using the fmt.Fprintf function and the given
+// format and arguments.
+func (l *StdLogger) Warnf(format string, args ...interface{}) {
+	if l.warnEnabled {
+		fmt.Fprintf(l.errStream, format+"\n", args...)
+	}
+}
+
 // Error sends to the log an error message formatted using the fmt.Fprintln function and the given
 // arguments.
 func (l *StdLogger) Error(args ...interface{}) {
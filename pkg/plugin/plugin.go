@@ -0,0 +1,158 @@
+// Package plugin discovers and runs plugins, which are executables named "rhoas-<name>" on the PATH.
+// A plugin is exposed as the "rhoas <name>" command, in the same way as git and kubectl plugins.
+package plugin
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/iostreams"
+)
+
+// Prefix is the prefix of the names of the plugin executables
+const Prefix = "rhoas-"
+
+// Environment variables set when running a plugin, in addition to
+// the location of the configuration file, which is set in config.EnvName
+const (
+	EnvAPIURL      = "RHOAS_API_URL"
+	EnvKafkaID     = "RHOAS_KAFKA_ID"
+	EnvRegistryID  = "RHOAS_REGISTRY_ID"
+	EnvAccessToken = "RHOAS_ACCESS_TOKEN"
+)
+
+// Plugin is an executable found on the PATH
+type Plugin struct {
+	// Name is the name of the command which runs the plugin
+	Name string `json:"name" yaml:"name"`
+	// Path is the path of the executable
+	Path string `json:"path" yaml:"path"`
+}
+
+// Find returns the plugins found in the directories of the path list, in the format of the PATH
+// environment variable. When several executables have the same name, the first one wins,
+// as when running a command from a shell. The plugins are sorted by name
+func Find(pathList string) []Plugin {
+	plugins := []Plugin{}
+	found := map[string]bool{}
+
+	for _, dir := range filepath.SplitList(pathList) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := pluginName(entry.Name())
+			if !ok || found[name] {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			found[name] = true
+			plugins = append(plugins, Plugin{Name: name, Path: path})
+		}
+	}
+
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Name < plugins[j].Name
+	})
+	return plugins
+}
+
+// pluginName returns the name of the command of a plugin executable
+func pluginName(fileName string) (string, bool) {
+	if !strings.HasPrefix(fileName, Prefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(fileName, Prefix)
+	if runtime.GOOS == "windows" {
+		ext := filepath.Ext(name)
+		if !strings.EqualFold(ext, ".exe") {
+			return "", false
+		}
+		name = strings.TrimSuffix(name, ext)
+	}
+	if name == "" || strings.ContainsAny(name, " .") {
+		return "", false
+	}
+	return name, true
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	return info.Mode().Perm()&0o111 != 0
+}
+
+// Environment returns the environment variables passed to plugins, describing the current session.
+// The access token is only set when the session has one
+func Environment(cfgLocation string, cfg *config.Config) []string {
+	env := []string{
+		config.EnvName + "=" + cfgLocation,
+		EnvAPIURL + "=" + cfg.APIUrl,
+	}
+
+	kafkaID := ""
+	if cfg.HasKafka() {
+		kafkaID = cfg.Services.Kafka.ClusterID
+	}
+	env = append(env, EnvKafkaID+"="+kafkaID)
+
+	registryID := ""
+	if cfg.HasServiceRegistry() {
+		registryID = cfg.Services.ServiceRegistry.InstanceID
+	}
+	env = append(env, EnvRegistryID+"="+registryID)
+
+	if cfg.AccessToken != "" {
+		env = append(env, EnvAccessToken+"="+cfg.AccessToken)
+	}
+
+	return env
+}
+
+// ExitError is returned when a plugin exits with a non-zero status,
+// so that the CLI exits with the same status
+type ExitError struct {
+	Plugin Plugin
+	Code   int
+}
+
+func (e *ExitError) Error() string {
+	return "plugin " + e.Plugin.Name + " exited with status " + strconv.Itoa(e.Code)
+}
+
+// Run runs the plugin with the arguments, connected to the IO streams, adding the environment
+// variables to the ones of the CLI
+func Run(p Plugin, args []string, env []string, io *iostreams.IOStreams) error {
+	// #nosec G204
+	cmd := exec.Command(p.Path, args...)
+	cmd.Stdin = io.In
+	cmd.Stdout = io.Out
+	cmd.Stderr = io.ErrOut
+	cmd.Env = append(os.Environ(), env...)
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return &ExitError{Plugin: p, Code: exitErr.ExitCode()}
+	}
+	return err
+}
@@ -0,0 +1,81 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/redhat-developer/app-services-cli/internal/config"
+)
+
+func writeFile(t *testing.T, path string, mode os.FileMode) {
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"), mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFind(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are found by their extension on Windows")
+	}
+
+	first, second := t.TempDir(), t.TempDir()
+	writeFile(t, filepath.Join(first, "rhoas-hello"), 0o755)
+	writeFile(t, filepath.Join(first, "rhoas-not-executable"), 0o644)
+	writeFile(t, filepath.Join(first, "other"), 0o755)
+	writeFile(t, filepath.Join(second, "rhoas-hello"), 0o755)
+	writeFile(t, filepath.Join(second, "rhoas-audit"), 0o755)
+	writeFile(t, filepath.Join(second, "rhoas-"), 0o755)
+	if err := os.Mkdir(filepath.Join(second, "rhoas-dir"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	pathList := first + string(os.PathListSeparator) + filepath.Join(first, "missing") + string(os.PathListSeparator) + second
+	got := Find(pathList)
+	want := []Plugin{
+		{Name: "audit", Path: filepath.Join(second, "rhoas-audit")},
+		{Name: "hello", Path: filepath.Join(first, "rhoas-hello")},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Find() = %v, want %v", got, want)
+	}
+}
+
+func TestEnvironment(t *testing.T) {
+	t.Run("logged in with instances selected", func(t *testing.T) {
+		cfg := &config.Config{
+			APIUrl:      "https://api.openshift.com",
+			AccessToken: "token",
+			Services: config.ServiceConfigMap{
+				Kafka:           &config.KafkaConfig{ClusterID: "kafka-id"},
+				ServiceRegistry: &config.ServiceRegistryConfig{InstanceID: "registry-id", Name: "my-registry"},
+			},
+		}
+		got := Environment("/home/user/.config/rhoas/config.json", cfg)
+		want := []string{
+			"RHOASCONFIG=/home/user/.config/rhoas/config.json",
+			"RHOAS_API_URL=https://api.openshift.com",
+			"RHOAS_KAFKA_ID=kafka-id",
+			"RHOAS_REGISTRY_ID=registry-id",
+			"RHOAS_ACCESS_TOKEN=token",
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Environment() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("logged out", func(t *testing.T) {
+		got := Environment("config.json", &config.Config{})
+		want := []string{
+			"RHOASCONFIG=config.json",
+			"RHOAS_API_URL=",
+			"RHOAS_KAFKA_ID=",
+			"RHOAS_REGISTRY_ID=",
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Environment() = %v, want %v", got, want)
+		}
+	})
+}
@@ -40,22 +40,20 @@ func EnableDevPreview(f *factory.Factory, enablement bool) (*config.Config, erro
 		return nil, err
 	}
 
-	config, err := f.Config.Load()
-	if err != nil {
-		logger.Info(f.Localizer.MustLocalize("profile.error.enablement"), err)
-		return nil, err
-	}
-
-	config.DevPreviewEnabled = enablement
-	err = f.Config.Save(config)
+	var cfg *config.Config
+	err = f.Config.Update(func(c *config.Config) error {
+		c.DevPreviewEnabled = enablement
+		cfg = c
+		return nil
+	})
 	if err != nil {
 		logger.Info(f.Localizer.MustLocalize("profile.error.enablement"), err)
 		return nil, err
 	}
-	if config.DevPreviewEnabled {
+	if cfg.DevPreviewEnabled {
 		logger.Info(f.Localizer.MustLocalize("profile.status.devpreview.enabled"))
 	} else {
 		logger.Info(f.Localizer.MustLocalize("profile.status.devpreview.disabled"))
 	}
-	return config, err
+	return cfg, err
 }
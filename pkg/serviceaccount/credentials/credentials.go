@@ -1,6 +1,7 @@
 package credentials
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -8,32 +9,8 @@ import (
 	"path/filepath"
 
 	"github.com/redhat-developer/app-services-cli/pkg/color"
-	"github.com/redhat-developer/app-services-cli/pkg/dump"
 
 	"github.com/AlecAivazis/survey/v2"
-
-	"github.com/MakeNowJust/heredoc"
-)
-
-// Templates
-var (
-	templateProperties = heredoc.Doc(`
-	## Generated by rhoas cli
-	clientID=%v
-	clientSecret=%v
-	`)
-
-	templateEnv = heredoc.Doc(`
-	## Generated by rhoas cli
-	CLIENT_ID=%v
-	CLIENT_SECRET=%v
-	`)
-
-	templateJSON = heredoc.Doc(`
-	{ 
-		"clientID":"%v", 
-		"clientSecret":"%v" 
-	}`)
 )
 
 // Credentials is a type which represents the credentials
@@ -41,17 +18,32 @@ var (
 type Credentials struct {
 	ClientID     string `json:"client_id,omitempty"`
 	ClientSecret string `json:"client_secret,omitempty"`
+	// BootstrapServerHost is the bootstrap server host of the selected Kafka instance, if any
+	BootstrapServerHost string `json:"bootstrap_server_host,omitempty"`
+	// TokenURL is the OAuth token endpoint which issues tokens for the service account,
+	// set along with BootstrapServerHost
+	TokenURL string `json:"token_url,omitempty"`
 }
 
 // GetDefaultPath returns the default absolute path for the credentials file
 func GetDefaultPath(outputFormat string) (filePath string) {
 	switch outputFormat {
-	case "env":
+	case EnvFormat:
 		filePath = ".env"
-	case "properties":
+	case PropertiesFormat:
 		filePath = "credentials.properties"
-	case dump.JSONFormat:
+	case JSONFormat:
 		filePath = "credentials.json"
+	case YAMLFormat:
+		filePath = "credentials.yaml"
+	case JAASFormat:
+		filePath = "jaas.conf"
+	case LibrdkafkaFormat:
+		filePath = "librdkafka.conf"
+	case SecretFormat:
+		filePath = "credentials-secret.yaml"
+	default:
+		filePath = "credentials"
 	}
 
 	pwd, err := os.Getwd()
@@ -66,29 +58,16 @@ func GetDefaultPath(outputFormat string) (filePath string) {
 
 // Write saves the credentials to a file
 // in the specified output format
-func Write(output string, filepath string, credentials *Credentials) error {
-	fileTemplate := getFileFormat(output)
-	fileBody := fmt.Sprintf(fileTemplate, credentials.ClientID, credentials.ClientSecret)
-
-	fileData := []byte(fileBody)
+func Write(output string, filepath string, credentials *Credentials, opts *FormatOptions) error {
+	var buf bytes.Buffer
+	if err := Encode(&buf, output, credentials, opts); err != nil {
+		return err
+	}
 
 	// replace any env vars in the file path
 	trueFilePath := os.ExpandEnv(filepath)
 
-	return ioutil.WriteFile(trueFilePath, fileData, 0o600)
-}
-
-func getFileFormat(output string) (format string) {
-	switch output {
-	case "env":
-		format = templateEnv
-	case "properties":
-		format = templateProperties
-	case dump.JSONFormat:
-		format = templateJSON
-	}
-
-	return format
+	return ioutil.WriteFile(trueFilePath, buf.Bytes(), 0o600)
 }
 
 // ChooseFileLocation starts an interactive prompt to get the path to the credentials file
@@ -0,0 +1,312 @@
+package credentials
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode/utf16"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Formats of the credentials
+const (
+	EnvFormat        = "env"
+	JSONFormat       = "json"
+	PropertiesFormat = "properties"
+	YAMLFormat       = "yaml"
+	JAASFormat       = "jaas"
+	LibrdkafkaFormat = "librdkafka"
+	SecretFormat     = "secret"
+	TemplateFormat   = "template"
+)
+
+// Formats are the supported formats of the credentials
+var Formats = []string{EnvFormat, JSONFormat, PropertiesFormat, YAMLFormat, JAASFormat, LibrdkafkaFormat, SecretFormat, TemplateFormat}
+
+// FormatOptions are the options of the formats which need more than the credentials
+type FormatOptions struct {
+	// SecretName is the name of the Kubernetes secret rendered by the secret format
+	SecretName string
+	// TemplatePath is the path to the Go template rendered by the template format
+	TemplatePath string
+}
+
+const (
+	generatedHeader = "Generated by rhoas cli"
+
+	// defaultSecretName is the name of the Kubernetes secret when FormatOptions.SecretName is not set
+	defaultSecretName = "rhoas-service-account"
+)
+
+// document is the credentials as written by the json and yaml formats
+type document struct {
+	ClientID            string `json:"clientID" yaml:"clientID"`
+	ClientSecret        string `json:"clientSecret" yaml:"clientSecret"`
+	BootstrapServerHost string `json:"bootstrapServerHost,omitempty" yaml:"bootstrapServerHost,omitempty"`
+	TokenURL            string `json:"oauthTokenEndpointURI,omitempty" yaml:"oauthTokenEndpointURI,omitempty"`
+}
+
+// secretManifest is the Kubernetes secret written by the secret format
+type secretManifest struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Metadata   struct {
+		Name string `yaml:"name"`
+	} `yaml:"metadata"`
+	Type       string        `yaml:"type"`
+	StringData yaml.MapSlice `yaml:"stringData"`
+}
+
+// sampleCredentials are the credentials used to check that a template can be executed
+var sampleCredentials = &Credentials{
+	ClientID:            "srvc-acct-00000000-0000-0000-0000-000000000000",
+	ClientSecret:        "00000000-0000-0000-0000-000000000000",
+	BootstrapServerHost: "kafka.example.com:443",
+	TokenURL:            "https://sso.example.com/token",
+}
+
+// safeEnvValue matches the values which do not need to be quoted in an env file
+var safeEnvValue = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]*$`)
+
+// Encode writes the credentials to w in the given format
+func Encode(w io.Writer, format string, creds *Credentials, opts *FormatOptions) error {
+	if opts == nil {
+		opts = &FormatOptions{}
+	}
+
+	switch format {
+	case EnvFormat:
+		return encodeEnv(w, creds)
+	case JSONFormat:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(newDocument(creds))
+	case PropertiesFormat:
+		return encodeProperties(w, creds)
+	case YAMLFormat:
+		return encodeYAML(w, newDocument(creds))
+	case JAASFormat:
+		return encodeJAAS(w, creds)
+	case LibrdkafkaFormat:
+		return encodeLibrdkafka(w, creds)
+	case SecretFormat:
+		return encodeSecret(w, creds, opts.SecretName)
+	case TemplateFormat:
+		tmpl, err := parseTemplate(opts.TemplatePath)
+		if err != nil {
+			return err
+		}
+		return tmpl.Execute(w, creds)
+	default:
+		return fmt.Errorf("unsupported credentials format %q", format)
+	}
+}
+
+// checkFormat returns an error when the credentials cannot be encoded in the given format,
+// such as when the template of the template format is invalid.
+// The template is executed with sample credentials, so that it fails before the service account is created
+func checkFormat(format string, opts *FormatOptions) error {
+	if format != TemplateFormat {
+		return nil
+	}
+	if opts == nil {
+		opts = &FormatOptions{}
+	}
+	tmpl, err := parseTemplate(opts.TemplatePath)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(ioutil.Discard, sampleCredentials)
+}
+
+func newDocument(creds *Credentials) *document {
+	return &document{
+		ClientID:            creds.ClientID,
+		ClientSecret:        creds.ClientSecret,
+		BootstrapServerHost: creds.BootstrapServerHost,
+		TokenURL:            creds.TokenURL,
+	}
+}
+
+// encodeEnv writes the credentials as environment variables,
+// named the same as in the env configuration of "rhoas generate-config"
+func encodeEnv(w io.Writer, creds *Credentials) error {
+	lines := []string{
+		"## " + generatedHeader,
+		"CLIENT_ID=" + EnvValue(creds.ClientID),
+		"CLIENT_SECRET=" + EnvValue(creds.ClientSecret),
+	}
+	if creds.BootstrapServerHost != "" {
+		lines = append(lines, "KAFKA_HOST="+EnvValue(creds.BootstrapServerHost))
+	}
+	if creds.TokenURL != "" {
+		lines = append(lines, "OAUTH_TOKEN_ENDPOINT_URI="+EnvValue(creds.TokenURL))
+	}
+	return writeLines(w, lines)
+}
+
+func encodeProperties(w io.Writer, creds *Credentials) error {
+	lines := []string{
+		"## " + generatedHeader,
+		"clientID=" + PropertiesValue(creds.ClientID),
+		"clientSecret=" + PropertiesValue(creds.ClientSecret),
+	}
+	if creds.BootstrapServerHost != "" {
+		lines = append(lines, "bootstrapServerHost="+PropertiesValue(creds.BootstrapServerHost))
+	}
+	if creds.TokenURL != "" {
+		lines = append(lines, "oauthTokenEndpointURI="+PropertiesValue(creds.TokenURL))
+	}
+	return writeLines(w, lines)
+}
+
+func encodeYAML(w io.Writer, v interface{}) error {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "## %v\n%s", generatedHeader, data)
+	return err
+}
+
+// encodeJAAS writes a JAAS configuration file for the Kafka Java client,
+// which uses OAuth when the token endpoint is known and SASL/PLAIN otherwise
+func encodeJAAS(w io.Writer, creds *Credentials) error {
+	lines := []string{"// " + generatedHeader}
+	if creds.BootstrapServerHost != "" {
+		lines = append(lines, "// bootstrap.servers="+creds.BootstrapServerHost)
+	}
+	lines = append(lines, "KafkaClient {")
+	if creds.TokenURL != "" {
+		lines = append(lines,
+			"  org.apache.kafka.common.security.oauthbearer.OAuthBearerLoginModule required",
+			"  oauth.client.id="+JAASValue(creds.ClientID),
+			"  oauth.client.secret="+JAASValue(creds.ClientSecret),
+			"  oauth.token.endpoint.uri="+JAASValue(creds.TokenURL)+";",
+		)
+	} else {
+		lines = append(lines,
+			"  org.apache.kafka.common.security.plain.PlainLoginModule required",
+			"  username="+JAASValue(creds.ClientID),
+			"  password="+JAASValue(creds.ClientSecret)+";",
+		)
+	}
+	lines = append(lines, "};")
+	return writeLines(w, lines)
+}
+
+// encodeLibrdkafka writes a librdkafka configuration file, as read by "kcat -F",
+// which uses OAuth when the token endpoint is known and SASL/PLAIN otherwise
+func encodeLibrdkafka(w io.Writer, creds *Credentials) error {
+	lines := []string{"## " + generatedHeader}
+	if creds.BootstrapServerHost != "" {
+		lines = append(lines, "bootstrap.servers="+creds.BootstrapServerHost)
+	}
+	lines = append(lines, "security.protocol=SASL_SSL")
+	if creds.TokenURL != "" {
+		lines = append(lines,
+			"sasl.mechanisms=OAUTHBEARER",
+			"sasl.oauthbearer.method=oidc",
+			"sasl.oauthbearer.client.id="+creds.ClientID,
+			"sasl.oauthbearer.client.secret="+creds.ClientSecret,
+			"sasl.oauthbearer.token.endpoint.url="+creds.TokenURL,
+		)
+	} else {
+		lines = append(lines,
+			"sasl.mechanisms=PLAIN",
+			"sasl.username="+creds.ClientID,
+			"sasl.password="+creds.ClientSecret,
+		)
+	}
+	return writeLines(w, lines)
+}
+
+// encodeSecret writes a Kubernetes secret manifest, with the same keys as the secrets created by SecretSink
+func encodeSecret(w io.Writer, creds *Credentials, name string) error {
+	manifest := &secretManifest{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Type:       "Opaque",
+		StringData: yaml.MapSlice{},
+	}
+	manifest.Metadata.Name = name
+	if name == "" {
+		manifest.Metadata.Name = defaultSecretName
+	}
+	data := secretData(creds)
+	for _, key := range secretKeys {
+		if value, ok := data[key]; ok {
+			manifest.StringData = append(manifest.StringData, yaml.MapItem{Key: key, Value: value})
+		}
+	}
+
+	return encodeYAML(w, manifest)
+}
+
+// parseTemplate parses the Go template at path, which is rendered with the Credentials
+func parseTemplate(path string) (*template.Template, error) {
+	if path == "" {
+		return nil, errors.New("the template format requires the path to a template")
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return template.New(filepath.Base(path)).Parse(string(data))
+}
+
+// EnvValue quotes the value when it contains characters which a shell or a dotenv parser would interpret
+func EnvValue(v string) string {
+	if safeEnvValue.MatchString(v) {
+		return v
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "`", "\\`", "\n", `\n`)
+	return `"` + r.Replace(v) + `"`
+}
+
+// PropertiesValue escapes the value of a Java properties file, which is read as ISO 8859-1
+func PropertiesValue(v string) string {
+	var b strings.Builder
+	for i, r := range v {
+		switch {
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\f':
+			b.WriteString(`\f`)
+		case r == ' ' && i == 0:
+			// leading whitespace would be stripped
+			b.WriteString(`\ `)
+		case r < 0x20 || r > 0x7e:
+			for _, u := range utf16.Encode([]rune{r}) {
+				fmt.Fprintf(&b, `\u%04x`, u)
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// JAASValue quotes a value of a JAAS configuration
+func JAASValue(v string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + r.Replace(v) + `"`
+}
+
+func writeLines(w io.Writer, lines []string) error {
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
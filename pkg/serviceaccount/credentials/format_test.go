@@ -0,0 +1,185 @@
+package credentials
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+var testKafkaCredentials = &Credentials{
+	ClientID:            "srvc-acct-1",
+	ClientSecret:        `se"cr\et $x`,
+	BootstrapServerHost: "my-kafka.kafka.example.com:443",
+	TokenURL:            "https://identity.example.com/auth/realms/rhoas/protocol/openid-connect/token",
+}
+
+func encode(t *testing.T, format string, creds *Credentials, opts *FormatOptions) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := Encode(&buf, format, creds, opts); err != nil {
+		t.Fatalf("Encode(%v) error = %v", format, err)
+	}
+	return buf.String()
+}
+
+func TestEncodeDocuments(t *testing.T) {
+	tests := []struct {
+		format    string
+		unmarshal func([]byte, interface{}) error
+	}{
+		{format: JSONFormat, unmarshal: json.Unmarshal},
+		{format: YAMLFormat, unmarshal: yaml.Unmarshal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var doc document
+			if err := tt.unmarshal([]byte(encode(t, tt.format, testKafkaCredentials, nil)), &doc); err != nil {
+				t.Fatal(err)
+			}
+			if doc != *newDocument(testKafkaCredentials) {
+				t.Errorf("decoded %+v, want %+v", doc, *newDocument(testKafkaCredentials))
+			}
+
+			out := encode(t, tt.format, testCredentials, nil)
+			if strings.Contains(out, "bootstrapServerHost") || strings.Contains(out, "oauthTokenEndpointURI") {
+				t.Errorf("expected no Kafka details without a Kafka instance, got %v", out)
+			}
+		})
+	}
+}
+
+func TestEncodeLines(t *testing.T) {
+	tests := []struct {
+		format      string
+		creds       *Credentials
+		wantLines   []string
+		unwantLines []string
+	}{
+		{
+			format: EnvFormat,
+			creds:  testKafkaCredentials,
+			wantLines: []string{
+				"CLIENT_ID=srvc-acct-1",
+				`CLIENT_SECRET="se\"cr\\et \$x"`,
+				"KAFKA_HOST=my-kafka.kafka.example.com:443",
+				"OAUTH_TOKEN_ENDPOINT_URI=" + testKafkaCredentials.TokenURL,
+			},
+		},
+		{
+			format:      EnvFormat,
+			creds:       testCredentials,
+			unwantLines: []string{"KAFKA_HOST", "OAUTH_TOKEN_ENDPOINT_URI"},
+		},
+		{
+			format: PropertiesFormat,
+			creds:  &Credentials{ClientID: "srvc-acct-1", ClientSecret: " a\\b\ncé"},
+			wantLines: []string{
+				"clientID=srvc-acct-1",
+				`clientSecret=\ a\\b\nc\u00e9`,
+			},
+		},
+		{
+			format: JAASFormat,
+			creds:  testKafkaCredentials,
+			wantLines: []string{
+				"// bootstrap.servers=my-kafka.kafka.example.com:443",
+				"org.apache.kafka.common.security.oauthbearer.OAuthBearerLoginModule required",
+				`oauth.client.secret="se\"cr\\et $x"`,
+				`oauth.token.endpoint.uri="` + testKafkaCredentials.TokenURL + `";`,
+			},
+		},
+		{
+			format:      JAASFormat,
+			creds:       testCredentials,
+			wantLines:   []string{"org.apache.kafka.common.security.plain.PlainLoginModule required", `username="srvc-acct-1"`},
+			unwantLines: []string{"bootstrap.servers", "oauth"},
+		},
+		{
+			format: LibrdkafkaFormat,
+			creds:  testKafkaCredentials,
+			wantLines: []string{
+				"bootstrap.servers=my-kafka.kafka.example.com:443",
+				"sasl.mechanisms=OAUTHBEARER",
+				"sasl.oauthbearer.client.id=srvc-acct-1",
+				"sasl.oauthbearer.token.endpoint.url=" + testKafkaCredentials.TokenURL,
+			},
+		},
+		{
+			format:      LibrdkafkaFormat,
+			creds:       testCredentials,
+			wantLines:   []string{"sasl.mechanisms=PLAIN", "sasl.username=srvc-acct-1"},
+			unwantLines: []string{"bootstrap.servers", "oauthbearer"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			out := encode(t, tt.format, tt.creds, nil)
+			for _, line := range tt.wantLines {
+				if !strings.Contains(out, line+"\n") {
+					t.Errorf("expected the line %q in:\n%v", line, out)
+				}
+			}
+			for _, line := range tt.unwantLines {
+				if strings.Contains(out, line) {
+					t.Errorf("unexpected %q in:\n%v", line, out)
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeSecret(t *testing.T) {
+	var manifest struct {
+		Kind     string `yaml:"kind"`
+		Metadata struct {
+			Name string `yaml:"name"`
+		} `yaml:"metadata"`
+		StringData map[string]string `yaml:"stringData"`
+	}
+	out := encode(t, SecretFormat, testKafkaCredentials, &FormatOptions{SecretName: "my-app"})
+	if err := yaml.Unmarshal([]byte(out), &manifest); err != nil {
+		t.Fatal(err)
+	}
+
+	if manifest.Kind != "Secret" || manifest.Metadata.Name != "my-app" {
+		t.Errorf("unexpected manifest:\n%v", out)
+	}
+	for key, value := range secretData(testKafkaCredentials) {
+		if manifest.StringData[key] != value {
+			t.Errorf("stringData[%v] = %q, want %q", key, manifest.StringData[key], value)
+		}
+	}
+}
+
+func TestEncodeTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.properties.tmpl")
+	if err := os.WriteFile(path, []byte("id={{.ClientID}}\nservers={{.BootstrapServerHost}}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	out := encode(t, TemplateFormat, testKafkaCredentials, &FormatOptions{TemplatePath: path})
+	if out != "id=srvc-acct-1\nservers=my-kafka.kafka.example.com:443\n" {
+		t.Errorf("unexpected output %q", out)
+	}
+
+	if err := checkFormat(TemplateFormat, &FormatOptions{}); err == nil {
+		t.Error("expected an error without a template")
+	}
+	if err := os.WriteFile(path, []byte("{{.ClientID"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkFormat(TemplateFormat, &FormatOptions{TemplatePath: path}); err == nil {
+		t.Error("expected an error for an invalid template")
+	}
+	if err := os.WriteFile(path, []byte("{{.Unknown}}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkFormat(TemplateFormat, &FormatOptions{TemplatePath: path}); err == nil {
+		t.Error("expected an error for a template which cannot be executed")
+	}
+}
@@ -0,0 +1,169 @@
+package credentials
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Keys of the credentials in a Kubernetes secret, the same as in the secret created by "rhoas cluster connect"
+const (
+	SecretClientIDKey            = "client-id"
+	SecretClientSecretKey        = "client-secret"
+	SecretBootstrapServerHostKey = "bootstrap-server-host"
+	SecretTokenURLKey            = "token-url"
+)
+
+// secretKeys are the keys of a secret, in the order in which they are written to manifests
+var secretKeys = []string{SecretClientIDKey, SecretClientSecretKey, SecretBootstrapServerHostKey, SecretTokenURLKey}
+
+// SealedSecretResource is the resource of the SealedSecrets of the Sealed Secrets controller
+var SealedSecretResource = schema.GroupVersionResource{
+	Group:    "bitnami.com",
+	Version:  "v1alpha1",
+	Resource: "sealedsecrets",
+}
+
+// SecretSink writes the credentials into a Kubernetes secret.
+// When SealingKey is set, a SealedSecret is created instead,
+// which only the Sealed Secrets controller of the cluster can decrypt
+type SecretSink struct {
+	Clientset     kubernetes.Interface
+	DynamicClient dynamic.Interface
+	Namespace     string
+	Name          string
+	// Overwrite allows an existing secret to be replaced
+	Overwrite bool
+	// SealingKey is the public key of the Sealed Secrets controller
+	SealingKey *rsa.PublicKey
+}
+
+// Location returns the kind, namespace and name of the secret
+func (s *SecretSink) Location() string {
+	kind := "Secret"
+	if s.SealingKey != nil {
+		kind = "SealedSecret"
+	}
+	return fmt.Sprintf("%v %v/%v", kind, s.Namespace, s.Name)
+}
+
+// Check returns ErrAlreadyExists when the secret exists and cannot be overwritten
+func (s *SecretSink) Check(ctx context.Context) error {
+	var err error
+	if s.SealingKey != nil {
+		_, err = s.DynamicClient.Resource(SealedSecretResource).Namespace(s.Namespace).Get(ctx, s.Name, metav1.GetOptions{})
+	} else {
+		_, err = s.Clientset.CoreV1().Secrets(s.Namespace).Get(ctx, s.Name, metav1.GetOptions{})
+	}
+
+	switch {
+	case kerrors.IsNotFound(err):
+		return nil
+	case err != nil:
+		return err
+	case !s.Overwrite:
+		return ErrAlreadyExists
+	default:
+		return nil
+	}
+}
+
+// Write creates the secret, or replaces it when Overwrite is set
+func (s *SecretSink) Write(ctx context.Context, creds *Credentials) error {
+	if s.SealingKey != nil {
+		return s.writeSealedSecret(ctx, creds)
+	}
+
+	secrets := s.Clientset.CoreV1().Secrets(s.Namespace)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.Name,
+			Namespace: s.Namespace,
+		},
+		Type:       corev1.SecretTypeOpaque,
+		StringData: secretData(creds),
+	}
+
+	_, err := secrets.Create(ctx, secret, metav1.CreateOptions{})
+	if !kerrors.IsAlreadyExists(err) || !s.Overwrite {
+		return err
+	}
+
+	existing, err := secrets.Get(ctx, s.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	secret.ResourceVersion = existing.ResourceVersion
+	_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+	return err
+}
+
+func (s *SecretSink) writeSealedSecret(ctx context.Context, creds *Credentials) error {
+	encryptedData := map[string]interface{}{}
+	for key, value := range secretData(creds) {
+		sealed, err := seal(randReader, s.SealingKey, s.Namespace, s.Name, []byte(value))
+		if err != nil {
+			return err
+		}
+		encryptedData[key] = base64.StdEncoding.EncodeToString(sealed)
+	}
+
+	metadata := map[string]interface{}{
+		"name":      s.Name,
+		"namespace": s.Namespace,
+	}
+	sealedSecret := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": SealedSecretResource.GroupVersion().String(),
+			"kind":       "SealedSecret",
+			"metadata":   metadata,
+			"spec": map[string]interface{}{
+				"encryptedData": encryptedData,
+				"template": map[string]interface{}{
+					"metadata": metadata,
+					"type":     string(corev1.SecretTypeOpaque),
+				},
+			},
+		},
+	}
+
+	sealedSecrets := s.DynamicClient.Resource(SealedSecretResource).Namespace(s.Namespace)
+
+	_, err := sealedSecrets.Create(ctx, sealedSecret, metav1.CreateOptions{})
+	if !kerrors.IsAlreadyExists(err) || !s.Overwrite {
+		return err
+	}
+
+	existing, err := sealedSecrets.Get(ctx, s.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	sealedSecret.SetResourceVersion(existing.GetResourceVersion())
+	_, err = sealedSecrets.Update(ctx, sealedSecret, metav1.UpdateOptions{})
+	return err
+}
+
+// secretData returns the data of the secret storing the credentials.
+// The Kafka instance is only included when one is selected
+func secretData(creds *Credentials) map[string]string {
+	data := map[string]string{
+		SecretClientIDKey:     creds.ClientID,
+		SecretClientSecretKey: creds.ClientSecret,
+	}
+	if creds.BootstrapServerHost != "" {
+		data[SecretBootstrapServerHostKey] = creds.BootstrapServerHost
+	}
+	if creds.TokenURL != "" {
+		data[SecretTokenURLKey] = creds.TokenURL
+	}
+	return data
+}
@@ -0,0 +1,87 @@
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// sessionKeyLength is the length of the AES-256 key which encrypts a sealed value
+const sessionKeyLength = 32
+
+// randReader is the source of the session keys, replaced in tests
+var randReader io.Reader = rand.Reader
+
+// ParseSealingKey parses the public key of a Sealed Secrets controller,
+// either from the certificate printed by "kubeseal --fetch-cert" or from a PEM encoded public key
+func ParseSealingKey(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	var key interface{}
+	switch block.Type {
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key = cert.PublicKey
+	case "PUBLIC KEY":
+		var err error
+		if key, err = x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+			return nil, err
+		}
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("the public key is not an RSA key")
+	}
+	return rsaKey, nil
+}
+
+// seal encrypts a value of the secret namespace/name the way the Sealed Secrets controller
+// decrypts the values of the strict scope: a random AES-GCM session key encrypts the value,
+// and is itself encrypted with RSA-OAEP, labelled with the namespace and name of the secret
+func seal(rnd io.Reader, key *rsa.PublicKey, namespace string, name string, value []byte) ([]byte, error) {
+	sessionKey := make([]byte, sessionKeyLength)
+	if _, err := io.ReadFull(rnd, sessionKey); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(sessionKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	label := []byte(namespace + "/" + name)
+	encryptedKey, err := rsa.EncryptOAEP(sha256.New(), rnd, key, sessionKey, label)
+	if err != nil {
+		return nil, err
+	}
+
+	// the session key is only used once, so the nonce can be zero
+	ciphertext := make([]byte, 2, 2+len(encryptedKey)+len(value)+aead.Overhead())
+	binary.BigEndian.PutUint16(ciphertext, uint16(len(encryptedKey)))
+	ciphertext = append(ciphertext, encryptedKey...)
+
+	return aead.Seal(ciphertext, make([]byte, aead.NonceSize()), value, nil), nil
+}
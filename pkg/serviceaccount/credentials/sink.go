@@ -0,0 +1,87 @@
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+)
+
+// ErrAlreadyExists is returned by Sink.Check when writing the credentials
+// would overwrite existing credentials without permission
+var ErrAlreadyExists = errors.New("credentials already exist")
+
+// Sink is a target which the credentials of a service account are written to.
+// New targets are supported by implementing this interface
+type Sink interface {
+	// Location describes where the credentials are written, such as the path of a file
+	Location() string
+	// Check returns an error when the credentials could not be written.
+	// It is called before the credentials are created, so they are not lost
+	Check(ctx context.Context) error
+	// Write writes the credentials
+	Write(ctx context.Context, creds *Credentials) error
+}
+
+// FileSink writes the credentials to a local file
+type FileSink struct {
+	// Format is the format of the file, such as "env"
+	Format        string
+	FormatOptions *FormatOptions
+	Path          string
+	// Overwrite allows an existing file to be overwritten
+	Overwrite bool
+}
+
+// Location returns the path of the file
+func (s *FileSink) Location() string {
+	return s.Path
+}
+
+// Check returns ErrAlreadyExists when the file exists and cannot be overwritten
+func (s *FileSink) Check(_ context.Context) error {
+	if err := checkFormat(s.Format, s.FormatOptions); err != nil {
+		return err
+	}
+	if _, err := os.Stat(os.ExpandEnv(s.Path)); err == nil && !s.Overwrite {
+		return ErrAlreadyExists
+	}
+	return nil
+}
+
+// Write saves the credentials to the file
+func (s *FileSink) Write(_ context.Context, creds *Credentials) error {
+	return Write(s.Format, s.Path, creds, s.FormatOptions)
+}
+
+// StdoutSink prints the credentials, so they can be piped to another program
+type StdoutSink struct {
+	Out io.Writer
+	// Format is the format of the credentials, such as "env"
+	Format        string
+	FormatOptions *FormatOptions
+}
+
+// Location returns "stdout"
+func (s *StdoutSink) Location() string {
+	return "stdout"
+}
+
+// Check returns an error when the credentials cannot be encoded, as nothing is overwritten
+func (s *StdoutSink) Check(_ context.Context) error {
+	return checkFormat(s.Format, s.FormatOptions)
+}
+
+// Write prints the credentials
+func (s *StdoutSink) Write(_ context.Context, creds *Credentials) error {
+	var buf bytes.Buffer
+	if err := Encode(&buf, s.Format, creds, s.FormatOptions); err != nil {
+		return err
+	}
+	if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	_, err := s.Out.Write(buf.Bytes())
+	return err
+}
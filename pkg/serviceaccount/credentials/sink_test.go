@@ -0,0 +1,252 @@
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var testCredentials = &Credentials{ClientID: "srvc-acct-1", ClientSecret: `se"cr\et`}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	sink := &FileSink{Format: "env", Path: path}
+
+	if err := sink.Check(context.Background()); err != nil {
+		t.Fatalf("unexpected error for a missing file: %v", err)
+	}
+	if err := sink.Write(context.Background(), testCredentials); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Check(context.Background()); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists for an existing file, got %v", err)
+	}
+
+	sink.Overwrite = true
+	if err := sink.Check(context.Background()); err != nil {
+		t.Errorf("unexpected error when overwriting: %v", err)
+	}
+}
+
+func TestStdoutSink(t *testing.T) {
+	out := &bytes.Buffer{}
+	sink := &StdoutSink{Out: out, Format: "json"}
+
+	if err := sink.Write(context.Background(), testCredentials); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "srvc-acct-1") || !strings.HasSuffix(out.String(), "\n") {
+		t.Errorf("unexpected output %q", out.String())
+	}
+}
+
+func TestSecretSink(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset()
+	sink := &SecretSink{Clientset: clientset, Namespace: "my-ns", Name: "my-app"}
+
+	if err := sink.Check(ctx); err != nil {
+		t.Fatalf("unexpected error for a missing secret: %v", err)
+	}
+	if err := sink.Write(ctx, testCredentials); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Check(ctx); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists for an existing secret, got %v", err)
+	}
+	if err := sink.Write(ctx, testCredentials); err == nil {
+		t.Error("expected an error when writing an existing secret without overwriting it")
+	}
+
+	sink.Overwrite = true
+	if err := sink.Write(ctx, &Credentials{ClientID: "srvc-acct-1", ClientSecret: "new"}); err != nil {
+		t.Fatal(err)
+	}
+	secret, err := clientset.CoreV1().Secrets("my-ns").Get(ctx, "my-app", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secret.StringData[SecretClientIDKey] != "srvc-acct-1" || secret.StringData[SecretClientSecretKey] != "new" {
+		t.Errorf("unexpected secret data %v", secret.StringData)
+	}
+}
+
+func TestSealedSecretSink(t *testing.T) {
+	ctx := context.Background()
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	sink := &SecretSink{DynamicClient: dynamicClient, Namespace: "my-ns", Name: "my-app", SealingKey: &privateKey.PublicKey}
+
+	if err = sink.Check(ctx); err != nil {
+		t.Fatalf("unexpected error for a missing sealed secret: %v", err)
+	}
+	if err = sink.Write(ctx, testCredentials); err != nil {
+		t.Fatal(err)
+	}
+	if err = sink.Check(ctx); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists for an existing sealed secret, got %v", err)
+	}
+
+	sealedSecret, err := dynamicClient.Resource(SealedSecretResource).Namespace("my-ns").Get(ctx, "my-app", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, _, _ := unstructured.NestedString(sealedSecret.Object, "spec", "encryptedData", SecretClientSecretKey)
+	ciphertext, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := unseal(privateKey, "my-ns/my-app", ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(value) != testCredentials.ClientSecret {
+		t.Errorf("unsealed the value %q, want %q", value, testCredentials.ClientSecret)
+	}
+	if _, err = unseal(privateKey, "other-ns/my-app", ciphertext); err == nil {
+		t.Error("expected the value to be sealed for the namespace and name of the secret only")
+	}
+}
+
+func TestParseSealingKey(t *testing.T) {
+	if _, err := ParseSealingKey([]byte("not a certificate")); err == nil {
+		t.Error("expected an error for data which is not PEM encoded")
+	}
+}
+
+// unseal decrypts a value sealed by seal, the same way as the Sealed Secrets controller
+func unseal(key *rsa.PrivateKey, label string, ciphertext []byte) ([]byte, error) {
+	keyLength := int(binary.BigEndian.Uint16(ciphertext))
+	sessionKey, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, key, ciphertext[2:2+keyLength], []byte(label))
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(sessionKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Open(nil, make([]byte, aead.NonceSize()), ciphertext[2+keyLength:], nil)
+}
+
+// fakeVault is a Vault server with a single key/value secrets engine mounted at "secret/"
+type fakeVault struct {
+	mu      sync.Mutex
+	version string
+	secrets map[string]map[string]interface{}
+}
+
+func (v *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if r.Header.Get("X-Vault-Token") != "root" {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	if strings.HasPrefix(path, "sys/internal/ui/mounts/") {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"path": "secret/", "options": map[string]interface{}{"version": v.version}},
+		})
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		secret, ok := v.secrets[path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": secret})
+		return
+	}
+
+	var body map[string]interface{}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	if v.version == "2" {
+		if options, ok := body["options"].(map[string]interface{}); ok && options["cas"] == float64(0) && v.secrets[path] != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":["check-and-set parameter did not match the current version"]}`))
+			return
+		}
+		body = body["data"].(map[string]interface{})
+	}
+	v.secrets[path] = body
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestVaultSink(t *testing.T) {
+	tests := []struct {
+		version  string
+		wantPath string
+	}{
+		{version: "1", wantPath: "secret/my-app"},
+		{version: "2", wantPath: "secret/data/my-app"},
+	}
+	for _, tt := range tests {
+		t.Run("version "+tt.version, func(t *testing.T) {
+			ctx := context.Background()
+			vault := &fakeVault{version: tt.version, secrets: map[string]map[string]interface{}{}}
+			server := httptest.NewServer(vault)
+			defer server.Close()
+
+			sink := &VaultSink{Address: server.URL, Token: "root", Path: "/secret/my-app"}
+
+			if err := sink.Check(ctx); err != nil {
+				t.Fatalf("unexpected error for a missing secret: %v", err)
+			}
+			if err := sink.Write(ctx, testCredentials); err != nil {
+				t.Fatal(err)
+			}
+			if got := vault.secrets[tt.wantPath]["client_secret"]; got != testCredentials.ClientSecret {
+				t.Errorf("unexpected client secret %v in %v", got, vault.secrets)
+			}
+			if err := sink.Check(ctx); !errors.Is(err, ErrAlreadyExists) {
+				t.Errorf("expected ErrAlreadyExists for an existing secret, got %v", err)
+			}
+
+			sink.Overwrite = true
+			if err := sink.Write(ctx, &Credentials{ClientID: "srvc-acct-1", ClientSecret: "new"}); err != nil {
+				t.Fatal(err)
+			}
+			if got := vault.secrets[tt.wantPath]["client_secret"]; got != "new" {
+				t.Errorf("expected the secret to be overwritten, got %v", got)
+			}
+
+			sink.Token = "invalid"
+			if err := sink.Write(ctx, testCredentials); err == nil || !strings.Contains(err.Error(), "permission denied") {
+				t.Errorf("expected the error of Vault, got %v", err)
+			}
+		})
+	}
+}
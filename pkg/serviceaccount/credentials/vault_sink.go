@@ -0,0 +1,160 @@
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// VaultSink writes the credentials into a HashiCorp Vault key/value secrets engine,
+// using the Vault HTTP API. Both versions of the engine are supported
+type VaultSink struct {
+	// Address is the address of the Vault server, such as "https://vault.example.com:8200"
+	Address string
+	Token   string
+	// Path is the path of the secret, including the path of the secrets engine, such as "secret/my-app"
+	Path string
+	// Overwrite allows an existing secret to be replaced
+	Overwrite  bool
+	HTTPClient *http.Client
+}
+
+// vaultMount is the secrets engine which a path belongs to
+type vaultMount struct {
+	// Path is the path of the secrets engine, ending with a slash
+	Path    string `json:"path"`
+	Options struct {
+		Version string `json:"version"`
+	} `json:"options"`
+}
+
+// Location returns the address of the server and the path of the secret
+func (s *VaultSink) Location() string {
+	return fmt.Sprintf("%v/%v", strings.TrimSuffix(s.Address, "/"), s.path())
+}
+
+// Check returns ErrAlreadyExists when the secret exists and cannot be overwritten
+func (s *VaultSink) Check(ctx context.Context) error {
+	mount, err := s.mount(ctx)
+	if err != nil {
+		return err
+	}
+	if s.Overwrite {
+		return nil
+	}
+
+	status, _, err := s.do(ctx, http.MethodGet, s.dataPath(mount), nil)
+	switch {
+	case err != nil:
+		return err
+	case status == http.StatusNotFound:
+		return nil
+	default:
+		return ErrAlreadyExists
+	}
+}
+
+// Write writes the credentials into the secret
+func (s *VaultSink) Write(ctx context.Context, creds *Credentials) error {
+	mount, err := s.mount(ctx)
+	if err != nil {
+		return err
+	}
+
+	var body interface{} = creds
+	if mount.Options.Version == "2" {
+		data := map[string]interface{}{"data": creds}
+		if !s.Overwrite {
+			// a check-and-set version of 0 only allows the secret to be written if it does not exist
+			data["options"] = map[string]interface{}{"cas": 0}
+		}
+		body = data
+	}
+
+	_, _, err = s.do(ctx, http.MethodPost, s.dataPath(mount), body)
+	return err
+}
+
+// mount finds the secrets engine of the path, the same way as the Vault CLI.
+// The path is assumed to belong to a version 1 engine when Vault cannot tell
+func (s *VaultSink) mount(ctx context.Context) (*vaultMount, error) {
+	var res struct {
+		Data vaultMount `json:"data"`
+	}
+	status, resBody, err := s.do(ctx, http.MethodGet, "sys/internal/ui/mounts/"+s.path(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return &vaultMount{}, nil
+	}
+	if err = json.Unmarshal(resBody, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// dataPath returns the API path of the secret, which version 2 engines prefix with "data/"
+func (s *VaultSink) dataPath(mount *vaultMount) string {
+	path := s.path()
+	if mount.Options.Version != "2" {
+		return path
+	}
+	return mount.Path + "data/" + strings.TrimPrefix(path, mount.Path)
+}
+
+func (s *VaultSink) path() string {
+	return strings.Trim(s.Path, "/")
+}
+
+// do sends a request to the Vault API.
+// A not found response is not an error, so it returns the status along with the body of the response
+func (s *VaultSink) do(ctx context.Context, method string, path string, body interface{}) (int, []byte, error) {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, nil, err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(s.Address, "/")+"/v1/"+path, reqBody)
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("X-Vault-Token", s.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	if res.StatusCode < 300 || res.StatusCode == http.StatusNotFound {
+		return res.StatusCode, resBody, nil
+	}
+
+	var vaultErr struct {
+		Errors []string `json:"errors"`
+	}
+	if json.Unmarshal(resBody, &vaultErr) == nil && len(vaultErr.Errors) > 0 {
+		return 0, nil, fmt.Errorf("vault responded with %v: %v", res.Status, strings.Join(vaultErr.Errors, "; "))
+	}
+	return 0, nil, fmt.Errorf("vault responded with %v", res.Status)
+}
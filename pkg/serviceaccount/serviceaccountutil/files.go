@@ -0,0 +1,70 @@
+package serviceaccountutil
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// maxCredentialsFileSize is the size above which files are not searched for client IDs,
+// as credentials files are small
+const maxCredentialsFileSize = 1 << 20
+
+// FindClientIDsInFiles returns the client IDs which appear in the files at the paths.
+// The files of the directories are searched too, but not the files of their sub-directories.
+// Every credentials file format written by the CLI contains the client ID as is, so the content of the files is searched
+// instead of parsing them
+func FindClientIDsInFiles(paths []string, clientIDs []string) (map[string]bool, error) {
+	found := map[string]bool{}
+	for _, path := range paths {
+		path = os.ExpandEnv(path)
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		files := []string{path}
+		if info.IsDir() {
+			entries, err := os.ReadDir(path)
+			if err != nil {
+				return nil, err
+			}
+			files = files[:0]
+			for _, entry := range entries {
+				if entry.Type().IsRegular() {
+					files = append(files, filepath.Join(path, entry.Name()))
+				}
+			}
+		}
+
+		for _, file := range files {
+			if err := findClientIDsInFile(file, clientIDs, found); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return found, nil
+}
+
+func findClientIDsInFile(file string, clientIDs []string, found map[string]bool) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+	if info.Size() > maxCredentialsFileSize {
+		return nil
+	}
+
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	for _, clientID := range clientIDs {
+		if clientID != "" && bytes.Contains(content, []byte(clientID)) {
+			found[clientID] = true
+		}
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package serviceaccountutil
+
+import (
+	"context"
+
+	"github.com/redhat-developer/app-services-cli/internal/config"
+	"github.com/redhat-developer/app-services-cli/pkg/clientconfig"
+	"github.com/redhat-developer/app-services-cli/pkg/kafka"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+)
+
+// KafkaDetails returns the bootstrap server host of the selected Kafka instance and the token endpoint
+// which issues tokens for the service accounts, which are saved along with the credentials.
+// Both are empty when no Kafka instance is selected
+func KafkaDetails(ctx context.Context, api kafkamgmtclient.DefaultApi, cfg *config.Config) (bootstrapServerHost string, tokenURL string, err error) {
+	// the service accounts are not used by the local development environment
+	if !cfg.HasKafka() || cfg.IsLocalProfile() {
+		return "", "", nil
+	}
+
+	kafkaInstance, _, err := kafka.GetKafkaByID(ctx, api, cfg.Services.Kafka.ClusterID)
+	if err != nil {
+		return "", "", err
+	}
+
+	return kafkaInstance.GetBootstrapServerHost(), clientconfig.TokenURL(cfg), nil
+}
@@ -0,0 +1,102 @@
+// Package serviceaccountutil contains helpers to find, filter and sort service accounts
+package serviceaccountutil
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+)
+
+// GeneratedNamePrefix is the prefix of the names of the service accounts which the CLI creates on its own,
+// such as when connecting a Kafka instance to a Kubernetes cluster
+const GeneratedNamePrefix = "rhoascli-"
+
+var generatedNameRegexp = regexp.MustCompile("^" + GeneratedNamePrefix + `\d+$`)
+
+// NewGeneratedName returns the name of a service account the CLI creates on its own at the given time
+func NewGeneratedName(t time.Time) string {
+	return fmt.Sprintf("%v%v", GeneratedNamePrefix, t.Unix())
+}
+
+// IsGeneratedName returns true if the name is one the CLI gives to the service accounts it creates on its own
+func IsGeneratedName(name string) bool {
+	return generatedNameRegexp.MatchString(name)
+}
+
+// Fields the service accounts can be sorted by
+const (
+	SortByName      = "name"
+	SortByOwner     = "owner"
+	SortByCreatedAt = "created-at"
+)
+
+// SortFields are the fields the service accounts can be sorted by
+var SortFields = []string{SortByName, SortByOwner, SortByCreatedAt}
+
+// Filter selects service accounts. Its empty fields select every service account
+type Filter struct {
+	// Search matches the service accounts whose name or client ID contains it, ignoring case
+	Search string
+	// Owner matches the service accounts owned by the user
+	Owner string
+	// CreatedBefore matches the service accounts created before the time
+	CreatedBefore *time.Time
+}
+
+// Apply returns the service accounts selected by the filter
+func (f *Filter) Apply(serviceAccounts []kafkamgmtclient.ServiceAccountListItem) []kafkamgmtclient.ServiceAccountListItem {
+	search := strings.ToLower(f.Search)
+
+	filtered := []kafkamgmtclient.ServiceAccountListItem{}
+	for _, sa := range serviceAccounts {
+		if search != "" && !strings.Contains(strings.ToLower(sa.GetName()), search) && !strings.Contains(strings.ToLower(sa.GetClientId()), search) {
+			continue
+		}
+		if f.Owner != "" && sa.GetOwner() != f.Owner {
+			continue
+		}
+		if f.CreatedBefore != nil && !sa.GetCreatedAt().Before(*f.CreatedBefore) {
+			continue
+		}
+		filtered = append(filtered, sa)
+	}
+	return filtered
+}
+
+// Sort sorts the service accounts by one of SortFields, in descending order when desc is set.
+// The service accounts with the same value keep their order
+func Sort(serviceAccounts []kafkamgmtclient.ServiceAccountListItem, field string, desc bool) {
+	less := func(i, j int) bool {
+		a, b := serviceAccounts[i], serviceAccounts[j]
+		switch field {
+		case SortByOwner:
+			return a.GetOwner() < b.GetOwner()
+		case SortByCreatedAt:
+			return a.GetCreatedAt().Before(b.GetCreatedAt())
+		default:
+			return a.GetName() < b.GetName()
+		}
+	}
+	if desc {
+		sort.SliceStable(serviceAccounts, func(i, j int) bool { return less(j, i) })
+	} else {
+		sort.SliceStable(serviceAccounts, less)
+	}
+}
+
+// Page returns a page of the service accounts. Pages start at 1
+func Page(serviceAccounts []kafkamgmtclient.ServiceAccountListItem, page int, size int) []kafkamgmtclient.ServiceAccountListItem {
+	start := (page - 1) * size
+	if start > len(serviceAccounts) {
+		start = len(serviceAccounts)
+	}
+	end := start + size
+	if end > len(serviceAccounts) {
+		end = len(serviceAccounts)
+	}
+	return serviceAccounts[start:end]
+}
@@ -0,0 +1,130 @@
+package serviceaccountutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+)
+
+func TestIsGeneratedName(t *testing.T) {
+	tests := map[string]bool{
+		NewGeneratedName(time.Unix(1625000000, 0)): true,
+		"rhoascli-1625000000":                      true,
+		"rhoascli-":                                false,
+		"rhoascli-abc":                             false,
+		"my-rhoascli-1625000000":                   false,
+		"my-service-account":                       false,
+	}
+	for name, want := range tests {
+		if got := IsGeneratedName(name); got != want {
+			t.Errorf("IsGeneratedName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func serviceAccount(name string, clientID string, owner string, createdAt time.Time) kafkamgmtclient.ServiceAccountListItem {
+	return kafkamgmtclient.ServiceAccountListItem{
+		Name:      kafkamgmtclient.PtrString(name),
+		ClientId:  kafkamgmtclient.PtrString(clientID),
+		Owner:     kafkamgmtclient.PtrString(owner),
+		CreatedAt: kafkamgmtclient.PtrTime(createdAt),
+	}
+}
+
+func names(serviceAccounts []kafkamgmtclient.ServiceAccountListItem) []string {
+	n := []string{}
+	for _, sa := range serviceAccounts {
+		n = append(n, sa.GetName())
+	}
+	return n
+}
+
+func TestFilterSortAndPage(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2021, 6, d, 0, 0, 0, 0, time.UTC) }
+	serviceAccounts := []kafkamgmtclient.ServiceAccountListItem{
+		serviceAccount("billing", "srvc-acct-1", "alice", day(3)),
+		serviceAccount("rhoascli-1", "srvc-acct-2", "bob", day(1)),
+		serviceAccount("Checkout", "srvc-acct-3", "alice", day(2)),
+	}
+
+	createdBefore := day(3)
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []string
+	}{
+		{name: "no filter", want: []string{"billing", "rhoascli-1", "Checkout"}},
+		{name: "search name ignoring case", filter: Filter{Search: "CHECK"}, want: []string{"Checkout"}},
+		{name: "search client ID", filter: Filter{Search: "acct-2"}, want: []string{"rhoascli-1"}},
+		{name: "owner", filter: Filter{Owner: "alice"}, want: []string{"billing", "Checkout"}},
+		{name: "created before", filter: Filter{CreatedBefore: &createdBefore}, want: []string{"rhoascli-1", "Checkout"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := names(tt.filter.Apply(serviceAccounts))
+			if len(got) != len(tt.want) {
+				t.Fatalf("Apply() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Apply() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	Sort(serviceAccounts, SortByCreatedAt, true)
+	if got := names(serviceAccounts); got[0] != "billing" || got[2] != "rhoascli-1" {
+		t.Errorf("unexpected order when sorting by creation time in descending order: %v", got)
+	}
+	Sort(serviceAccounts, SortByOwner, false)
+	if got := names(serviceAccounts); got[2] != "rhoascli-1" {
+		t.Errorf("unexpected order when sorting by owner: %v", got)
+	}
+
+	if got := names(Page(serviceAccounts, 2, 2)); len(got) != 1 {
+		t.Errorf("expected 1 service account on the last page, got %v", got)
+	}
+	if got := Page(serviceAccounts, 3, 2); len(got) != 0 {
+		t.Errorf("expected no service account after the last page, got %v", got)
+	}
+}
+
+func TestFindClientIDsInFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(dir, ".env"), []byte("CLIENT_ID=srvc-acct-1\nCLIENT_SECRET=secret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "nested"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "nested", "credentials.json"), []byte(`{"clientID":"srvc-acct-2"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	clientIDs := []string{"srvc-acct-1", "srvc-acct-2", "srvc-acct-3"}
+
+	found, err := FindClientIDsInFiles([]string{dir}, clientIDs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found["srvc-acct-1"] || found["srvc-acct-2"] || found["srvc-acct-3"] {
+		t.Errorf("unexpected client IDs found in the directory: %v", found)
+	}
+
+	found, err = FindClientIDsInFiles([]string{dir, filepath.Join(dir, "nested", "credentials.json")}, clientIDs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found["srvc-acct-1"] || !found["srvc-acct-2"] || found["srvc-acct-3"] {
+		t.Errorf("unexpected client IDs found in the directory and the file: %v", found)
+	}
+
+	if _, err = FindClientIDsInFiles([]string{filepath.Join(dir, "missing")}, clientIDs); err == nil {
+		t.Error("expected an error for a missing path")
+	}
+}
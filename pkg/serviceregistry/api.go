@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/redhat-developer/app-services-cli/pkg/common/clierr"
 	srsmgmtv1 "github.com/redhat-developer/app-services-sdk-go/registrymgmt/apiv1/client"
 )
 
@@ -26,7 +27,7 @@ func GetServiceRegistryByName(ctx context.Context, api srsmgmtv1.RegistriesApi,
 	}
 
 	if registryList.GetTotal() == 0 {
-		return nil, nil, fmt.Errorf(`Instance "%v" not found`, name)
+		return nil, nil, clierr.New(clierr.CodeNotFound, fmt.Errorf(`Instance "%v" not found`, name))
 	}
 
 	items := registryList.GetItems()
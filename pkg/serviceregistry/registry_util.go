@@ -4,6 +4,7 @@ import (
 	"errors"
 	"regexp"
 
+	"github.com/redhat-developer/app-services-cli/pkg/common/clierr"
 	"github.com/redhat-developer/app-services-cli/pkg/common/commonerr"
 )
 
@@ -18,7 +19,7 @@ func ValidateName(val interface{}) error {
 	}
 
 	if len(name) < 1 || len(name) > 32 {
-		return errors.New("ServiceRegistry instance name must be between 1 and 32 characters")
+		return clierr.New(clierr.CodeValidation, errors.New("ServiceRegistry instance name must be between 1 and 32 characters"))
 	}
 
 	matched := validNameRegexp.MatchString(name)
@@ -27,5 +28,5 @@ func ValidateName(val interface{}) error {
 		return nil
 	}
 
-	return errors.New("Invalid service registry name: " + name)
+	return clierr.New(clierr.CodeValidation, errors.New("Invalid service registry name: "+name))
 }